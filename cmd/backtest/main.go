@@ -0,0 +1,56 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"math"
+	"os"
+	"time"
+
+	"github.com/hunterjsb/tft/internal/dotenv"
+	"github.com/hunterjsb/tft/internal/riot"
+)
+
+func main() {
+	// Load environment variables using custom dotenv module
+	if err := dotenv.LoadDefault(); err != nil {
+		fmt.Printf("Warning: Error loading .env file: %v\n", err)
+	}
+
+	model := riot.NewPlacementModel()
+	dir := flag.String("dir", os.Getenv("MATCH_ARCHIVE_DIR"), "match archive directory")
+	minHistory := flag.Int("min-history", 4, "participants with prior games needed to predict a match")
+	flag.Float64Var(&model.Scale, "scale", model.Scale, "rating per place better than average")
+	flag.Float64Var(&model.PriorGames, "prior", model.PriorGames, "pseudo-games shrinking ratings toward average")
+	rankWeight := flag.Float64("rank-weight", 0, "rating per tier above Gold I, from current ranks looked up with the Riot API (0 disables)")
+	flag.Parse()
+
+	// Ranks are looked up today, so they are only an approximation of each player's rank at the time
+	if *rankWeight != 0 {
+		ranks := riot.NewLeagueRanks()
+		ranks.Spacing = 1500 * time.Millisecond
+		model.RankOf = riot.RankRating(ranks, *rankWeight)
+	}
+
+	archive, err := riot.NewMatchArchive(*dir)
+	if err != nil {
+		fmt.Printf("Error opening match archive: %v\n", err)
+		os.Exit(1)
+	}
+
+	report, err := archive.BacktestPlacementModel(model, *minHistory)
+	if err != nil {
+		fmt.Printf("Error running backtest: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Matches: %d • Predictions: %d\n", report.Matches, report.Predictions)
+	fmt.Printf("Mean absolute error: %.3f places\n", report.MeanAbsoluteError)
+	fmt.Printf("Log loss: %.3f (uniform 8-player lobby: %.3f)\n", report.LogLoss, math.Log(8))
+	fmt.Printf("Top 4 Brier: %.3f\n", report.TopFourBrier)
+	fmt.Println("\nTop 4 calibration:")
+	for _, bin := range report.Calibration {
+		fmt.Printf("  %3.0f-%3.0f%%  n=%-5d predicted %5.1f%%  observed %5.1f%%\n",
+			bin.Lower*100, bin.Upper*100, bin.Count, bin.AvgPredicted*100, bin.Observed*100)
+	}
+}
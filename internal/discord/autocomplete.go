@@ -0,0 +1,161 @@
+package discord
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hunterjsb/tft/internal/riot"
+)
+
+// Autocomplete limits
+const (
+	maxAutocompleteChoices = 25 // Discord's limit
+	maxRecentLookups       = 50 // per guild
+)
+
+// PlayerHistory remembers Riot IDs seen by the bot so player options can suggest them
+type PlayerHistory struct {
+	mu      sync.Mutex
+	lookups map[string][]string // guild ID -> Riot IDs looked up there, most recent first
+	lobbies map[string][]string // Discord user ID -> Riot IDs from their latest /lobby
+}
+
+// NewPlayerHistory creates an empty history
+func NewPlayerHistory() *PlayerHistory {
+	return &PlayerHistory{
+		lookups: make(map[string][]string),
+		lobbies: make(map[string][]string),
+	}
+}
+
+// AddLookup records a Riot ID looked up in a guild ("" for DMs)
+func (h *PlayerHistory) AddLookup(guildID, riotID string) {
+	if h == nil || riotID == "" {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	recent := []string{riotID}
+	for _, id := range h.lookups[guildID] {
+		if !strings.EqualFold(id, riotID) && len(recent) < maxRecentLookups {
+			recent = append(recent, id)
+		}
+	}
+	h.lookups[guildID] = recent
+}
+
+// SetLobby records the Riot IDs from a user's latest lobby
+func (h *PlayerHistory) SetLobby(userID string, riotIDs []string) {
+	if h == nil || userID == "" {
+		return
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	h.lobbies[userID] = append([]string(nil), riotIDs...)
+}
+
+// Lookups returns the Riot IDs recently looked up in a guild, most recent first
+func (h *PlayerHistory) Lookups(guildID string) []string {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string(nil), h.lookups[guildID]...)
+}
+
+// Lobby returns the Riot IDs from a user's latest lobby
+func (h *PlayerHistory) Lobby(userID string) []string {
+	if h == nil {
+		return nil
+	}
+	h.mu.Lock()
+	defer h.mu.Unlock()
+	return append([]string(nil), h.lobbies[userID]...)
+}
+
+// handleAutocomplete answers autocomplete requests for Riot ID and region options
+func (b *DiscordBot) handleAutocomplete(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	var choices []*discordgo.ApplicationCommandOptionChoice
+	options := i.ApplicationCommandData().Options
+	// Subcommands such as /config region nest their options
+	if len(options) == 1 && options[0].Type == discordgo.ApplicationCommandOptionSubCommand {
+		options = options[0].Options
+	}
+	for _, option := range options {
+		if !option.Focused {
+			continue
+		}
+		typed, _ := option.Value.(string)
+		switch {
+		case option.Name == "riotid" || strings.HasSuffix(option.Name, "_riotid"):
+			choices = b.riotIDSuggestions(i.GuildID, interactionUserID(i), typed)
+		case option.Name == "region":
+			choices = regionSuggestions(typed)
+		}
+	}
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionApplicationCommandAutocompleteResult,
+		Data: &discordgo.InteractionResponseData{Choices: choices},
+	}); err != nil {
+		fmt.Printf("Error responding to autocomplete: %v\n", err)
+	}
+}
+
+// riotIDSuggestions suggests Riot IDs matching typed: accounts linked in the guild first,
+// then players from the caller's latest lobby, then recent lookups in the guild
+func (b *DiscordBot) riotIDSuggestions(guildID, userID, typed string) []*discordgo.ApplicationCommandOptionChoice {
+	typed = strings.ToLower(strings.TrimSpace(typed))
+	seen := make(map[string]bool)
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+
+	add := func(riotID, source string) {
+		key := strings.ToLower(riotID)
+		if riotID == "" || seen[key] || len(choices) >= maxAutocompleteChoices || !strings.Contains(key, typed) {
+			return
+		}
+		seen[key] = true
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  fmt.Sprintf("%s • %s", riotID, source),
+			Value: riotID,
+		})
+	}
+
+	if b.Links != nil && guildID != "" {
+		for _, key := range b.Links.Keys() {
+			if link, ok := b.Links.Get(key); ok && link.inGuild(guildID) {
+				add(link.RiotID(), "linked")
+			}
+		}
+	}
+	for _, riotID := range b.History.Lobby(userID) {
+		add(riotID, "your lobby")
+	}
+	for _, riotID := range b.History.Lookups(guildID) {
+		add(riotID, "recent")
+	}
+	return choices
+}
+
+// regionSuggestions suggests platforms whose code or name matches typed
+func regionSuggestions(typed string) []*discordgo.ApplicationCommandOptionChoice {
+	typed = strings.ToLower(strings.TrimSpace(typed))
+	choices := []*discordgo.ApplicationCommandOptionChoice{}
+	for _, platform := range riot.Platforms {
+		if typed != "" && !strings.Contains(strings.ToLower(platform.Code), typed) && !strings.Contains(strings.ToLower(platform.Name), typed) {
+			continue
+		}
+		choices = append(choices, &discordgo.ApplicationCommandOptionChoice{
+			Name:  fmt.Sprintf("%s (%s)", platform.Name, platform.Code),
+			Value: platform.Code,
+		})
+		if len(choices) >= maxAutocompleteChoices {
+			break
+		}
+	}
+	return choices
+}
@@ -0,0 +1,71 @@
+package discord
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hunterjsb/tft/internal/riot"
+	"github.com/hunterjsb/tft/internal/store"
+)
+
+func TestRiotIDSuggestions(t *testing.T) {
+	links, _ := store.Open[LinkedAccount]("")
+	_ = links.Set("a", LinkedAccount{GameName: "Koalafied", TagLine: "NA1", GuildIDs: []string{"guild"}})
+	_ = links.Set("b", LinkedAccount{GameName: "Elsewhere", TagLine: "NA1", GuildIDs: []string{"other"}})
+	bot := &DiscordBot{Links: links, History: NewPlayerHistory()}
+	bot.History.SetLobby("caller", []string{"GoldGoblin#NA1", "koalafied#na1"})
+	bot.History.AddLookup("guild", "PivotPete#0001")
+	bot.History.AddLookup("guild", "GoldGoblin#NA1")
+	bot.History.AddLookup("other", "Hidden#NA1")
+
+	choices := bot.riotIDSuggestions("guild", "caller", "")
+	var got []string
+	for _, choice := range choices {
+		got = append(got, choice.Name)
+	}
+	want := []string{"Koalafied#NA1 • linked", "GoldGoblin#NA1 • your lobby", "PivotPete#0001 • recent"}
+	if len(got) != len(want) {
+		t.Fatalf("Expected %v, got %v", want, got)
+	}
+	for idx := range want {
+		if got[idx] != want[idx] {
+			t.Errorf("Choice %d: expected %q, got %q", idx, want[idx], got[idx])
+		}
+	}
+
+	if filtered := bot.riotIDSuggestions("guild", "caller", "PIVOT"); len(filtered) != 1 || filtered[0].Value != "PivotPete#0001" {
+		t.Errorf("Expected case-insensitive filtering, got %v", filtered)
+	}
+}
+
+func TestRegionSuggestions(t *testing.T) {
+	if all := regionSuggestions(""); len(all) != len(riot.Platforms) {
+		t.Errorf("Expected every platform, got %d", len(all))
+	}
+	europe := regionSuggestions("europe")
+	if len(europe) != 2 || europe[0].Value != "EUW1" {
+		t.Errorf("Expected the two European platforms by name, got %v", europe)
+	}
+	if kr := regionSuggestions("kr"); len(kr) != 1 || kr[0].Name != "Korea (KR)" {
+		t.Errorf("Expected Korea by code, got %v", kr)
+	}
+}
+
+func TestPlayerHistory_AddLookup(t *testing.T) {
+	history := NewPlayerHistory()
+	for i := 0; i < maxRecentLookups+5; i++ {
+		history.AddLookup("guild", fmt.Sprintf("Player%d#NA1", i))
+	}
+	history.AddLookup("guild", "player3#na1")
+
+	lookups := history.Lookups("guild")
+	if len(lookups) != maxRecentLookups || lookups[0] != "player3#na1" || lookups[1] != fmt.Sprintf("Player%d#NA1", maxRecentLookups+4) {
+		t.Errorf("Expected a capped, deduplicated most-recent-first list, got %v", lookups[:3])
+	}
+
+	var nilHistory *PlayerHistory
+	nilHistory.AddLookup("guild", "x#y")
+	if nilHistory.Lookups("guild") != nil {
+		t.Error("Expected nil history to be empty")
+	}
+}
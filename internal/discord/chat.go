@@ -8,6 +8,11 @@ import (
 	"github.com/bwmarrin/discordgo"
 )
 
+// chatOptions are the /chat options
+type chatOptions struct {
+	Prompt string `option:"prompt" required:"true"`
+}
+
 // handleChatCommand handles the /chat command
 func (b *DiscordBot) handleChatCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	// Acknowledge the interaction immediately
@@ -19,11 +24,18 @@ func (b *DiscordBot) handleChatCommand(s *discordgo.Session, i *discordgo.Intera
 	}
 
 	// Get the prompt option
-	options := i.ApplicationCommandData().Options
-	promptOption := options[0].StringValue()
+	var opts chatOptions
+	if !b.bindOptions(s, i, &opts) {
+		return // Error already sent to Discord
+	}
+
+	if !b.aiEnabled(i.GuildID) {
+		b.sendError(s, i, "Feature Disabled", "AI responses are turned off in this server")
+		return
+	}
 
 	// Generate response from OpenAI
-	response, err := b.OpenAI.GenerateResponse(context.Background(), promptOption)
+	response, err := b.OpenAI.GenerateResponse(context.Background(), b.localizePrompt(i.GuildID, opts.Prompt))
 	if err != nil {
 		fmt.Printf("Error generating response: %v\n", err)
 		b.sendError(s, i, "AI Error", "Sorry, I couldn't process your request.")
@@ -0,0 +1,218 @@
+package discord
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hunterjsb/tft/internal/riot"
+)
+
+// compareOptions are the /compare options besides the two players
+type compareOptions struct {
+	Commentary bool `option:"commentary" default:"true"` // only used when AI analysis is configured and enabled
+}
+
+// handleCompareCommand handles the /compare command
+func (b *DiscordBot) handleCompareCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	// Acknowledge the interaction immediately
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}); err != nil {
+		fmt.Printf("Error acknowledging interaction: %v\n", err)
+		return
+	}
+
+	var opts compareOptions
+	if !b.bindOptions(s, i, &opts) {
+		return // Error already sent to Discord
+	}
+
+	// Parse both players' parameters
+	options := i.ApplicationCommandData().Options
+	params := ParsePlayerParams(options)
+	otherParams := ParsePrefixedPlayerParams(options, "other")
+
+	// Look up both accounts
+	playerResult, err := b.LookupPlayer(s, i, params)
+	if err != nil {
+		return // Error already sent to Discord
+	}
+	otherResult, err := b.LookupPlayer(s, i, otherParams)
+	if err != nil {
+		return // Error already sent to Discord
+	}
+
+	analyzer := b.newProfileAnalyzer()
+	profile, err := analyzer.AnalyzePlayer(playerResult.Account.PUUID)
+	if err != nil {
+		b.sendError(s, i, "Analysis Error", fmt.Sprintf("Could not analyze `%s`: %v", playerResult.GetDisplayName(), err))
+		return
+	}
+	otherProfile, err := analyzer.AnalyzePlayer(otherResult.Account.PUUID)
+	if err != nil {
+		b.sendError(s, i, "Analysis Error", fmt.Sprintf("Could not analyze `%s`: %v", otherResult.GetDisplayName(), err))
+		return
+	}
+
+	embed := b.formatComparison(playerResult, otherResult, profile, otherProfile)
+	if opts.Commentary && b.aiEnabled(i.GuildID) {
+		if commentary := b.generateComparisonCommentary(i.GuildID, playerResult.Account.GameName, otherResult.Account.GameName, profile, otherProfile); commentary != "" {
+			embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+				Name:   "🤖 Commentary",
+				Value:  commentary,
+				Inline: false,
+			})
+		}
+	}
+
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{embed},
+	}); err != nil {
+		fmt.Printf("Error editing interaction response: %v\n", err)
+	}
+}
+
+// formatComparison formats two player profiles side by side, marking who leads each stat
+func (b *DiscordBot) formatComparison(playerResult, otherResult *PlayerLookupResult, profile, otherProfile *riot.PlayerProfile) *discordgo.MessageEmbed {
+	nameA := playerResult.Account.GameName
+	nameB := otherResult.Account.GameName
+
+	// Placement stats only come from solo games, so they can't be compared without them
+	var placementA, placementB, topFourA, topFourB, consistencyA, consistencyB string
+	color := 0xffff00
+	edge := "No solo games to compare placements"
+	if profile.SoloGames > 0 && otherProfile.SoloGames > 0 {
+		placementA, placementB = comparisonMarks(profile.PlayStyle.AveragePlacement, otherProfile.PlayStyle.AveragePlacement, true)
+		topFourA, topFourB = comparisonMarks(profile.PlayStyle.TopFourRate, otherProfile.PlayStyle.TopFourRate, false)
+		consistencyA, consistencyB = comparisonMarks(profile.Performance.ConsistencyScore, otherProfile.Performance.ConsistencyScore, false)
+
+		// Color by who places better
+		diff := profile.PlayStyle.AveragePlacement - otherProfile.PlayStyle.AveragePlacement
+		if diff < 0 {
+			color = 0x00ff00
+		} else if diff > 0 {
+			color = 0xff0000
+		}
+		edge = b.formatPlacementEdge(nameA, nameB, diff) + " across their own recent games"
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("📊 %s vs %s", nameA, nameB),
+		Color:       color,
+		Description: edge,
+		Author: &discordgo.MessageEmbedAuthor{
+			Name:    playerResult.GetDisplayName(),
+			IconURL: playerResult.GetProfileIconURL(),
+		},
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   nameA,
+				Value:  b.formatComparisonColumn(profile, placementA, topFourA, consistencyA),
+				Inline: true,
+			},
+			{
+				Name:   nameB,
+				Value:  b.formatComparisonColumn(otherProfile, placementB, topFourB, consistencyB),
+				Inline: true,
+			},
+		},
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: fmt.Sprintf("%d vs %d games analyzed • ✅ marks the better stat", profile.AnalyzedGames, otherProfile.AnalyzedGames),
+		},
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+}
+
+// formatComparisonColumn formats one player's side of a comparison, with the marks from comparisonMarks
+func (b *DiscordBot) formatComparisonColumn(profile *riot.PlayerProfile, placementMark, topFourMark, consistencyMark string) string {
+	placements := "_No solo games_"
+	if profile.SoloGames > 0 {
+		placements = fmt.Sprintf("**Avg Placement:** #%.1f%s\n**Top 4 Rate:** %.0f%%%s\n**Consistency:** %s%s",
+			profile.PlayStyle.AveragePlacement, placementMark,
+			profile.PlayStyle.TopFourRate*100, topFourMark,
+			b.getConsistencyDescription(profile.Performance.ConsistencyScore), consistencyMark,
+		)
+	}
+	return fmt.Sprintf("%s\n**Economy:** %s\n**Leveling:** %s\n\n__Favorite Traits__\n%s\n\n__Favorite Units__\n%s",
+		placements,
+		capitalizeFirst(profile.PlayStyle.EconomyStyle),
+		capitalizeFirst(profile.PlayStyle.LevelingPattern),
+		b.formatFavoriteTraits(profile.CompPreference.FavoriteTraits, 3),
+		b.formatFavoriteUnits(profile.CompPreference.FavoriteUnits, 3),
+	)
+}
+
+// comparisonMarks marks the better of two stats with " ✅", leaving ties unmarked
+func comparisonMarks(a, b float64, lowerIsBetter bool) (string, string) {
+	if a == b {
+		return "", ""
+	}
+	if (a < b) == lowerIsBetter {
+		return " ✅", ""
+	}
+	return "", " ✅"
+}
+
+// comparisonPrompt asks for commentary on how two players' profiles differ
+func (b *DiscordBot) comparisonPrompt(nameA, nameB string, profile, otherProfile *riot.PlayerProfile) string {
+	var prompt strings.Builder
+	prompt.WriteString("Compare these two TFT players in 2-3 short sentences. Focus on the biggest differences in results and playstyle, and what each could learn from the other:\n\n")
+	for _, player := range []struct {
+		name    string
+		profile *riot.PlayerProfile
+	}{{nameA, profile}, {nameB, otherProfile}} {
+		var traits []string
+		for idx, trait := range player.profile.CompPreference.FavoriteTraits {
+			if idx >= 3 {
+				break
+			}
+			traits = append(traits, b.cleanTraitName(trait.Name))
+		}
+		placements := "no solo games"
+		if player.profile.SoloGames > 0 {
+			placements = fmt.Sprintf("avg #%.1f, top 4 %.0f%%, consistency %s",
+				player.profile.PlayStyle.AveragePlacement,
+				player.profile.PlayStyle.TopFourRate*100,
+				strings.ToLower(b.getConsistencyDescription(player.profile.Performance.ConsistencyScore)),
+			)
+		}
+		prompt.WriteString(fmt.Sprintf("%s: %s, %s economy, %s leveling, favorite traits %s (%d games)\n",
+			player.name,
+			placements,
+			player.profile.PlayStyle.EconomyStyle,
+			player.profile.PlayStyle.LevelingPattern,
+			strings.Join(traits, ", "),
+			player.profile.AnalyzedGames,
+		))
+	}
+	prompt.WriteString("\nBe concise. Bold player names using **bold**.")
+	return prompt.String()
+}
+
+// generateComparisonCommentary describes how two players differ, or returns "" when the request fails
+func (b *DiscordBot) generateComparisonCommentary(guildID, nameA, nameB string, profile, otherProfile *riot.PlayerProfile) string {
+	ctx, cancel := context.WithTimeout(context.Background(), 8*time.Second)
+	defer cancel()
+
+	commentary, err := b.OpenAI.GenerateResponse(ctx, b.localizePrompt(guildID, b.comparisonPrompt(nameA, nameB, profile, otherProfile)))
+	if err != nil {
+		fmt.Printf("AI Comparison error: %v\n", err)
+		return ""
+	}
+
+	// Keep within Discord's field limit
+	return truncateRunes(strings.TrimSpace(commentary), 1024)
+}
+
+// truncateRunes shortens text to at most limit characters, ending in "..." when cut.
+// Cutting by characters rather than bytes keeps multi-byte characters whole.
+func truncateRunes(text string, limit int) string {
+	runes := []rune(text)
+	if len(runes) <= limit {
+		return text
+	}
+	return string(runes[:limit-3]) + "..."
+}
@@ -0,0 +1,118 @@
+package discord
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/hunterjsb/tft/internal/riot"
+)
+
+func TestComparisonMarks(t *testing.T) {
+	tests := []struct {
+		a, b          float64
+		lowerIsBetter bool
+		wantA, wantB  string
+	}{
+		{3.2, 4.5, true, " ✅", ""},
+		{3.2, 4.5, false, "", " ✅"},
+		{0.4, 0.6, false, "", " ✅"},
+		{4.0, 4.0, true, "", ""},
+	}
+	for _, tt := range tests {
+		if gotA, gotB := comparisonMarks(tt.a, tt.b, tt.lowerIsBetter); gotA != tt.wantA || gotB != tt.wantB {
+			t.Errorf("comparisonMarks(%v, %v, %v) = %q, %q, want %q, %q", tt.a, tt.b, tt.lowerIsBetter, gotA, gotB, tt.wantA, tt.wantB)
+		}
+	}
+}
+
+func TestFormatComparison(t *testing.T) {
+	bot := &DiscordBot{}
+	player := &PlayerLookupResult{Account: &riot.Account{GameName: "Koalafied", TagLine: "NA1"}}
+	other := &PlayerLookupResult{Account: &riot.Account{GameName: "mubs", TagLine: "NA1"}}
+	profile := &riot.PlayerProfile{
+		AnalyzedGames: 20,
+		SoloGames:     20,
+		PlayStyle:     riot.PlayStyleProfile{AveragePlacement: 3.5, TopFourRate: 0.6, EconomyStyle: "greedy", LevelingPattern: "fast"},
+		Performance:   riot.PerformanceProfile{ConsistencyScore: 0.5},
+		CompPreference: riot.CompPreferenceProfile{
+			FavoriteTraits: []riot.TraitFrequency{{Name: "TFT15_Duelist", Frequency: 0.4}},
+		},
+	}
+	otherProfile := &riot.PlayerProfile{
+		AnalyzedGames: 18,
+		SoloGames:     18,
+		PlayStyle:     riot.PlayStyleProfile{AveragePlacement: 4.5, TopFourRate: 0.4, EconomyStyle: "balanced", LevelingPattern: "slow"},
+		Performance:   riot.PerformanceProfile{ConsistencyScore: 0.7},
+	}
+
+	embed := bot.formatComparison(player, other, profile, otherProfile)
+	if embed.Title != "📊 Koalafied vs mubs" || embed.Color != 0x00ff00 {
+		t.Errorf("Unexpected header %q / %x", embed.Title, embed.Color)
+	}
+	if !containsString(embed.Description, "**Koalafied** places 1.0 better on average") {
+		t.Errorf("Unexpected description %q", embed.Description)
+	}
+	if len(embed.Fields) != 2 || !embed.Fields[0].Inline || !embed.Fields[1].Inline {
+		t.Fatalf("Expected two inline columns, got %+v", embed.Fields)
+	}
+	left, right := embed.Fields[0].Value, embed.Fields[1].Value
+	for _, want := range []string{"#3.5 ✅", "60% ✅", "**Consistency:** Moderate\n", "**Economy:** Greedy", "**Leveling:** Fast", "**Duelist** 40%"} {
+		if !containsString(left, want) {
+			t.Errorf("Expected %q in left column %q", want, left)
+		}
+	}
+	for _, want := range []string{"#4.5\n", "**Consistency:** High ✅", "**Economy:** Balanced", "No data"} {
+		if !containsString(right, want) {
+			t.Errorf("Expected %q in right column %q", want, right)
+		}
+	}
+
+	// Double Up games alone have no placements to compare
+	duoOnly := &riot.PlayerProfile{AnalyzedGames: 18, DoubleUp: riot.DoubleUpProfile{Games: 18}}
+	embed = bot.formatComparison(player, other, profile, duoOnly)
+	if embed.Description != "No solo games to compare placements" || embed.Color != 0xffff00 {
+		t.Errorf("Unexpected header %q / %x", embed.Description, embed.Color)
+	}
+	if containsString(embed.Fields[0].Value+embed.Fields[1].Value, "✅") {
+		t.Errorf("Expected no marks without solo games, got %+v", embed.Fields)
+	}
+	if !containsString(embed.Fields[1].Value, "_No solo games_") {
+		t.Errorf("Expected no placements in right column %q", embed.Fields[1].Value)
+	}
+}
+
+func TestComparisonPrompt(t *testing.T) {
+	bot := &DiscordBot{}
+	profile := &riot.PlayerProfile{
+		AnalyzedGames: 20,
+		SoloGames:     20,
+		PlayStyle:     riot.PlayStyleProfile{AveragePlacement: 3.5, TopFourRate: 0.6, EconomyStyle: "greedy", LevelingPattern: "fast"},
+		CompPreference: riot.CompPreferenceProfile{
+			FavoriteTraits: []riot.TraitFrequency{{Name: "TFT15_Duelist"}, {Name: "TFT15_Sniper"}},
+		},
+	}
+	prompt := bot.comparisonPrompt("Koalafied", "mubs", profile, &riot.PlayerProfile{})
+	if !containsString(prompt, "Koalafied: avg #3.5, top 4 60%, consistency very low, greedy economy, fast leveling, favorite traits Duelist, Sniper (20 games)") {
+		t.Errorf("Unexpected prompt %q", prompt)
+	}
+	if !containsString(prompt, "mubs: no solo games") {
+		t.Errorf("Expected the other player without placements in prompt %q", prompt)
+	}
+}
+
+func TestTruncateRunes(t *testing.T) {
+	if got := truncateRunes("short", 10); got != "short" {
+		t.Errorf("Expected short text unchanged, got %q", got)
+	}
+
+	// Multi-byte characters are counted and cut whole
+	long := strings.Repeat("é", 1030)
+	got := truncateRunes(long, 1024)
+	if !utf8.ValidString(got) || utf8.RuneCountInString(got) != 1024 || !strings.HasSuffix(got, "é...") {
+		t.Errorf("Expected 1024 valid characters ending in an ellipsis, got %d", utf8.RuneCountInString(got))
+	}
+	if got := truncateRunes(strings.Repeat("é", 1024), 1024); got != strings.Repeat("é", 1024) {
+		t.Error("Expected text at the limit unchanged")
+	}
+}
@@ -0,0 +1,196 @@
+package discord
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hunterjsb/tft/internal/riot"
+)
+
+// Component custom IDs are "name:arg:arg...", where name picks the handler in
+// ComponentHandlers and the args carry the state needed to redraw the message,
+// so buttons and menus keep working across restarts.
+const (
+	customIDSeparator  = ":"
+	maxCustomIDLength  = 100 // Discord's limit
+	maxSelectMenuItems = 25  // Discord's limit
+)
+
+// componentID builds the custom ID of a component handled by the handler registered as name
+func componentID(name string, args ...any) string {
+	parts := []string{name}
+	for _, arg := range args {
+		parts = append(parts, fmt.Sprint(arg))
+	}
+	id := strings.Join(parts, customIDSeparator)
+	if len(id) > maxCustomIDLength {
+		fmt.Printf("Warning: custom ID %q is longer than %d characters\n", id, maxCustomIDLength)
+	}
+	return id
+}
+
+// parseComponentID splits a custom ID into its handler name and args
+func parseComponentID(customID string) (name string, args []string) {
+	parts := strings.Split(customID, customIDSeparator)
+	return parts[0], parts[1:]
+}
+
+// handleComponent routes a button press or select menu choice to its handler
+func (b *DiscordBot) handleComponent(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	name, args := parseComponentID(i.MessageComponentData().CustomID)
+	if handler, ok := b.ComponentHandlers[name]; ok {
+		handler(s, i, args)
+	}
+}
+
+// deferComponentUpdate acknowledges a component whose handler will edit the message it is on.
+// Returns false when the interaction couldn't be acknowledged.
+func deferComponentUpdate(s *discordgo.Session, i *discordgo.InteractionCreate) bool {
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredMessageUpdate,
+	}); err != nil {
+		fmt.Printf("Error acknowledging component: %v\n", err)
+		return false
+	}
+	return true
+}
+
+// sendComponentError shows an error to the user who used a component, leaving the message as it was
+func (b *DiscordBot) sendComponentError(s *discordgo.Session, i *discordgo.InteractionCreate, title, description string) {
+	embed := &discordgo.MessageEmbed{
+		Title:       title,
+		Description: description,
+		Color:       0xff0000,
+	}
+	if _, err := s.FollowupMessageCreate(i.Interaction, true, &discordgo.WebhookParams{
+		Embeds: []*discordgo.MessageEmbed{embed},
+		Flags:  discordgo.MessageFlagsEphemeral,
+	}); err != nil {
+		fmt.Printf("Error sending component error: %v\n", err)
+	}
+}
+
+// selectedValue returns the first value chosen in a select menu
+func selectedValue(i *discordgo.InteractionCreate) string {
+	if values := i.MessageComponentData().Values; len(values) > 0 {
+		return values[0]
+	}
+	return ""
+}
+
+// playerByPUUID looks up a player for a component that only knows their PUUID
+func (b *DiscordBot) playerByPUUID(puuid string) *PlayerLookupResult {
+	account, err := riot.GetAccountByPUUID(puuid)
+	if err != nil {
+		account = &riot.Account{PUUID: puuid}
+	}
+	summoner, _ := riot.GetSummonerByPUUID(puuid)
+	return &PlayerLookupResult{
+		Account:  account,
+		Summoner: summoner,
+		Params:   PlayerParams{GameName: account.GameName, TagLine: account.TagLine},
+	}
+}
+
+// participantResult describes a match participant as a looked-up player, using the
+// Riot ID from the match so only the profile icon needs a lookup
+func participantResult(match *riot.MatchDto, puuid string) *PlayerLookupResult {
+	account := &riot.Account{PUUID: puuid}
+	for _, participant := range match.Info.Participants {
+		if participant.PUUID == puuid {
+			account.GameName = participant.RiotIDGameName
+			account.TagLine = participant.RiotIDTagline
+			break
+		}
+	}
+	summoner, _ := riot.GetSummonerByPUUID(puuid)
+	return &PlayerLookupResult{
+		Account:  account,
+		Summoner: summoner,
+		Params:   PlayerParams{GameName: account.GameName, TagLine: account.TagLine},
+	}
+}
+
+// recentPageButtons pages /tftrecent through a player's history, count games at a time
+func recentPageButtons(puuid string, start, count int, hasOlder bool) []discordgo.MessageComponent {
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+			discordgo.Button{
+				Label:    "◀ Newer",
+				Style:    discordgo.SecondaryButton,
+				CustomID: componentID("recent", puuid, max(start-count, 0), count),
+				Disabled: start == 0,
+			},
+			discordgo.Button{
+				Label:    "Older ▶",
+				Style:    discordgo.SecondaryButton,
+				CustomID: componentID("recent", puuid, start+count, count),
+				Disabled: !hasOlder,
+			},
+		}},
+	}
+}
+
+// participantMenu picks a participant of a match, in placement order, for the component
+// handler registered as handler (e.g. /lastgame switching players)
+func participantMenu(match *riot.MatchDto, selected, handler, placeholder string) []discordgo.MessageComponent {
+	participants := append([]riot.ParticipantDto(nil), match.Info.Participants...)
+	sort.SliceStable(participants, func(a, c int) bool {
+		return participants[a].Placement < participants[c].Placement
+	})
+
+	var options []discordgo.SelectMenuOption
+	for idx, participant := range participants {
+		if idx >= maxSelectMenuItems {
+			break
+		}
+		name := fmt.Sprintf("Player %d", idx+1)
+		if participant.RiotIDGameName != "" {
+			name = fmt.Sprintf("%s#%s", participant.RiotIDGameName, participant.RiotIDTagline)
+		}
+		options = append(options, discordgo.SelectMenuOption{
+			Label:   fmt.Sprintf("#%d %s", participant.Placement, name),
+			Value:   participant.PUUID,
+			Default: participant.PUUID == selected,
+		})
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+			discordgo.SelectMenu{
+				CustomID:    componentID(handler, match.Metadata.MatchID),
+				Placeholder: placeholder,
+				Options:     options,
+			},
+		}},
+	}
+}
+
+// lobbyProfileMenu lets /lobby expand one opponent's full profile
+func lobbyProfileMenu(game *riot.CurrentGameInfo, labels map[string]string, you string) []discordgo.MessageComponent {
+	var options []discordgo.SelectMenuOption
+	for _, participant := range game.Participants {
+		if participant.PUUID == you || len(options) >= maxSelectMenuItems {
+			continue
+		}
+		options = append(options, discordgo.SelectMenuOption{
+			Label: labels[participant.PUUID],
+			Value: participant.PUUID,
+		})
+	}
+	if len(options) == 0 {
+		return []discordgo.MessageComponent{}
+	}
+
+	return []discordgo.MessageComponent{
+		discordgo.ActionsRow{Components: []discordgo.MessageComponent{
+			discordgo.SelectMenu{
+				CustomID:    componentID("lobbyprofile"),
+				Placeholder: "Expand an opponent's profile",
+				Options:     options,
+			},
+		}},
+	}
+}
@@ -0,0 +1,98 @@
+package discord
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hunterjsb/tft/internal/riot"
+)
+
+func TestComponentIDs(t *testing.T) {
+	id := componentID("recent", "puuid-1", 10, 5)
+	if id != "recent:puuid-1:10:5" {
+		t.Errorf("Unexpected custom ID %q", id)
+	}
+	name, args := parseComponentID(id)
+	if name != "recent" || fmt.Sprint(args) != "[puuid-1 10 5]" {
+		t.Errorf("Expected recent [puuid-1 10 5], got %s %v", name, args)
+	}
+
+	// Real PUUIDs are 78 characters and must still fit Discord's custom ID limit
+	puuid := "S7385disFDbPwDqOGvxT2q_V0-TtIrhTolM55G97WtT4APix8dQtU_fnOYI_OkSNn7HxDDu-dS1AFA"
+	if id := componentID("recent", puuid, 190, 10); len(id) > maxCustomIDLength {
+		t.Errorf("Custom ID is %d characters, over the limit", len(id))
+	}
+}
+
+func TestHandleComponentRoutes(t *testing.T) {
+	var gotArgs []string
+	bot := &DiscordBot{ComponentHandlers: map[string]func(*discordgo.Session, *discordgo.InteractionCreate, []string){
+		"lastgame": func(_ *discordgo.Session, _ *discordgo.InteractionCreate, args []string) { gotArgs = args },
+	}}
+	interaction := func(customID string) *discordgo.InteractionCreate {
+		return &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{
+			Type: discordgo.InteractionMessageComponent,
+			Data: discordgo.MessageComponentInteractionData{CustomID: customID},
+		}}
+	}
+
+	bot.handleComponent(nil, interaction("lastgame:NA1_5359015242"))
+	if fmt.Sprint(gotArgs) != "[NA1_5359015242]" {
+		t.Errorf("Expected the match ID arg, got %v", gotArgs)
+	}
+	bot.handleComponent(nil, interaction("unknown:1")) // ignored
+}
+
+func TestRecentPageButtons(t *testing.T) {
+	buttons := func(components []discordgo.MessageComponent) (newer, older discordgo.Button) {
+		row := components[0].(discordgo.ActionsRow)
+		return row.Components[0].(discordgo.Button), row.Components[1].(discordgo.Button)
+	}
+
+	newer, older := buttons(recentPageButtons("p", 0, 5, true))
+	if !newer.Disabled || older.Disabled || older.CustomID != "recent:p:5:5" {
+		t.Errorf("First page: unexpected buttons %+v %+v", newer, older)
+	}
+	newer, older = buttons(recentPageButtons("p", 3, 5, false))
+	if newer.Disabled || newer.CustomID != "recent:p:0:5" || !older.Disabled {
+		t.Errorf("Last page: unexpected buttons %+v %+v", newer, older)
+	}
+}
+
+func TestParticipantMenu(t *testing.T) {
+	match := &riot.MatchDto{
+		Metadata: riot.MetadataDto{MatchID: "NA1_1"},
+		Info: riot.InfoDto{Participants: []riot.ParticipantDto{
+			{PUUID: "b", Placement: 2, RiotIDGameName: "Second", RiotIDTagline: "NA1"},
+			{PUUID: "a", Placement: 1, RiotIDGameName: "First", RiotIDTagline: "EUW"},
+			{PUUID: "c", Placement: 3},
+		}},
+	}
+
+	row := participantMenu(match, "b", "lastgame", lastGameMenuPlaceholder)[0].(discordgo.ActionsRow)
+	menu := row.Components[0].(discordgo.SelectMenu)
+	if menu.CustomID != "lastgame:NA1_1" || len(menu.Options) != 3 {
+		t.Fatalf("Unexpected menu %+v", menu)
+	}
+	if menu.Options[0].Label != "#1 First#EUW" || menu.Options[2].Label != "#3 Player 3" {
+		t.Errorf("Expected participants in placement order, got %+v", menu.Options)
+	}
+	if menu.Options[0].Default || !menu.Options[1].Default {
+		t.Errorf("Expected the shown player selected, got %+v", menu.Options)
+	}
+}
+
+func TestLobbyProfileMenu(t *testing.T) {
+	game := &riot.CurrentGameInfo{Participants: []riot.CurrentGameParticipant{{PUUID: "me"}, {PUUID: "rival"}}}
+	row := lobbyProfileMenu(game, map[string]string{"me": "You", "rival": "Rival#EUW"}, "me")[0].(discordgo.ActionsRow)
+	menu := row.Components[0].(discordgo.SelectMenu)
+	if len(menu.Options) != 1 || menu.Options[0].Value != "rival" || menu.Options[0].Label != "Rival#EUW" {
+		t.Errorf("Expected only the opponent, got %+v", menu.Options)
+	}
+
+	solo := &riot.CurrentGameInfo{Participants: []riot.CurrentGameParticipant{{PUUID: "me"}}}
+	if components := lobbyProfileMenu(solo, nil, "me"); len(components) != 0 {
+		t.Errorf("Expected no menu without opponents, got %v", components)
+	}
+}
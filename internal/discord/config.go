@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"os"
 	"strconv"
+	"strings"
+	"time"
 )
 
 func LoadConfig() (*Config, error) {
@@ -21,11 +23,27 @@ func LoadConfig() (*Config, error) {
 		}
 	}
 
+	dataDir := os.Getenv("DATA_DIR")
+	if dataDir == "" {
+		dataDir = "data"
+	}
+
+	pollInterval := defaultPollInterval
+	if intervalStr := os.Getenv("TRACK_POLL_INTERVAL"); intervalStr != "" {
+		if interval, err := time.ParseDuration(intervalStr); err == nil && interval > 0 {
+			pollInterval = interval
+		}
+	}
+
 	return &Config{
 		DiscordToken: os.Getenv("DISCORD_TOKEN"),
 		OpenAIToken:  os.Getenv("OPENAI_API_KEY"),
 		GuildID:      os.Getenv("GUILD_ID"),
 		ChannelID:    os.Getenv("CHANNEL_ID"),
+		ArchiveDir:   os.Getenv("MATCH_ARCHIVE_DIR"),
+		MetaRankBand: strings.ToUpper(os.Getenv("META_RANK_BAND")),
+		DataDir:      dataDir,
+		PollInterval: pollInterval,
 		MaxTokens:    maxTokens,
 		Temperature:  temperature,
 	}, nil
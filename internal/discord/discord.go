@@ -2,10 +2,34 @@ package discord
 
 import (
 	"fmt"
+	"path/filepath"
+	"time"
 
 	"github.com/bwmarrin/discordgo"
+	"github.com/hunterjsb/tft/internal/riot"
+	"github.com/hunterjsb/tft/internal/store"
 )
 
+// Playstyle analysis window bounds
+var (
+	minPlaystyleGames = 5.0
+	maxPlaystyleGames = 50.0
+)
+
+// Recent games bounds for /tftrecent
+var (
+	minRecentGames = 1.0
+	maxRecentGames = 10.0
+)
+
+// queueChoices lists the TFT queues players can filter on
+var queueChoices = []*discordgo.ApplicationCommandOptionChoice{
+	{Name: "Ranked", Value: 1100},
+	{Name: "Normal", Value: 1090},
+	{Name: "Hyper Roll", Value: 1130},
+	{Name: "Double Up", Value: 1160},
+}
+
 // Command definitions
 var commands = []*discordgo.ApplicationCommand{
 	{
@@ -24,29 +48,45 @@ var commands = []*discordgo.ApplicationCommand{
 		Name:        "tftrecent",
 		Description: "Get recent TFT games for a player",
 		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "riotid",
+				Description:  "Player's Riot ID as name#tag (e.g., 'mubs#NA1')",
+				Required:     false,
+				Autocomplete: true,
+			},
 			{
 				Type:        discordgo.ApplicationCommandOptionString,
 				Name:        "gamename",
-				Description: "Player's Riot ID (e.g., 'mubs')",
-				Required:    true,
+				Description: "Player's Riot ID (e.g., 'mubs') - leave blank to use a linked account",
+				Required:    false,
 			},
 			{
 				Type:        discordgo.ApplicationCommandOptionString,
 				Name:        "tagline",
 				Description: "Player's tagline (e.g., 'NA1', 'koala')",
-				Required:    true,
+				Required:    false,
 			},
 			{
-				Type:        discordgo.ApplicationCommandOptionString,
-				Name:        "region",
-				Description: "Server region (NA1, BR1, EUW1, KR, etc. - leave blank to auto-detect)",
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "user",
+				Description: "Use this Discord user's linked account (defaults to yours)",
 				Required:    false,
 			},
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "region",
+				Description:  "Server region (NA1, BR1, EUW1, KR, etc. - leave blank to auto-detect)",
+				Required:     false,
+				Autocomplete: true,
+			},
 			{
 				Type:        discordgo.ApplicationCommandOptionInteger,
 				Name:        "count",
-				Description: "Number of games to show (1-10, default: 5)",
+				Description: "Games per page (1-10, default: 5)",
 				Required:    false,
+				MinValue:    &minRecentGames,
+				MaxValue:    maxRecentGames,
 			},
 		},
 	},
@@ -54,53 +94,284 @@ var commands = []*discordgo.ApplicationCommand{
 		Name:        "lastgame",
 		Description: "Get player's last TFT game with detailed info",
 		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "riotid",
+				Description:  "Player's Riot ID as name#tag (e.g., 'mubs#NA1')",
+				Required:     false,
+				Autocomplete: true,
+			},
 			{
 				Type:        discordgo.ApplicationCommandOptionString,
 				Name:        "gamename",
-				Description: "Player's Riot ID (e.g., 'mubs')",
-				Required:    true,
+				Description: "Player's Riot ID (e.g., 'mubs') - leave blank to use a linked account",
+				Required:    false,
 			},
 			{
 				Type:        discordgo.ApplicationCommandOptionString,
 				Name:        "tagline",
 				Description: "Player's tagline (e.g., 'NA1', 'koala')",
-				Required:    true,
+				Required:    false,
 			},
 			{
-				Type:        discordgo.ApplicationCommandOptionString,
-				Name:        "region",
-				Description: "Server region (NA1, BR1, EUW1, KR, etc. - leave blank to auto-detect)",
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "user",
+				Description: "Use this Discord user's linked account (defaults to yours)",
 				Required:    false,
 			},
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "region",
+				Description:  "Server region (NA1, BR1, EUW1, KR, etc. - leave blank to auto-detect)",
+				Required:     false,
+				Autocomplete: true,
+			},
 		},
 	},
 	{
 		Name:        "playstyle",
 		Description: "Analyze a player's TFT playstyle and tendencies",
 		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "riotid",
+				Description:  "Player's Riot ID as name#tag (e.g., 'mubs#NA1')",
+				Required:     false,
+				Autocomplete: true,
+			},
 			{
 				Type:        discordgo.ApplicationCommandOptionString,
 				Name:        "gamename",
-				Description: "Player's Riot ID (e.g., 'mubs')",
-				Required:    true,
+				Description: "Player's Riot ID (e.g., 'mubs') - leave blank to use a linked account",
+				Required:    false,
 			},
 			{
 				Type:        discordgo.ApplicationCommandOptionString,
 				Name:        "tagline",
 				Description: "Player's tagline (e.g., 'NA1', 'koala')",
-				Required:    true,
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "user",
+				Description: "Use this Discord user's linked account (defaults to yours)",
+				Required:    false,
+			},
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "region",
+				Description:  "Server region (NA1, BR1, EUW1, KR, etc. - leave blank to auto-detect)",
+				Required:     false,
+				Autocomplete: true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        "count",
+				Description: "Number of games to analyze (5-50, default: 20)",
+				Required:    false,
+				MinValue:    &minPlaystyleGames,
+				MaxValue:    maxPlaystyleGames,
 			},
 			{
 				Type:        discordgo.ApplicationCommandOptionString,
-				Name:        "region",
-				Description: "Server region (NA1, BR1, EUW1, KR, etc. - leave blank to auto-detect)",
+				Name:        "since",
+				Description: "Only games on or after this date (YYYY-MM-DD)",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "until",
+				Description: "Only games before this date (YYYY-MM-DD)",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "patch",
+				Description: "Only games on this patch (e.g., '15.17')",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        "queue",
+				Description: "Only games in this queue",
+				Required:    false,
+				Choices:     queueChoices,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        "set",
+				Description: "Only games from this TFT set number (e.g., 15)",
 				Required:    false,
 			},
 		},
 	},
 	{
-		Name:        "lobby",
-		Description: "Analyze your current TFT lobby",
+		Name:        "duo",
+		Description: "Analyze a Double Up pair's games together",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "riotid",
+				Description:  "Player's Riot ID as name#tag (e.g., 'mubs#NA1')",
+				Required:     false,
+				Autocomplete: true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "gamename",
+				Description: "Player's Riot ID (e.g., 'mubs') - leave blank to use a linked account",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "tagline",
+				Description: "Player's tagline (e.g., 'NA1', 'koala')",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "user",
+				Description: "Use this Discord user's linked account (defaults to yours)",
+				Required:    false,
+			},
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "partner_riotid",
+				Description:  "Partner's Riot ID as name#tag",
+				Required:     false,
+				Autocomplete: true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "partner_gamename",
+				Description: "Partner's Riot ID",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "partner_tagline",
+				Description: "Partner's tagline",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "partner_user",
+				Description: "Use this Discord user's linked account as the partner",
+				Required:    false,
+			},
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "region",
+				Description:  "Server region (NA1, BR1, EUW1, KR, etc. - leave blank to auto-detect)",
+				Required:     false,
+				Autocomplete: true,
+			},
+		},
+	},
+	{
+		Name:        "versus",
+		Description: "Head-to-head record between two players",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "riotid",
+				Description:  "Player's Riot ID as name#tag (e.g., 'mubs#NA1')",
+				Required:     false,
+				Autocomplete: true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "gamename",
+				Description: "Player's Riot ID (e.g., 'mubs') - leave blank to use a linked account",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "tagline",
+				Description: "Player's tagline (e.g., 'NA1', 'koala')",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "user",
+				Description: "Use this Discord user's linked account (defaults to yours)",
+				Required:    false,
+			},
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "opponent_riotid",
+				Description:  "Opponent's Riot ID as name#tag",
+				Required:     false,
+				Autocomplete: true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "opponent_gamename",
+				Description: "Opponent's Riot ID",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "opponent_tagline",
+				Description: "Opponent's tagline",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "opponent_user",
+				Description: "Use this Discord user's linked account as the opponent",
+				Required:    false,
+			},
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "region",
+				Description:  "Server region (NA1, BR1, EUW1, KR, etc. - leave blank to auto-detect)",
+				Required:     false,
+				Autocomplete: true,
+			},
+		},
+	},
+	{
+		Name:        "session",
+		Description: "Summarize a player's current play session",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "riotid",
+				Description:  "Player's Riot ID as name#tag (e.g., 'mubs#NA1')",
+				Required:     false,
+				Autocomplete: true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "gamename",
+				Description: "Player's Riot ID (e.g., 'mubs') - leave blank to use a linked account",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "tagline",
+				Description: "Player's tagline (e.g., 'NA1', 'koala')",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "user",
+				Description: "Use this Discord user's linked account (defaults to yours)",
+				Required:    false,
+			},
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "region",
+				Description:  "Server region (NA1, BR1, EUW1, KR, etc. - leave blank to auto-detect)",
+				Required:     false,
+				Autocomplete: true,
+			},
+		},
+	},
+	{
+		Name:        "link",
+		Description: "Link your Discord account to a Riot ID so commands default to it",
 		Options: []*discordgo.ApplicationCommandOption{
 			{
 				Type:        discordgo.ApplicationCommandOptionString,
@@ -114,12 +385,375 @@ var commands = []*discordgo.ApplicationCommand{
 				Description: "Your tagline (e.g., 'NA1', 'koala')",
 				Required:    true,
 			},
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "region",
+				Description:  "Your server region (NA1, BR1, EUW1, KR, etc. - leave blank to detect it)",
+				Required:     false,
+				Autocomplete: true,
+			},
+		},
+	},
+	{
+		Name:        "unlink",
+		Description: "Remove the Riot ID linked to your Discord account",
+	},
+	{
+		Name:        "track",
+		Description: "Post a player's live lobbies and finished games in this channel",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "riotid",
+				Description:  "Player's Riot ID as name#tag (e.g., 'mubs#NA1')",
+				Required:     false,
+				Autocomplete: true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "gamename",
+				Description: "Player's Riot ID (e.g., 'mubs') - leave blank to use a linked account",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "tagline",
+				Description: "Player's tagline (e.g., 'NA1', 'koala')",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "user",
+				Description: "Use this Discord user's linked account (defaults to yours)",
+				Required:    false,
+			},
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "region",
+				Description:  "Server region (NA1, BR1, EUW1, KR, etc. - defaults to NA1)",
+				Required:     false,
+				Autocomplete: true,
+			},
+		},
+	},
+	{
+		Name:        "untrack",
+		Description: "Stop posting a player's games in this channel",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "riotid",
+				Description:  "Player's Riot ID as name#tag (e.g., 'mubs#NA1')",
+				Required:     false,
+				Autocomplete: true,
+			},
 			{
 				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "gamename",
+				Description: "Player's Riot ID (e.g., 'mubs') - leave blank to use a linked account",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "tagline",
+				Description: "Player's tagline (e.g., 'NA1', 'koala')",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "user",
+				Description: "Use this Discord user's linked account (defaults to yours)",
+				Required:    false,
+			},
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "region",
+				Description:  "Server region (NA1, BR1, EUW1, KR, etc. - defaults to NA1)",
+				Required:     false,
+				Autocomplete: true,
+			},
+		},
+	},
+	{
+		Name:        "leaderboard",
+		Description: "Rank this server's linked players",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "sort",
+				Description: "What to rank by (default: rank)",
+				Required:    false,
+				Choices: []*discordgo.ApplicationCommandOptionChoice{
+					{Name: "Rank", Value: sortByRank},
+					{Name: "Average placement", Value: sortByPlacement},
+					{Name: "Top 4 rate", Value: sortByTopFour},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionInteger,
+				Name:        "games",
+				Description: "Recent games for placement and top 4 rate (5-50, default: 20)",
+				Required:    false,
+				MinValue:    &minPlaystyleGames,
+				MaxValue:    maxPlaystyleGames,
+			},
+		},
+	},
+	{
+		Name:        "match",
+		Description: "Break down all eight players of a TFT match",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "match",
+				Description: "Match ID (e.g., 'NA1_5359015242'), or 'last' for the player's latest game (default)",
+				Required:    false,
+			},
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "riotid",
+				Description:  "Player's Riot ID as name#tag (e.g., 'mubs#NA1')",
+				Required:     false,
+				Autocomplete: true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "gamename",
+				Description: "Player's Riot ID (e.g., 'mubs') - leave blank to use a linked account",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "tagline",
+				Description: "Player's tagline (e.g., 'NA1', 'koala')",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "user",
+				Description: "Use this Discord user's linked account (defaults to yours)",
+				Required:    false,
+			},
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "region",
+				Description:  "Server region (NA1, BR1, EUW1, KR, etc. - defaults to NA1)",
+				Required:     false,
+				Autocomplete: true,
+			},
+		},
+	},
+	{
+		Name:        "compare",
+		Description: "Compare two players' playstyle profiles side by side",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "riotid",
+				Description:  "Player's Riot ID as name#tag (e.g., 'mubs#NA1')",
+				Required:     false,
+				Autocomplete: true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "gamename",
+				Description: "Player's Riot ID (e.g., 'mubs') - leave blank to use a linked account",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "tagline",
+				Description: "Player's tagline (e.g., 'NA1', 'koala')",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "user",
+				Description: "Use this Discord user's linked account (defaults to yours)",
+				Required:    false,
+			},
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "other_riotid",
+				Description:  "Other player's Riot ID as name#tag",
+				Required:     false,
+				Autocomplete: true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "other_gamename",
+				Description: "Other player's Riot ID",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "other_tagline",
+				Description: "Other player's tagline",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "other_user",
+				Description: "Use this Discord user's linked account as the other player",
+				Required:    false,
+			},
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "region",
+				Description:  "Server region (NA1, BR1, EUW1, KR, etc. - leave blank to auto-detect)",
+				Required:     false,
+				Autocomplete: true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionBoolean,
+				Name:        "commentary",
+				Description: "Add AI commentary on the differences (default true)",
+				Required:    false,
+			},
+		},
+	},
+	{
+		Name:                     "config",
+		Description:              "Change the bot's settings for this server",
+		DefaultMemberPermissions: &configPermissions,
+		DMPermission:             new(bool),
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "show",
+				Description: "Show this server's settings",
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
 				Name:        "region",
-				Description: "Server region (NA1, BR1, EUW1, KR, etc. - leave blank to auto-detect)",
+				Description: "Set the region used when a command doesn't give one",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:         discordgo.ApplicationCommandOptionString,
+						Name:         "region",
+						Description:  "Server region (NA1, BR1, EUW1, KR, etc. - leave blank to auto-detect)",
+						Required:     false,
+						Autocomplete: true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "channel",
+				Description: "Allow or disallow commands in a channel (with none allowed, all channels work)",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:         discordgo.ApplicationCommandOptionChannel,
+						Name:         "channel",
+						Description:  "Channel to change",
+						Required:     true,
+						ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "allowed",
+						Description: "Whether commands can be used there",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "feature",
+				Description: "Turn a feature on or off",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "feature",
+						Description: "Feature to change",
+						Required:    true,
+						Choices:     featureChoices,
+					},
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "enabled",
+						Description: "Whether the feature is on",
+						Required:    true,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "reports",
+				Description: "Turn the weekly leaderboard on or off, or set where it's posted",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionBoolean,
+						Name:        "enabled",
+						Description: "Whether the weekly leaderboard is posted",
+						Required:    true,
+					},
+					{
+						Type:         discordgo.ApplicationCommandOptionChannel,
+						Name:         "channel",
+						Description:  "Channel to post in - leave blank for the default",
+						Required:     false,
+						ChannelTypes: []discordgo.ChannelType{discordgo.ChannelTypeGuildText},
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "language",
+				Description: "Set the language of AI responses",
+				Options: []*discordgo.ApplicationCommandOption{
+					{
+						Type:        discordgo.ApplicationCommandOptionString,
+						Name:        "language",
+						Description: "Language for AI responses",
+						Required:    true,
+						Choices:     languageChoices,
+					},
+				},
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionSubCommand,
+				Name:        "reset",
+				Description: "Restore the default settings",
+			},
+		},
+	},
+	{
+		Name:        "lobby",
+		Description: "Analyze your current TFT lobby",
+		Options: []*discordgo.ApplicationCommandOption{
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "riotid",
+				Description:  "Player's Riot ID as name#tag (e.g., 'mubs#NA1')",
+				Required:     false,
+				Autocomplete: true,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "gamename",
+				Description: "Your Riot ID (e.g., 'mubs') - leave blank to use a linked account",
+				Required:    false,
+			},
+			{
+				Type:        discordgo.ApplicationCommandOptionString,
+				Name:        "tagline",
+				Description: "Your tagline (e.g., 'NA1', 'koala')",
 				Required:    false,
 			},
+			{
+				Type:        discordgo.ApplicationCommandOptionUser,
+				Name:        "user",
+				Description: "Use this Discord user's linked account (defaults to yours)",
+				Required:    false,
+			},
+			{
+				Type:         discordgo.ApplicationCommandOptionString,
+				Name:         "region",
+				Description:  "Server region (NA1, BR1, EUW1, KR, etc. - leave blank to auto-detect)",
+				Required:     false,
+				Autocomplete: true,
+			},
 		},
 	},
 }
@@ -134,11 +768,48 @@ func NewDiscordBot(config *Config) (*DiscordBot, error) {
 	openAI := NewOpenAIClient(config.OpenAIToken, config.MaxTokens, config.Temperature)
 
 	bot := &DiscordBot{
-		Session:         session,
-		Config:          config,
-		OpenAI:          openAI,
-		GuildID:         config.GuildID,
-		CommandHandlers: make(map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate)),
+		Session:           session,
+		Config:            config,
+		OpenAI:            openAI,
+		GuildID:           config.GuildID,
+		CommandHandlers:   make(map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate)),
+		ComponentHandlers: make(map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate, args []string)),
+		Cache:             riot.NewDefaultCache(),
+		Ranks:             riot.NewLeagueRanks(),
+		MetaRanks:         riot.NewLeagueRanks(),
+		History:           NewPlayerHistory(),
+	}
+	bot.Ranks.Spacing = trackRequestSpacing
+	bot.MetaRanks.Spacing = trackRequestSpacing
+
+	// Open the persistent account links and subscriptions (in memory only without a data directory)
+	if bot.Links, err = openStore[LinkedAccount](config.DataDir, "links.json"); err != nil {
+		return nil, fmt.Errorf("error opening account links: %w", err)
+	}
+	if bot.Tracked, err = openStore[TrackedPlayer](config.DataDir, "tracking.json"); err != nil {
+		return nil, fmt.Errorf("error opening tracked players: %w", err)
+	}
+	if bot.Leaderboards, err = openStore[LeaderboardSnapshot](config.DataDir, "leaderboards.json"); err != nil {
+		return nil, fmt.Errorf("error opening leaderboard history: %w", err)
+	}
+	if bot.Guilds, err = openStore[GuildSettings](config.DataDir, "guilds.json"); err != nil {
+		return nil, fmt.Errorf("error opening guild settings: %w", err)
+	}
+
+	// Open the match archive the current meta is derived from (optional)
+	if config.ArchiveDir != "" {
+		archive, err := riot.NewMatchArchive(config.ArchiveDir)
+		if err != nil {
+			return nil, fmt.Errorf("error opening match archive: %w", err)
+		}
+		bot.Archive = archive
+	}
+
+	// Load display names for champions, traits and items (optional)
+	if static, err := riot.LoadStaticData(riot.DataDragonVersion); err != nil {
+		fmt.Printf("Error loading static data: %v\n", err)
+	} else {
+		bot.Static = static
 	}
 
 	// Set up command handlers
@@ -147,10 +818,60 @@ func NewDiscordBot(config *Config) (*DiscordBot, error) {
 	bot.CommandHandlers["lastgame"] = bot.handleLastGameCommand
 	bot.CommandHandlers["lobby"] = bot.handleLobbyCommand
 	bot.CommandHandlers["playstyle"] = bot.handlePlaystyleCommand
+	bot.CommandHandlers["duo"] = bot.handleDuoCommand
+	bot.CommandHandlers["versus"] = bot.handleVersusCommand
+	bot.CommandHandlers["session"] = bot.handleSessionCommand
+	bot.CommandHandlers["link"] = bot.handleLinkCommand
+	bot.CommandHandlers["unlink"] = bot.handleUnlinkCommand
+	bot.CommandHandlers["track"] = bot.handleTrackCommand
+	bot.CommandHandlers["untrack"] = bot.handleUntrackCommand
+	bot.CommandHandlers["leaderboard"] = bot.handleLeaderboardCommand
+	bot.CommandHandlers["match"] = bot.handleMatchCommand
+	bot.CommandHandlers["compare"] = bot.handleCompareCommand
+	bot.CommandHandlers["config"] = bot.handleConfigCommand
+
+	// Set up button and select menu handlers
+	bot.ComponentHandlers["recent"] = bot.handleRecentPage
+	bot.ComponentHandlers["lastgame"] = bot.handleLastGameParticipant
+	bot.ComponentHandlers["lobbyprofile"] = bot.handleLobbyProfile
+	bot.ComponentHandlers["matchplayer"] = bot.handleMatchPlayer
 
 	return bot, nil
 }
 
+// openStore opens a store file in the data directory, or an in-memory store without one
+func openStore[V any](dataDir, name string) (*store.Store[V], error) {
+	if dataDir == "" {
+		return store.Open[V]("")
+	}
+	return store.Open[V](filepath.Join(dataDir, name))
+}
+
+// runEvery runs task every interval in the background until the returned stop function
+// is called. The task can watch stop to end early; stop waits for it to return.
+func runEvery(interval time.Duration, task func(stop <-chan struct{})) func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	ticker := time.NewTicker(interval)
+	go func() {
+		defer close(done)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ticker.C:
+				task(stop)
+			case <-stop:
+				return
+			}
+		}
+	}()
+
+	return func() {
+		close(stop)
+		<-done
+	}
+}
+
 // Start starts the Discord bot
 func (b *DiscordBot) Start() error {
 	// Get bot user ID
@@ -176,12 +897,37 @@ func (b *DiscordBot) Start() error {
 	}
 	b.Commands = registeredCommands
 
+	// Periodically evict expired profiles and matches from the shared cache
+	b.stopJanitor = b.Cache.StartJanitor(0)
+
+	// Post new games by tracked players to their subscribed channels
+	b.stopPoller = b.startTrackingPoller(b.Config.PollInterval)
+
+	// Post server leaderboards weekly to their report channels
+	b.stopLeaderboard = b.startWeeklyLeaderboard()
+
+	// Keep the meta snapshot current as matches are archived
+	b.stopMeta = b.startMetaRefresher()
+
 	fmt.Println("Bot is now running with slash commands registered.")
 	return nil
 }
 
 // Stop stops the Discord bot and removes commands if configured to do so
 func (b *DiscordBot) Stop() error {
+	if b.stopJanitor != nil {
+		b.stopJanitor()
+	}
+	if b.stopPoller != nil {
+		b.stopPoller()
+	}
+	if b.stopLeaderboard != nil {
+		b.stopLeaderboard()
+	}
+	if b.stopMeta != nil {
+		b.stopMeta()
+	}
+
 	// Remove commands (you can make this configurable if needed)
 	fmt.Println("Removing commands...")
 	for _, cmd := range b.Commands {
@@ -211,11 +957,28 @@ func (b *DiscordBot) registerCommands() ([]*discordgo.ApplicationCommand, error)
 
 // interactionHandler handles Discord interaction events
 func (b *DiscordBot) interactionHandler(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	// Autocomplete requests come in while the user is still typing an option
+	if i.Type == discordgo.InteractionApplicationCommandAutocomplete {
+		b.handleAutocomplete(s, i)
+		return
+	}
+
+	// Buttons and select menus on messages the bot sent
+	if i.Type == discordgo.InteractionMessageComponent {
+		b.handleComponent(s, i)
+		return
+	}
+
 	// Check if it's a command interaction
 	if i.Type == discordgo.InteractionApplicationCommand {
 		// Get command name
 		commandName := i.ApplicationCommandData().Name
 
+		// Servers can limit commands to some channels
+		if !b.checkChannelAllowed(s, i) {
+			return
+		}
+
 		// Check if there's a handler for this command
 		if handler, ok := b.CommandHandlers[commandName]; ok {
 			handler(s, i)
@@ -223,6 +986,22 @@ func (b *DiscordBot) interactionHandler(s *discordgo.Session, i *discordgo.Inter
 	}
 }
 
+// newProfileAnalyzer creates a profile analyzer wired to the bot's shared cache, archive and
+// meta snapshot, predicting lobbies with players' ranks when they can be looked up
+func (b *DiscordBot) newProfileAnalyzer() *riot.ProfileAnalyzer {
+	analyzer := riot.NewProfileAnalyzer()
+	if b.Cache != nil {
+		analyzer.Cache = b.Cache
+	}
+	analyzer.Archive = b.Archive
+	analyzer.Meta = b.currentMeta()
+	if b.Ranks != nil {
+		analyzer.Model = riot.NewPlacementModel()
+		analyzer.Model.RankOf = riot.RankRating(b.Ranks, riot.DefaultRankWeight)
+	}
+	return analyzer
+}
+
 // sendError sends an error embed
 func (b *DiscordBot) sendError(s *discordgo.Session, i *discordgo.InteractionCreate, title, description string) {
 	embed := &discordgo.MessageEmbed{
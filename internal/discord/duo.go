@@ -0,0 +1,137 @@
+package discord
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hunterjsb/tft/internal/riot"
+)
+
+// handleDuoCommand handles the /duo command
+func (b *DiscordBot) handleDuoCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	// Acknowledge the interaction immediately
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}); err != nil {
+		fmt.Printf("Error acknowledging interaction: %v\n", err)
+		return
+	}
+
+	// Parse both players' parameters
+	options := i.ApplicationCommandData().Options
+	params := ParsePlayerParams(options)
+	partnerParams := ParsePrefixedPlayerParams(options, "partner")
+
+	// Look up both accounts
+	playerResult, err := b.LookupPlayer(s, i, params)
+	if err != nil {
+		return // Error already sent to Discord
+	}
+	partnerResult, err := b.LookupPlayer(s, i, partnerParams)
+	if err != nil {
+		return // Error already sent to Discord
+	}
+
+	analyzer := b.newProfileAnalyzer()
+	duo, err := analyzer.AnalyzeDuo(playerResult.Account.PUUID, partnerResult.Account.PUUID)
+	if err != nil {
+		b.sendError(s, i, "Analysis Error", fmt.Sprintf("Could not analyze `%s` & `%s`: %v", playerResult.GetDisplayName(), partnerResult.GetDisplayName(), err))
+		return
+	}
+
+	embed := b.formatDuoAnalysis(playerResult, partnerResult, duo)
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{embed},
+	}); err != nil {
+		fmt.Printf("Error editing interaction response: %v\n", err)
+	}
+}
+
+// formatDuoAnalysis formats a Double Up pair's shared games into a Discord embed
+func (b *DiscordBot) formatDuoAnalysis(playerResult, partnerResult *PlayerLookupResult, duo *riot.DuoProfile) *discordgo.MessageEmbed {
+	performance := fmt.Sprintf("**Avg Team Placement:** #%.1f\n**Win Rate:** %.0f%%\n**Top 2 Rate:** %.0f%%",
+		duo.AvgTeamPlacement,
+		duo.WinRate*100,
+		duo.TopTwoRate*100,
+	)
+
+	damage := fmt.Sprintf("**%s:** %.0f\n**%s:** %.0f",
+		playerResult.Account.GameName, duo.AvgDamageA,
+		partnerResult.Account.GameName, duo.AvgDamageB,
+	)
+
+	return &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("👥 %s & %s", playerResult.Account.GameName, partnerResult.Account.GameName),
+		Color:       b.getColorByPerformance(duo.AvgTeamPlacement * 2), // team placements are 1-4
+		Description: fmt.Sprintf("Double Up analysis based on **%d games together**", duo.Games),
+		Author: &discordgo.MessageEmbedAuthor{
+			Name:    playerResult.GetDisplayName(),
+			IconURL: playerResult.GetProfileIconURL(),
+		},
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "📊 Team Performance",
+				Value:  performance,
+				Inline: true,
+			},
+			{
+				Name:   "💥 Avg Damage",
+				Value:  damage,
+				Inline: true,
+			},
+			{
+				Name:   "📈 Recent Form",
+				Value:  b.formatTeamRecentForm(duo.RecentForm),
+				Inline: true,
+			},
+			{
+				Name:   "⭐ Team Traits",
+				Value:  b.formatFavoriteTraits(duo.FavoriteTraits, 5),
+				Inline: true,
+			},
+		},
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+}
+
+// formatTeamRecentForm formats recent Double Up team placements (1-4) with emojis
+func (b *DiscordBot) formatTeamRecentForm(recentForm []int) string {
+	return formatPlacements(recentForm, b.getTeamPlacementEmoji)
+}
+
+// getTeamPlacementEmoji returns an emoji for a Double Up team placement: a win, a top 2 or a loss
+func (b *DiscordBot) getTeamPlacementEmoji(placement int) string {
+	switch placement {
+	case 1:
+		return "🥇"
+	case 2:
+		return "🟢"
+	default:
+		return "🔴"
+	}
+}
+
+// formatDuoPartners formats a player's favorite Double Up partners with joint results
+func (b *DiscordBot) formatDuoPartners(partners []riot.DuoPartner, limit int) string {
+	if len(partners) == 0 {
+		return "No data"
+	}
+
+	var lines []string
+	for i, partner := range partners {
+		if i >= limit {
+			break
+		}
+		name := partner.GameName
+		if name == "" {
+			name = "Unknown"
+		} else if partner.TagLine != "" {
+			name = fmt.Sprintf("%s#%s", partner.GameName, partner.TagLine)
+		}
+		lines = append(lines, fmt.Sprintf("**%s** %dg • #%.1f • Win %.0f%%", name, partner.Games, partner.AvgTeamPlacement, partner.WinRate*100))
+	}
+
+	return strings.Join(lines, "\n")
+}
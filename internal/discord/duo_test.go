@@ -0,0 +1,17 @@
+package discord
+
+import "testing"
+
+func TestFormatTeamRecentForm(t *testing.T) {
+	bot := &DiscordBot{}
+
+	result := bot.formatTeamRecentForm([]int{1, 2, 3, 4})
+	expected := "🥇1 🟢2 🔴3 🔴4"
+	if result != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, result)
+	}
+
+	if result := bot.formatTeamRecentForm(nil); result != "No recent games" {
+		t.Errorf("Expected 'No recent games', got '%s'", result)
+	}
+}
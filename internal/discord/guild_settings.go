@@ -0,0 +1,355 @@
+package discord
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hunterjsb/tft/internal/riot"
+)
+
+// Features a server can turn off with /config feature
+const (
+	featureAI       = "ai"
+	featureTracking = "tracking"
+)
+
+// featureChoices lists the features a server can toggle
+var featureChoices = []*discordgo.ApplicationCommandOptionChoice{
+	{Name: "AI analysis", Value: featureAI},
+	{Name: "Player tracking", Value: featureTracking},
+}
+
+// languageChoices lists the languages AI responses can be written in
+var languageChoices = []*discordgo.ApplicationCommandOptionChoice{
+	{Name: "English", Value: "en"},
+	{Name: "Español", Value: "es"},
+	{Name: "Português", Value: "pt"},
+	{Name: "Français", Value: "fr"},
+	{Name: "Deutsch", Value: "de"},
+	{Name: "한국어", Value: "ko"},
+	{Name: "日本語", Value: "ja"},
+	{Name: "中文", Value: "zh"},
+}
+
+// languageNames names each language code in English, for AI prompts
+var languageNames = map[string]string{
+	"en": "English",
+	"es": "Spanish",
+	"pt": "Portuguese",
+	"fr": "French",
+	"de": "German",
+	"ko": "Korean",
+	"ja": "Japanese",
+	"zh": "Chinese",
+}
+
+// configPermissions are the permissions needed to use /config
+var configPermissions int64 = discordgo.PermissionManageGuild
+
+// GuildSettings are a server's bot settings, managed with /config. The zero value is
+// the default: every channel and feature allowed, regions auto-detected, English.
+type GuildSettings struct {
+	Region           string    `json:"region,omitempty"`           // platform used when a command has no region, e.g. "EUW1"
+	AllowedChannels  []string  `json:"allowedChannels,omitempty"`  // channels commands work in; empty allows all
+	DisabledFeatures []string  `json:"disabledFeatures,omitempty"` // see featureChoices
+	ReportChannel    string    `json:"reportChannel,omitempty"`    // where the weekly leaderboard is posted
+	ReportsOff       bool      `json:"reportsOff,omitempty"`       // no weekly leaderboard, even in the configured guild
+	Language         string    `json:"language,omitempty"`         // language code for AI responses, empty for English
+	UpdatedBy        string    `json:"updatedBy,omitempty"`        // Discord user ID of the last admin to change them
+	UpdatedAt        time.Time `json:"updatedAt"`
+}
+
+// channelAllowed reports whether commands may be used in a channel
+func (g GuildSettings) channelAllowed(channelID string) bool {
+	return len(g.AllowedChannels) == 0 || containsID(g.AllowedChannels, channelID)
+}
+
+// featureEnabled reports whether a feature is turned on
+func (g GuildSettings) featureEnabled(feature string) bool {
+	return !containsID(g.DisabledFeatures, feature)
+}
+
+// containsID reports whether ids contains id
+func containsID(ids []string, id string) bool {
+	for _, candidate := range ids {
+		if candidate == id {
+			return true
+		}
+	}
+	return false
+}
+
+// withID returns ids with id added or removed, keeping it sorted
+func withID(ids []string, id string, present bool) []string {
+	var updated []string
+	for _, candidate := range ids {
+		if candidate != id {
+			updated = append(updated, candidate)
+		}
+	}
+	if present {
+		updated = append(updated, id)
+		sort.Strings(updated)
+	}
+	return updated
+}
+
+// guildSettings returns a server's settings, or the defaults outside servers and before any are saved
+func (b *DiscordBot) guildSettings(guildID string) GuildSettings {
+	if guildID == "" || b.Guilds == nil {
+		return GuildSettings{}
+	}
+	settings, _ := b.Guilds.Get(guildID)
+	return settings
+}
+
+// aiEnabled reports whether AI responses can be generated for a server
+func (b *DiscordBot) aiEnabled(guildID string) bool {
+	return b.OpenAI != nil && b.guildSettings(guildID).featureEnabled(featureAI)
+}
+
+// localizePrompt asks for an AI response in the server's language
+func (b *DiscordBot) localizePrompt(guildID, prompt string) string {
+	language := b.guildSettings(guildID).Language
+	if name, ok := languageNames[language]; ok && language != "en" {
+		return prompt + fmt.Sprintf("\nRespond in %s.", name)
+	}
+	return prompt
+}
+
+// reportChannel returns where a server's weekly leaderboard is posted, falling back to the
+// configured channel for the configured guild. Empty when the server turned reports off.
+func (b *DiscordBot) reportChannel(guildID string) string {
+	settings := b.guildSettings(guildID)
+	if settings.ReportsOff {
+		return ""
+	}
+	if settings.ReportChannel != "" {
+		return settings.ReportChannel
+	}
+	if b.Config != nil && guildID == b.Config.GuildID {
+		return b.Config.ChannelID
+	}
+	return ""
+}
+
+// channelGuildID returns the server a channel belongs to, or "" when unknown
+func (b *DiscordBot) channelGuildID(channelID string) string {
+	if b.Session == nil || b.Session.State == nil {
+		return ""
+	}
+	if channel, err := b.Session.State.Channel(channelID); err == nil {
+		return channel.GuildID
+	}
+	if channel, err := b.Session.Channel(channelID); err == nil {
+		return channel.GuildID
+	}
+	return ""
+}
+
+// checkChannelAllowed answers commands used outside a server's allowed channels.
+// Returns false when the command shouldn't run. /config always runs so admins can't lock themselves out.
+func (b *DiscordBot) checkChannelAllowed(s *discordgo.Session, i *discordgo.InteractionCreate) bool {
+	settings := b.guildSettings(i.GuildID)
+	if i.ApplicationCommandData().Name == "config" || settings.channelAllowed(i.ChannelID) {
+		return true
+	}
+
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{
+			Embeds: []*discordgo.MessageEmbed{{
+				Title:       "Wrong Channel",
+				Description: fmt.Sprintf("Commands are limited to %s in this server", formatChannels(settings.AllowedChannels)),
+				Color:       0xff0000,
+			}},
+			Flags: discordgo.MessageFlagsEphemeral,
+		},
+	}); err != nil {
+		fmt.Printf("Error responding to interaction: %v\n", err)
+	}
+	return false
+}
+
+// configOptions are the options of the /config subcommands; each uses a few of them
+type configOptions struct {
+	Region   string `option:"region"`
+	Channel  string `option:"channel"`
+	Allowed  bool   `option:"allowed"`
+	Feature  string `option:"feature"`
+	Enabled  bool   `option:"enabled"`
+	Language string `option:"language"`
+}
+
+// handleConfigCommand handles the /config command group
+func (b *DiscordBot) handleConfigCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	// Acknowledge privately; settings are for admins
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Flags: discordgo.MessageFlagsEphemeral},
+	}); err != nil {
+		fmt.Printf("Error acknowledging interaction: %v\n", err)
+		return
+	}
+
+	if i.GuildID == "" {
+		b.sendError(s, i, "Server Only", "Settings can only be changed in servers")
+		return
+	}
+	// Default member permissions only hide the command, and servers can override them
+	if i.Member == nil || i.Member.Permissions&configPermissions == 0 {
+		b.sendError(s, i, "Missing Permission", "You need the **Manage Server** permission to change settings")
+		return
+	}
+
+	options := i.ApplicationCommandData().Options
+	if len(options) == 0 {
+		return
+	}
+	subcommand := options[0]
+	var opts configOptions
+	if err := BindOptions(subcommand.Options, &opts); err != nil {
+		b.sendError(s, i, "Invalid Input", err.Error())
+		return
+	}
+
+	settings := b.guildSettings(i.GuildID)
+	changed := subcommand.Name != "show"
+	if changed {
+		updated, problem := applyConfigChange(settings, subcommand.Name, opts)
+		if problem != "" {
+			b.sendError(s, i, "Invalid Input", problem)
+			return
+		}
+		updated.UpdatedBy = interactionUserID(i)
+		updated.UpdatedAt = time.Now()
+		if err := b.Guilds.Set(i.GuildID, updated); err != nil {
+			fmt.Printf("Error saving guild settings: %v\n", err)
+			b.sendError(s, i, "Config Failed", "Could not save the settings, please try again later")
+			return
+		}
+		settings = updated
+	}
+
+	embed := b.formatGuildSettings(i.GuildID, settings, changed)
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{embed},
+	}); err != nil {
+		fmt.Printf("Error editing interaction response: %v\n", err)
+	}
+}
+
+// applyConfigChange applies a /config subcommand to settings. When the change is
+// invalid, problem explains why to the Discord user.
+func applyConfigChange(settings GuildSettings, subcommand string, opts configOptions) (updated GuildSettings, problem string) {
+	switch subcommand {
+	case "region":
+		region := strings.ToUpper(opts.Region)
+		if _, ok := riot.RegionMapping[region]; region != "" && !ok {
+			return settings, fmt.Sprintf("Unknown region `%s`", opts.Region)
+		}
+		settings.Region = region
+	case "channel":
+		if opts.Channel == "" {
+			return settings, "`channel` is required"
+		}
+		settings.AllowedChannels = withID(settings.AllowedChannels, opts.Channel, opts.Allowed)
+	case "feature":
+		if !validChoice(featureChoices, opts.Feature) {
+			return settings, fmt.Sprintf("Unknown feature `%s`", opts.Feature)
+		}
+		settings.DisabledFeatures = withID(settings.DisabledFeatures, opts.Feature, !opts.Enabled)
+	case "reports":
+		settings.ReportsOff = !opts.Enabled
+		settings.ReportChannel = opts.Channel
+		if settings.ReportsOff {
+			settings.ReportChannel = ""
+		}
+	case "language":
+		if !validChoice(languageChoices, opts.Language) {
+			return settings, fmt.Sprintf("Unknown language `%s`", opts.Language)
+		}
+		settings.Language = opts.Language
+	case "reset":
+		settings = GuildSettings{}
+	default:
+		return settings, fmt.Sprintf("Unknown setting `%s`", subcommand)
+	}
+	return settings, ""
+}
+
+// validChoice reports whether value is one of a string option's choices
+func validChoice(choices []*discordgo.ApplicationCommandOptionChoice, value string) bool {
+	for _, choice := range choices {
+		if choice.Value == value {
+			return true
+		}
+	}
+	return false
+}
+
+// formatGuildSettings formats a server's settings into an embed
+func (b *DiscordBot) formatGuildSettings(guildID string, settings GuildSettings, changed bool) *discordgo.MessageEmbed {
+	region := settings.Region
+	if region == "" {
+		region = "Auto-detect"
+	}
+
+	channels := "All channels"
+	if len(settings.AllowedChannels) > 0 {
+		channels = formatChannels(settings.AllowedChannels)
+	}
+
+	var features []string
+	for _, choice := range featureChoices {
+		status := "✅"
+		if !settings.featureEnabled(choice.Value.(string)) {
+			status = "❌"
+		}
+		features = append(features, fmt.Sprintf("%s %s", status, choice.Name))
+	}
+
+	reports := "Off"
+	if channelID := b.reportChannel(guildID); channelID != "" {
+		reports = fmt.Sprintf("<#%s>", channelID)
+	}
+
+	language := "English"
+	for _, choice := range languageChoices {
+		if choice.Value == settings.Language {
+			language = choice.Name
+		}
+	}
+
+	title := "⚙️ Server Settings"
+	if changed {
+		title = "⚙️ Server Settings Updated"
+	}
+	embed := &discordgo.MessageEmbed{
+		Title: title,
+		Color: 0x5865f2,
+		Fields: []*discordgo.MessageEmbedField{
+			{Name: "🌍 Default Region", Value: region, Inline: true},
+			{Name: "🗣️ AI Language", Value: language, Inline: true},
+			{Name: "📅 Weekly Leaderboard", Value: reports, Inline: true},
+			{Name: "💬 Command Channels", Value: channels, Inline: false},
+			{Name: "🔧 Features", Value: strings.Join(features, "\n"), Inline: false},
+		},
+	}
+	if settings.UpdatedBy != "" {
+		embed.Description = fmt.Sprintf("Last changed by <@%s> on %s", settings.UpdatedBy, settings.UpdatedAt.Format("Jan 2"))
+	}
+	return embed
+}
+
+// formatChannels mentions each channel, e.g. "<#1>, <#2>"
+func formatChannels(channelIDs []string) string {
+	mentions := make([]string, len(channelIDs))
+	for idx, channelID := range channelIDs {
+		mentions[idx] = fmt.Sprintf("<#%s>", channelID)
+	}
+	return strings.Join(mentions, ", ")
+}
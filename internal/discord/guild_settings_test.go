@@ -0,0 +1,91 @@
+package discord
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/hunterjsb/tft/internal/store"
+)
+
+func TestApplyConfigChange(t *testing.T) {
+	settings := GuildSettings{AllowedChannels: []string{"c2"}}
+
+	updated, problem := applyConfigChange(settings, "channel", configOptions{Channel: "c1", Allowed: true})
+	if problem != "" || fmt.Sprint(updated.AllowedChannels) != "[c1 c2]" {
+		t.Errorf("Expected c1 allowed alongside c2, got %v (%q)", updated.AllowedChannels, problem)
+	}
+	updated, _ = applyConfigChange(updated, "channel", configOptions{Channel: "c2", Allowed: false})
+	if fmt.Sprint(updated.AllowedChannels) != "[c1]" {
+		t.Errorf("Expected c2 removed, got %v", updated.AllowedChannels)
+	}
+
+	updated, problem = applyConfigChange(updated, "region", configOptions{Region: "euw1"})
+	if problem != "" || updated.Region != "EUW1" {
+		t.Errorf("Expected region EUW1, got %q (%q)", updated.Region, problem)
+	}
+	if _, problem := applyConfigChange(updated, "region", configOptions{Region: "mars1"}); problem == "" {
+		t.Error("Expected an unknown region to be rejected")
+	}
+
+	updated, _ = applyConfigChange(updated, "feature", configOptions{Feature: featureAI, Enabled: false})
+	if updated.featureEnabled(featureAI) || !updated.featureEnabled(featureTracking) {
+		t.Errorf("Expected only AI disabled, got %v", updated.DisabledFeatures)
+	}
+	updated, _ = applyConfigChange(updated, "feature", configOptions{Feature: featureAI, Enabled: true})
+	if !updated.featureEnabled(featureAI) {
+		t.Errorf("Expected AI enabled again, got %v", updated.DisabledFeatures)
+	}
+	updated, _ = applyConfigChange(updated, "reports", configOptions{Enabled: true, Channel: "c3"})
+	if updated.ReportsOff || updated.ReportChannel != "c3" {
+		t.Errorf("Expected reports in c3, got %+v", updated)
+	}
+	updated, _ = applyConfigChange(updated, "reports", configOptions{Enabled: false, Channel: "c3"})
+	if !updated.ReportsOff || updated.ReportChannel != "" {
+		t.Errorf("Expected reports off, got %+v", updated)
+	}
+	if _, problem := applyConfigChange(updated, "language", configOptions{Language: "xx"}); problem == "" {
+		t.Error("Expected an unknown language to be rejected")
+	}
+
+	if reset, _ := applyConfigChange(updated, "reset", configOptions{}); reset.Region != "" || len(reset.AllowedChannels) != 0 {
+		t.Errorf("Expected defaults after reset, got %+v", reset)
+	}
+}
+
+func TestGuildSettingsDefaults(t *testing.T) {
+	guilds, err := store.Open[GuildSettings]("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := guilds.Set("g1", GuildSettings{AllowedChannels: []string{"c1"}, Language: "es", ReportChannel: "reports"}); err != nil {
+		t.Fatal(err)
+	}
+	bot := &DiscordBot{Guilds: guilds, Config: &Config{GuildID: "g2", ChannelID: "env"}}
+
+	settings := bot.guildSettings("g1")
+	if !settings.channelAllowed("c1") || settings.channelAllowed("c2") {
+		t.Errorf("Expected only c1 allowed in g1, got %v", settings.AllowedChannels)
+	}
+	if !bot.guildSettings("g2").channelAllowed("anything") || !bot.guildSettings("").channelAllowed("dm") {
+		t.Error("Expected every channel allowed without settings")
+	}
+
+	if got := bot.localizePrompt("g1", "Hi"); got != "Hi\nRespond in Spanish." {
+		t.Errorf("Unexpected localized prompt %q", got)
+	}
+	if got := bot.localizePrompt("g2", "Hi"); got != "Hi" {
+		t.Errorf("Expected an English prompt unchanged, got %q", got)
+	}
+
+	if got := fmt.Sprint(bot.reportChannels()); got != "map[g1:reports g2:env]" {
+		t.Errorf("Unexpected report channels %s", got)
+	}
+
+	// Turning reports off overrides the configured channel
+	if err := guilds.Set("g2", GuildSettings{ReportsOff: true}); err != nil {
+		t.Fatal(err)
+	}
+	if got := fmt.Sprint(bot.reportChannels()); got != "map[g1:reports]" {
+		t.Errorf("Expected reports off in g2, got %s", got)
+	}
+}
@@ -0,0 +1,364 @@
+package discord
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hunterjsb/tft/internal/riot"
+)
+
+// Leaderboard settings
+const (
+	maxLeaderboardRows       = 20
+	maxLeaderboardAnalyses   = 5         // members analyzed from scratch per /leaderboard; the rest wait for a later run
+	leaderboardCheckInterval = time.Hour // how often the weekly post checks whether a new week started
+)
+
+// Leaderboard sort orders
+const (
+	sortByRank      = "rank"
+	sortByPlacement = "placement"
+	sortByTopFour   = "top4"
+)
+
+// leaderboardOptions are the /leaderboard options
+type leaderboardOptions struct {
+	Sort  string `option:"sort" default:"rank"`
+	Games int    `option:"games" default:"20" min:"5" max:"50"`
+}
+
+// LeaderboardEntry is a linked member's standing on a guild leaderboard
+type LeaderboardEntry struct {
+	UserID       string `json:"userId"`
+	PUUID        string `json:"puuid"`
+	RiotID       string `json:"riotId"`
+	Tier         string `json:"tier,omitempty"` // empty when unranked
+	Rank         string `json:"rank,omitempty"`
+	LeaguePoints int    `json:"leaguePoints"`
+	Score        int    `json:"score"` // see riot.LeagueEntry.Score
+
+	// Recent form, filled in when sorting by placement or top 4 rate
+	Games            int     `json:"games,omitempty"`
+	AveragePlacement float64 `json:"averagePlacement,omitempty"`
+	TopFourRate      float64 `json:"topFourRate,omitempty"`
+	Pending          bool    `json:"-"` // recent form wasn't analyzed yet
+}
+
+// ranked reports whether the member has a ranked standing
+func (e LeaderboardEntry) ranked() bool {
+	return e.Tier != ""
+}
+
+// standing formats the member's rank like "Gold II 45 LP"
+func (e LeaderboardEntry) standing() string {
+	return riot.LeagueEntry{Tier: e.Tier, Rank: e.Rank, LeaguePoints: e.LeaguePoints}.String()
+}
+
+// LeaderboardSnapshot is a guild leaderboard saved by the weekly post, to compare later weeks against
+type LeaderboardSnapshot struct {
+	GuildID string             `json:"guildId"`
+	Week    string             `json:"week"` // date of the Monday the week started, YYYY-MM-DD
+	TakenAt time.Time          `json:"takenAt"`
+	Entries []LeaderboardEntry `json:"entries"` // in rank order
+}
+
+// weekStart returns the date of the Monday (UTC) starting t's week, e.g. "2025-09-01"
+func weekStart(t time.Time) string {
+	t = t.UTC()
+	offset := (int(t.Weekday()) + 6) % 7 // days since Monday
+	return t.AddDate(0, 0, -offset).Format(dateLayout)
+}
+
+// snapshotKey is the history store key of a guild's snapshot for a week
+func snapshotKey(guildID, week string) string {
+	return guildID + "/" + week
+}
+
+// latestSnapshot returns a guild's most recent leaderboard snapshot from before a week,
+// or from any week when before is empty
+func (b *DiscordBot) latestSnapshot(guildID, before string) (LeaderboardSnapshot, bool) {
+	var latest LeaderboardSnapshot
+	found := false
+	if b.Leaderboards == nil {
+		return latest, false
+	}
+	for _, key := range b.Leaderboards.Keys() { // sorted, so weeks are in order
+		if !strings.HasPrefix(key, guildID+"/") || (before != "" && key >= snapshotKey(guildID, before)) {
+			continue
+		}
+		if snapshot, ok := b.Leaderboards.Get(key); ok {
+			latest, found = snapshot, true
+		}
+	}
+	return latest, found
+}
+
+// handleLeaderboardCommand handles the /leaderboard command
+func (b *DiscordBot) handleLeaderboardCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}); err != nil {
+		fmt.Printf("Error acknowledging interaction: %v\n", err)
+		return
+	}
+
+	var opts leaderboardOptions
+	if !b.bindOptions(s, i, &opts) {
+		return // Error already sent to Discord
+	}
+	if i.GuildID == "" {
+		b.sendError(s, i, "Server Only", "Leaderboards are only available in servers")
+		return
+	}
+
+	entries := b.buildLeaderboard(i.GuildID, opts.Sort != sortByRank, opts.Games)
+	if len(entries) == 0 {
+		b.sendError(s, i, "No Linked Players", "Nobody in this server has linked a Riot account yet. Use `/link` to join the leaderboard.")
+		return
+	}
+	sortLeaderboard(entries, opts.Sort)
+
+	var previous *LeaderboardSnapshot
+	if snapshot, ok := b.latestSnapshot(i.GuildID, ""); ok {
+		previous = &snapshot
+	}
+
+	embed := formatLeaderboard("🏆 Server Leaderboard", entries, opts.Sort, opts.Games, previous)
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{embed},
+	}); err != nil {
+		fmt.Printf("Error editing interaction response: %v\n", err)
+	}
+}
+
+// buildLeaderboard looks up the ranked standing of every member who linked an account in
+// the guild, and their recent form over games when withStats is set. Recent form comes from
+// cached profiles where possible; at most maxLeaderboardAnalyses others are analyzed, spaced
+// to respect the Riot rate limit, and the rest are marked pending.
+func (b *DiscordBot) buildLeaderboard(guildID string, withStats bool, games int) []LeaderboardEntry {
+	var entries []LeaderboardEntry
+	analyzer := b.newProfileAnalyzer()
+	opts := riot.AnalysisOptions{Count: games}
+	analyzed := 0
+
+	for _, userID := range b.Links.Keys() {
+		link, ok := b.Links.Get(userID)
+		if !ok || !link.inGuild(guildID) {
+			continue
+		}
+		entry := LeaderboardEntry{UserID: userID, PUUID: link.PUUID, RiotID: link.RiotID()}
+
+		leagues, err := riot.GetTFTLeagueEntriesByPUUID(link.PUUID, platformOrDefault(link.Region))
+		if err != nil {
+			fmt.Printf("Error fetching league entries for %s: %v\n", link.RiotID(), err)
+		} else if ranked := riot.RankedEntry(leagues); ranked != nil {
+			entry.Tier = ranked.Tier
+			entry.Rank = ranked.Rank
+			entry.LeaguePoints = ranked.LeaguePoints
+			entry.Score = ranked.Score()
+		}
+
+		if withStats {
+			profile, cached := analyzer.CachedProfile(link.PUUID, opts)
+			switch {
+			case cached:
+			case analyzed >= maxLeaderboardAnalyses:
+				entry.Pending = true
+			default:
+				if analyzed > 0 {
+					time.Sleep(trackRequestSpacing)
+				}
+				analyzed++
+				// Too few recent games leaves the stats empty and the member at the bottom
+				profile, _ = analyzer.AnalyzePlayerWithOptions(link.PUUID, opts)
+			}
+			if profile != nil {
+				entry.Games = profile.SoloGames
+				entry.AveragePlacement = profile.PlayStyle.AveragePlacement
+				entry.TopFourRate = profile.PlayStyle.TopFourRate
+			}
+		}
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+// sortLeaderboard orders entries by rank, average placement or top 4 rate. Members
+// without a rank or recent solo games come last; ties fall back to rank, then Riot ID.
+func sortLeaderboard(entries []LeaderboardEntry, by string) {
+	sort.SliceStable(entries, func(a, c int) bool {
+		x, y := entries[a], entries[c]
+		switch by {
+		case sortByPlacement, sortByTopFour:
+			if (x.Games > 0) != (y.Games > 0) {
+				return x.Games > 0
+			}
+			if by == sortByPlacement && x.AveragePlacement != y.AveragePlacement {
+				return x.AveragePlacement < y.AveragePlacement
+			}
+			if by == sortByTopFour && x.TopFourRate != y.TopFourRate {
+				return x.TopFourRate > y.TopFourRate
+			}
+		}
+		if x.ranked() != y.ranked() {
+			return x.ranked()
+		}
+		if x.Score != y.Score {
+			return x.Score > y.Score
+		}
+		return strings.ToLower(x.RiotID) < strings.ToLower(y.RiotID)
+	})
+}
+
+// formatLeaderboard formats sorted entries into an embed, with changes since previous when given
+func formatLeaderboard(title string, entries []LeaderboardEntry, by string, games int, previous *LeaderboardSnapshot) *discordgo.MessageEmbed {
+	var lines []string
+	for idx, entry := range entries {
+		if idx >= maxLeaderboardRows {
+			lines = append(lines, fmt.Sprintf("*…and %d more*", len(entries)-idx))
+			break
+		}
+
+		stat := entry.standing()
+		switch {
+		case by != sortByRank && entry.Pending:
+			stat = "not analyzed yet"
+		case by != sortByRank && entry.Games == 0:
+			stat = "no solo games"
+		case by == sortByPlacement:
+			stat = fmt.Sprintf("#%.1f avg · %s", entry.AveragePlacement, stat)
+		case by == sortByTopFour:
+			stat = fmt.Sprintf("%.0f%% top 4 · %s", entry.TopFourRate*100, stat)
+		}
+		lines = append(lines, fmt.Sprintf("%s <@%s> `%s` — %s%s", leaderboardPosition(idx+1), entry.UserID, entry.RiotID, stat, leaderboardDelta(entry, idx+1, previous)))
+	}
+
+	footer := "Sorted by rank"
+	switch by {
+	case sortByPlacement:
+		footer = fmt.Sprintf("Sorted by average placement over the last %d games", games)
+	case sortByTopFour:
+		footer = fmt.Sprintf("Sorted by top 4 rate over the last %d games", games)
+	}
+	pending := 0
+	for _, entry := range entries {
+		if entry.Pending {
+			pending++
+		}
+	}
+	if pending > 0 {
+		footer += fmt.Sprintf(" • %d not analyzed yet, run it again shortly to include them", pending)
+	}
+	if previous != nil {
+		footer += fmt.Sprintf(" • Changes since the week of %s", previous.Week)
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       title,
+		Description: strings.Join(lines, "\n"),
+		Color:       0xffd700,
+		Footer:      &discordgo.MessageEmbedFooter{Text: footer},
+		Timestamp:   time.Now().Format(time.RFC3339),
+	}
+}
+
+// leaderboardPosition formats a position, with medals for the podium
+func leaderboardPosition(position int) string {
+	switch position {
+	case 1:
+		return "🥇"
+	case 2:
+		return "🥈"
+	case 3:
+		return "🥉"
+	default:
+		return fmt.Sprintf("**%d.**", position)
+	}
+}
+
+// leaderboardDelta describes how a member moved since the previous snapshot,
+// e.g. " ▲2 (+45 LP)", or " 🆕" for members who weren't on it
+func leaderboardDelta(entry LeaderboardEntry, position int, previous *LeaderboardSnapshot) string {
+	if previous == nil {
+		return ""
+	}
+	for idx, old := range previous.Entries {
+		if old.PUUID != entry.PUUID {
+			continue
+		}
+		delta := ""
+		switch moved := idx + 1 - position; {
+		case moved > 0:
+			delta = fmt.Sprintf(" ▲%d", moved)
+		case moved < 0:
+			delta = fmt.Sprintf(" ▼%d", -moved)
+		}
+		if entry.ranked() && old.ranked() && entry.Score != old.Score {
+			delta += fmt.Sprintf(" (%+d LP)", entry.Score-old.Score)
+		}
+		return delta
+	}
+	return " 🆕"
+}
+
+// startWeeklyLeaderboard posts the leaderboard of every guild with a report channel once a week
+func (b *DiscordBot) startWeeklyLeaderboard() func() {
+	return runEvery(leaderboardCheckInterval, func(<-chan struct{}) {
+		now := time.Now()
+		for guildID, channelID := range b.reportChannels() {
+			b.postWeeklyLeaderboard(guildID, channelID, now)
+		}
+	})
+}
+
+// reportChannels maps each guild with a weekly leaderboard to the channel it's posted in
+func (b *DiscordBot) reportChannels() map[string]string {
+	channels := make(map[string]string)
+	var guildIDs []string
+	if b.Config != nil {
+		guildIDs = append(guildIDs, b.Config.GuildID)
+	}
+	if b.Guilds != nil {
+		guildIDs = append(guildIDs, b.Guilds.Keys()...)
+	}
+	for _, guildID := range guildIDs {
+		if channelID := b.reportChannel(guildID); guildID != "" && channelID != "" {
+			channels[guildID] = channelID
+		}
+	}
+	return channels
+}
+
+// postWeeklyLeaderboard posts a guild's leaderboard, compared with the previous week's,
+// unless it was already posted this week, and saves it to the history
+func (b *DiscordBot) postWeeklyLeaderboard(guildID, channelID string, now time.Time) {
+	week := weekStart(now)
+	if _, posted := b.Leaderboards.Get(snapshotKey(guildID, week)); posted {
+		return
+	}
+
+	entries := b.buildLeaderboard(guildID, false, 0)
+	if len(entries) == 0 {
+		return
+	}
+	sortLeaderboard(entries, sortByRank)
+
+	var previous *LeaderboardSnapshot
+	if snapshot, ok := b.latestSnapshot(guildID, week); ok {
+		previous = &snapshot
+	}
+
+	embed := formatLeaderboard(fmt.Sprintf("🏆 Weekly Leaderboard — %s", week), entries, sortByRank, 0, previous)
+	if _, err := b.Session.ChannelMessageSendEmbed(channelID, embed); err != nil {
+		fmt.Printf("Error posting weekly leaderboard: %v\n", err)
+		return
+	}
+
+	snapshot := LeaderboardSnapshot{GuildID: guildID, Week: week, TakenAt: now, Entries: entries}
+	if err := b.Leaderboards.Set(snapshotKey(guildID, week), snapshot); err != nil {
+		fmt.Printf("Error saving leaderboard history: %v\n", err)
+	}
+}
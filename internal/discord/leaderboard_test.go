@@ -0,0 +1,124 @@
+package discord
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/hunterjsb/tft/internal/store"
+)
+
+func TestSortLeaderboard(t *testing.T) {
+	entries := func() []LeaderboardEntry {
+		return []LeaderboardEntry{
+			{RiotID: "unranked#1", Games: 10, AveragePlacement: 3.0, TopFourRate: 0.7},
+			{RiotID: "gold#1", Tier: "GOLD", Score: 1345, Games: 10, AveragePlacement: 4.5, TopFourRate: 0.4},
+			{RiotID: "master#1", Tier: "MASTER", Score: 2900},
+			{RiotID: "plat#1", Tier: "PLATINUM", Score: 1700, Games: 10, AveragePlacement: 3.5, TopFourRate: 0.6},
+			// Double Up games alone leave no placement stats to sort by
+			{RiotID: "duo#1", Tier: "DIAMOND", Score: 2500},
+		}
+	}
+	order := func(entries []LeaderboardEntry) string {
+		var ids []string
+		for _, entry := range entries {
+			ids = append(ids, entry.RiotID)
+		}
+		return fmt.Sprint(ids)
+	}
+
+	tests := map[string]string{
+		sortByRank:      "[master#1 duo#1 plat#1 gold#1 unranked#1]",
+		sortByPlacement: "[unranked#1 plat#1 gold#1 master#1 duo#1]",
+		sortByTopFour:   "[unranked#1 plat#1 gold#1 master#1 duo#1]",
+	}
+	for by, want := range tests {
+		sorted := entries()
+		sortLeaderboard(sorted, by)
+		if got := order(sorted); got != want {
+			t.Errorf("Sort by %s: expected %s, got %s", by, want, got)
+		}
+	}
+}
+
+func TestWeekStart(t *testing.T) {
+	tests := map[string]string{
+		"2025-09-01T00:00:00Z": "2025-09-01", // Monday
+		"2025-09-03T12:00:00Z": "2025-09-01",
+		"2025-09-07T23:59:59Z": "2025-09-01", // Sunday
+		"2025-09-08T00:00:00Z": "2025-09-08",
+	}
+	for at, want := range tests {
+		now, _ := time.Parse(time.RFC3339, at)
+		if got := weekStart(now); got != want {
+			t.Errorf("weekStart(%s) = %s, want %s", at, got, want)
+		}
+	}
+}
+
+func TestLatestSnapshot(t *testing.T) {
+	history, err := store.Open[LeaderboardSnapshot]("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, snapshot := range []LeaderboardSnapshot{
+		{GuildID: "g1", Week: "2025-08-25"},
+		{GuildID: "g1", Week: "2025-09-01"},
+		{GuildID: "g2", Week: "2025-09-08"},
+	} {
+		if err := history.Set(snapshotKey(snapshot.GuildID, snapshot.Week), snapshot); err != nil {
+			t.Fatal(err)
+		}
+	}
+	bot := &DiscordBot{Leaderboards: history}
+
+	if snapshot, ok := bot.latestSnapshot("g1", ""); !ok || snapshot.Week != "2025-09-01" {
+		t.Errorf("Expected latest g1 week 2025-09-01, got %+v", snapshot)
+	}
+	if snapshot, ok := bot.latestSnapshot("g1", "2025-09-01"); !ok || snapshot.Week != "2025-08-25" {
+		t.Errorf("Expected g1 week before 2025-09-01 to be 2025-08-25, got %+v", snapshot)
+	}
+	if _, ok := bot.latestSnapshot("g3", ""); ok {
+		t.Error("Expected no snapshot for an unknown guild")
+	}
+}
+
+func TestFormatLeaderboard(t *testing.T) {
+	entries := []LeaderboardEntry{
+		{UserID: "u1", PUUID: "p1", RiotID: "climber#1", Tier: "GOLD", Rank: "I", LeaguePoints: 20, Score: 1620},
+		{UserID: "u2", PUUID: "p2", RiotID: "steady#1", Tier: "GOLD", Rank: "II", LeaguePoints: 50, Score: 1550},
+		{UserID: "u3", PUUID: "p3", RiotID: "newbie#1"},
+	}
+	previous := &LeaderboardSnapshot{Week: "2025-09-01", Entries: []LeaderboardEntry{
+		{PUUID: "p2", Tier: "GOLD", Score: 1550},
+		{PUUID: "p1", Tier: "GOLD", Score: 1575},
+	}}
+
+	embed := formatLeaderboard("Leaderboard", entries, sortByRank, 0, previous)
+	for _, want := range []string{
+		"🥇 <@u1> `climber#1` — Gold I 20 LP ▲1 (+45 LP)",
+		"🥈 <@u2> `steady#1` — Gold II 50 LP ▼1",
+		"🥉 <@u3> `newbie#1` — Unranked 🆕",
+	} {
+		if !containsString(embed.Description, want) {
+			t.Errorf("Expected %q in %q", want, embed.Description)
+		}
+	}
+	if !containsString(embed.Footer.Text, "week of 2025-09-01") {
+		t.Errorf("Expected the compared week in the footer, got %q", embed.Footer.Text)
+	}
+
+	embed = formatLeaderboard("Leaderboard", entries[2:], sortByPlacement, 20, nil)
+	if !containsString(embed.Description, "no solo games") || containsString(embed.Description, "🆕") {
+		t.Errorf("Expected no stats and no deltas, got %q", embed.Description)
+	}
+
+	pending := []LeaderboardEntry{entries[2], {UserID: "u4", PUUID: "p4", RiotID: "later#1", Pending: true}}
+	embed = formatLeaderboard("Leaderboard", pending, sortByTopFour, 20, nil)
+	if !containsString(embed.Description, "`later#1` — not analyzed yet") {
+		t.Errorf("Expected the pending member to be marked, got %q", embed.Description)
+	}
+	if !containsString(embed.Footer.Text, "1 not analyzed yet") {
+		t.Errorf("Expected the pending count in the footer, got %q", embed.Footer.Text)
+	}
+}
@@ -0,0 +1,217 @@
+package discord
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hunterjsb/tft/internal/riot"
+)
+
+// LinkedAccount is the Riot account a Discord user linked with /link
+type LinkedAccount struct {
+	PUUID    string    `json:"puuid"`
+	GameName string    `json:"gameName"`
+	TagLine  string    `json:"tagLine"`
+	Region   string    `json:"region,omitempty"`
+	LinkedAt time.Time `json:"linkedAt"`
+	GuildIDs []string  `json:"guildIds,omitempty"` // guilds the user linked from, for autocomplete
+}
+
+// RiotID returns the linked account as "name#tag"
+func (l LinkedAccount) RiotID() string {
+	return fmt.Sprintf("%s#%s", l.GameName, l.TagLine)
+}
+
+// inGuild reports whether the account was linked from a guild
+func (l LinkedAccount) inGuild(guildID string) bool {
+	for _, id := range l.GuildIDs {
+		if id == guildID {
+			return true
+		}
+	}
+	return false
+}
+
+// handleLinkCommand handles the /link command
+func (b *DiscordBot) handleLinkCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	// Acknowledge privately; links are personal
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Flags: discordgo.MessageFlagsEphemeral},
+	}); err != nil {
+		fmt.Printf("Error acknowledging interaction: %v\n", err)
+		return
+	}
+
+	params := ParsePlayerParams(i.ApplicationCommandData().Options)
+	region := strings.ToUpper(strings.TrimSpace(params.Region))
+	if _, ok := riot.RegionMapping[region]; region != "" && !ok {
+		b.sendError(s, i, "Invalid Input", fmt.Sprintf("Unknown region `%s`", params.Region))
+		return
+	}
+
+	// Verify the Riot ID exists before linking it
+	account, err := riot.GetAccountByRiotId(params.GameName, params.TagLine)
+	if err != nil {
+		b.sendError(s, i, "Player Not Found", fmt.Sprintf("Could not find player `%s#%s`", params.GameName, params.TagLine))
+		return
+	}
+
+	// Detect the platform from match history so later commands don't have to
+	detected := false
+	if region == "" {
+		if platform, err := riot.DetectPlatform(account.PUUID); err == nil {
+			region = platform
+			detected = true
+		}
+	}
+
+	link := LinkedAccount{
+		PUUID:    account.PUUID,
+		GameName: account.GameName,
+		TagLine:  account.TagLine,
+		Region:   region,
+		LinkedAt: time.Now(),
+	}
+	err = b.Links.Update(interactionUserID(i), func(previous LinkedAccount, _ bool) LinkedAccount {
+		// Remember every guild the user linked from, even across re-links
+		link.GuildIDs = previous.GuildIDs
+		if i.GuildID != "" && !previous.inGuild(i.GuildID) {
+			link.GuildIDs = append(link.GuildIDs, i.GuildID)
+		}
+		return link
+	})
+	if err != nil {
+		fmt.Printf("Error saving account link: %v\n", err)
+		b.sendError(s, i, "Link Failed", "Could not save your linked account, please try again later")
+		return
+	}
+
+	description := fmt.Sprintf("Linked to **%s#%s**", link.GameName, link.TagLine)
+	if link.Region != "" {
+		description += fmt.Sprintf(" on **%s**", link.Region)
+	}
+	if detected {
+		description += " (detected from your match history, use `region` to change it)"
+	}
+	description += "\n\nPlayer commands now use this account when you leave `gamename` and `tagline` blank."
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "🔗 Account Linked",
+		Description: description,
+		Color:       0x00ff00,
+		Timestamp:   time.Now().Format(time.RFC3339),
+	}
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{embed},
+	}); err != nil {
+		fmt.Printf("Error editing interaction response: %v\n", err)
+	}
+}
+
+// handleUnlinkCommand handles the /unlink command
+func (b *DiscordBot) handleUnlinkCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Flags: discordgo.MessageFlagsEphemeral},
+	}); err != nil {
+		fmt.Printf("Error acknowledging interaction: %v\n", err)
+		return
+	}
+
+	userID := interactionUserID(i)
+	link, linked := b.Links.Get(userID)
+	if !linked {
+		b.sendError(s, i, "No Linked Account", "You haven't linked a Riot account. Use `/link` to add one.")
+		return
+	}
+	if _, err := b.Links.Delete(userID); err != nil {
+		fmt.Printf("Error removing account link: %v\n", err)
+		b.sendError(s, i, "Unlink Failed", "Could not remove your linked account, please try again later")
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "🔓 Account Unlinked",
+		Description: fmt.Sprintf("Removed the link to **%s#%s**", link.GameName, link.TagLine),
+		Color:       0x00ff00,
+		Timestamp:   time.Now().Format(time.RFC3339),
+	}
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{embed},
+	}); err != nil {
+		fmt.Printf("Error editing interaction response: %v\n", err)
+	}
+}
+
+// lookupLinkedPlayer looks up the account linked to the player's Discord user.
+// Returns nil error on success, or sends appropriate error message to Discord on failure.
+func (b *DiscordBot) lookupLinkedPlayer(s *discordgo.Session, i *discordgo.InteractionCreate, params PlayerParams) (*PlayerLookupResult, error) {
+	link, problem := b.findLink(params, interactionUserID(i))
+	if problem != "" {
+		b.sendError(s, i, "Invalid Input", problem)
+		return nil, fmt.Errorf("no linked account: %s", problem)
+	}
+
+	// Refresh the Riot ID in case the player renamed since linking
+	account, err := riot.GetAccountByPUUID(link.PUUID)
+	if err != nil {
+		account = &riot.Account{PUUID: link.PUUID, GameName: link.GameName, TagLine: link.TagLine}
+	}
+	summoner, _ := riot.GetSummonerByPUUID(account.PUUID)
+
+	params.GameName = account.GameName
+	params.TagLine = account.TagLine
+	if params.Region == "" {
+		params.Region = link.Region
+	}
+	if params.Region == "" {
+		params.Region = b.guildSettings(i.GuildID).Region
+	}
+
+	result := &PlayerLookupResult{
+		Account:  account,
+		Summoner: summoner,
+		Params:   params,
+	}
+	b.History.AddLookup(i.GuildID, result.GetDisplayName())
+	return result, nil
+}
+
+// findLink returns the linked account for the params' user, or the caller's own
+// link for a primary player. When there is none, problem explains why to the Discord user.
+func (b *DiscordBot) findLink(params PlayerParams, callerID string) (link LinkedAccount, problem string) {
+	userID := params.UserID
+	if userID == "" && !params.Secondary {
+		userID = callerID
+	}
+	if userID == "" {
+		return link, "Player name is required"
+	}
+
+	linked := false
+	if b.Links != nil {
+		link, linked = b.Links.Get(userID)
+	}
+	switch {
+	case linked:
+		return link, ""
+	case userID == callerID:
+		return link, "Provide `gamename` and `tagline`, or link your Riot account with `/link`"
+	default:
+		return link, fmt.Sprintf("<@%s> hasn't linked a Riot account", userID)
+	}
+}
+
+// interactionUserID returns the ID of the user who triggered an interaction, in a guild or a DM
+func interactionUserID(i *discordgo.InteractionCreate) string {
+	if i.Member != nil && i.Member.User != nil {
+		return i.Member.User.ID
+	}
+	if i.User != nil {
+		return i.User.ID
+	}
+	return ""
+}
@@ -0,0 +1,48 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hunterjsb/tft/internal/store"
+)
+
+func TestFindLink(t *testing.T) {
+	links, err := store.Open[LinkedAccount]("")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	_ = links.Set("caller", LinkedAccount{PUUID: "caller-puuid", GameName: "Caller", TagLine: "NA1"})
+	_ = links.Set("friend", LinkedAccount{PUUID: "friend-puuid", GameName: "Friend", TagLine: "NA1"})
+	bot := &DiscordBot{Links: links}
+
+	if link, problem := bot.findLink(PlayerParams{}, "caller"); problem != "" || link.PUUID != "caller-puuid" {
+		t.Errorf("Expected caller's own link, got %+v %q", link, problem)
+	}
+	if link, problem := bot.findLink(PlayerParams{UserID: "friend"}, "caller"); problem != "" || link.PUUID != "friend-puuid" {
+		t.Errorf("Expected mentioned user's link, got %+v %q", link, problem)
+	}
+	if _, problem := bot.findLink(PlayerParams{Secondary: true}, "caller"); problem != "Player name is required" {
+		t.Errorf("Expected secondary players not to default to the caller, got %q", problem)
+	}
+	if _, problem := bot.findLink(PlayerParams{UserID: "stranger"}, "caller"); problem != "<@stranger> hasn't linked a Riot account" {
+		t.Errorf("Expected unlinked user message, got %q", problem)
+	}
+	if _, problem := bot.findLink(PlayerParams{}, "newcomer"); problem == "" {
+		t.Error("Expected unlinked caller to be told about /link")
+	}
+	if _, problem := (&DiscordBot{}).findLink(PlayerParams{}, "caller"); problem == "" {
+		t.Error("Expected a bot without links to report no link")
+	}
+}
+
+func TestInteractionUserID(t *testing.T) {
+	guild := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{Member: &discordgo.Member{User: &discordgo.User{ID: "member"}}}}
+	dm := &discordgo.InteractionCreate{Interaction: &discordgo.Interaction{User: &discordgo.User{ID: "dm"}}}
+	if id := interactionUserID(guild); id != "member" {
+		t.Errorf("Expected member ID, got %q", id)
+	}
+	if id := interactionUserID(dm); id != "dm" {
+		t.Errorf("Expected DM user ID, got %q", id)
+	}
+}
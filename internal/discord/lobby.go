@@ -35,15 +35,57 @@ func (b *DiscordBot) handleLobbyCommand(s *discordgo.Session, i *discordgo.Inter
 	}
 
 	// Analyze the entire lobby
-	analyzer := riot.NewProfileAnalyzer()
+	analyzer := b.newProfileAnalyzer()
 	lobby, err := analyzer.AnalyzeLobbyAggregated(gameInfo)
 	if err != nil {
 		b.sendError(s, i, "Analysis Error", fmt.Sprintf("Could not analyze lobby: %v", err))
 		return
 	}
 
+	// Remember the lobby so its players can be autocompleted later
+	var riotIDs []string
+	for _, profile := range lobby.Profiles {
+		if profile != nil && profile.GameName != "" && profile.TagLine != "" && profile.PUUID != playerResult.Account.PUUID {
+			riotIDs = append(riotIDs, fmt.Sprintf("%s#%s", profile.GameName, profile.TagLine))
+		}
+	}
+	b.History.SetLobby(interactionUserID(i), riotIDs)
+
 	embed := b.formatLobbyAnalysisEmbed(playerResult, gameInfo, lobby)
+	labels := lobbyPlayerLabels(gameInfo, lobby.Profiles, playerResult.Account.PUUID)
+	components := lobbyProfileMenu(gameInfo, labels, playerResult.Account.PUUID)
 
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds:     &[]*discordgo.MessageEmbed{embed},
+		Components: &components,
+	}); err != nil {
+		fmt.Printf("Error editing interaction response: %v\n", err)
+	}
+}
+
+// handleLobbyProfile handles the /lobby opponent menu by showing the chosen player's
+// full profile privately, leaving the lobby analysis in place
+func (b *DiscordBot) handleLobbyProfile(s *discordgo.Session, i *discordgo.InteractionCreate, _ []string) {
+	puuid := selectedValue(i)
+	if puuid == "" {
+		return
+	}
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Flags: discordgo.MessageFlagsEphemeral},
+	}); err != nil {
+		fmt.Printf("Error acknowledging interaction: %v\n", err)
+		return
+	}
+
+	// The lobby analysis already profiled everyone, so this is usually a cache hit
+	profile, err := b.newProfileAnalyzer().AnalyzePlayer(puuid)
+	if err != nil {
+		b.sendError(s, i, "Analysis Error", fmt.Sprintf("Could not analyze playstyle: %v", err))
+		return
+	}
+
+	embed := b.formatPlaystyleAnalysis(b.playerByPUUID(puuid), profile)
 	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
 		Embeds: &[]*discordgo.MessageEmbed{embed},
 	}); err != nil {
@@ -90,9 +132,10 @@ func (b *DiscordBot) formatLobbyAnalysisEmbed(playerResult *PlayerLookupResult,
 
 	// Map to check "you"
 	you := playerResult.Account.PUUID
+	labels := lobbyPlayerLabels(game, lobby.Profiles, you)
 
 	// Build individual player lines
-	for idx, participant := range game.Participants {
+	for _, participant := range game.Participants {
 		// Find matching profile
 		var profile *riot.PlayerProfile
 		for _, p := range lobby.Profiles {
@@ -102,12 +145,18 @@ func (b *DiscordBot) formatLobbyAnalysisEmbed(playerResult *PlayerLookupResult,
 			}
 		}
 
-		playerLabel := fmt.Sprintf("Player %d", idx+1)
-		if participant.PUUID == you {
-			playerLabel = "You"
+		playerLabel := labels[participant.PUUID]
+		if participant.PUUID == you && playerLabel != "You" {
+			playerLabel += " (You)"
 		}
 
 		value := b.formatPlayerSummary(profile)
+		for _, prediction := range lobby.Predictions {
+			if prediction.PUUID == participant.PUUID {
+				value = fmt.Sprintf("%s\n%s", b.formatPlacementPrediction(prediction), value)
+				break
+			}
+		}
 		fields = append(fields, &discordgo.MessageEmbedField{
 			Name:   fmt.Sprintf("👤 %s", playerLabel),
 			Value:  value,
@@ -115,6 +164,36 @@ func (b *DiscordBot) formatLobbyAnalysisEmbed(playerResult *PlayerLookupResult,
 		})
 	}
 
+	// Scout who overlaps with the caller's comps
+	if report, err := riot.BuildScoutingReport(you, lobby.Profiles, b.currentMeta()); err == nil {
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   "🕵️ Scouting Your Comps",
+			Value:  b.formatScoutingReport(report, labels),
+			Inline: false,
+		})
+	}
+
+	// Group Double Up teammates
+	if len(lobby.Teams) > 0 {
+		var lines []string
+		for idx, team := range lobby.Teams {
+			var members []string
+			for _, puuid := range team.PUUIDs {
+				members = append(members, labels[puuid])
+			}
+			line := fmt.Sprintf("**Team %d:** %s", idx+1, strings.Join(members, " & "))
+			if team.AvgTeamPlacement > 0 {
+				line += fmt.Sprintf(" • #%.1f", team.AvgTeamPlacement)
+			}
+			lines = append(lines, line)
+		}
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   "👥 Teams",
+			Value:  strings.Join(lines, "\n"),
+			Inline: false,
+		})
+	}
+
 	// Balance inline fields in rows of 2 or 3
 	// Discord will layout them automatically; we keep Inline true on player fields
 
@@ -141,6 +220,59 @@ func (b *DiscordBot) formatLobbyAnalysisEmbed(playerResult *PlayerLookupResult,
 	}
 }
 
+// lobbyPlayerLabels names lobby participants by Riot ID, falling back to
+// "You" for the caller and "Player N" when the Riot ID is unknown
+func lobbyPlayerLabels(game *riot.CurrentGameInfo, profiles []*riot.PlayerProfile, you string) map[string]string {
+	names := make(map[string]string, len(profiles))
+	for _, p := range profiles {
+		if p != nil && p.GameName != "" {
+			names[p.PUUID] = p.GameName
+			if p.TagLine != "" {
+				names[p.PUUID] = fmt.Sprintf("%s#%s", p.GameName, p.TagLine)
+			}
+		}
+	}
+
+	labels := make(map[string]string, len(game.Participants))
+	for idx, participant := range game.Participants {
+		switch name, ok := names[participant.PUUID]; {
+		case ok:
+			labels[participant.PUUID] = name
+		case participant.PUUID == you:
+			labels[participant.PUUID] = "You"
+		default:
+			labels[participant.PUUID] = fmt.Sprintf("Player %d", idx+1)
+		}
+	}
+	return labels
+}
+
+// formatScoutingReport lists the opponents contesting each of the caller's comps
+func (b *DiscordBot) formatScoutingReport(report *riot.ScoutingReport, labels map[string]string) string {
+	var lines []string
+	for _, comp := range report.Comps {
+		line := fmt.Sprintf("**%s** (%.0f%% of your games, #%.1f)", b.cleanCompName(comp.Comp), comp.Frequency*100, comp.AvgPlacement)
+		if comp.Comp == report.LeastContested {
+			line += " ✅"
+		}
+
+		var threats []string
+		for i, opponent := range comp.Opponents {
+			if i >= 3 {
+				break
+			}
+			threats = append(threats, fmt.Sprintf("%s %.0f%% #%.1f", labels[opponent.PUUID], opponent.Overlap*100, opponent.AvgPlacement))
+		}
+		if len(threats) == 0 {
+			line += "\n↳ Open"
+		} else {
+			line += "\n↳ " + strings.Join(threats, " • ")
+		}
+		lines = append(lines, line)
+	}
+	return strings.Join(lines, "\n")
+}
+
 // formatPlayerSummary creates a concise summary for a player's profile
 func (b *DiscordBot) formatPlayerSummary(p *riot.PlayerProfile) string {
 	if p == nil || p.AnalyzedGames == 0 {
@@ -168,14 +300,28 @@ func (b *DiscordBot) formatPlayerSummary(p *riot.PlayerProfile) string {
 		fav = strings.Join(favTraits, ", ")
 	}
 
-	// Threat indicator
+	// Placement stats only come from solo games
+	if p.SoloGames == 0 {
+		return fmt.Sprintf("_No solo games_\nFav: %s", fav)
+	}
+
+	// Threat indicator, driven by time-weighted form so recent games count more
+	form := p.Performance.WeightedPlacement
+	if form == 0 {
+		form = avg
+	}
 	threat := ""
 	switch {
-	case avg <= 3.2 && top4 >= 60:
+	case form <= 3.2 && top4 >= 60:
 		threat = "🔥 "
-	case avg <= 3.8 && top4 >= 50:
+	case form <= 3.8 && top4 >= 50:
 		threat = "💎 "
 	}
 
 	return fmt.Sprintf("%s#%.1f • Top4 %.0f%%\nStyle: %s/%s • Fav: %s", threat, avg, top4, econ, leveling, fav)
 }
+
+// formatPlacementPrediction formats a player's expected placement in the lobby
+func (b *DiscordBot) formatPlacementPrediction(prediction riot.PlacementPrediction) string {
+	return fmt.Sprintf("🎲 Expected **#%.1f** • Top4 %.0f%%", prediction.ExpectedPlacement, prediction.TopFourProbability*100)
+}
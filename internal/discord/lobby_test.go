@@ -0,0 +1,39 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/hunterjsb/tft/internal/riot"
+)
+
+func TestFormatScoutingReport(t *testing.T) {
+	bot := &DiscordBot{}
+	report := &riot.ScoutingReport{
+		LeastContested: "TFT15_Bastion+TFT15_Mentor",
+		Comps: []riot.CompScouting{
+			{Comp: "TFT15_Duelist+TFT15_Sniper", Frequency: 0.5, AvgPlacement: 3.0, Opponents: []riot.CompThreat{{PUUID: "rival", Overlap: 0.6, AvgPlacement: 1.0}}},
+			{Comp: "TFT15_Bastion+TFT15_Mentor", Frequency: 0.3, AvgPlacement: 4.0},
+		},
+	}
+
+	result := bot.formatScoutingReport(report, map[string]string{"rival": "Player 3"})
+	if !containsString(result, "Duelist + Sniper") || !containsString(result, "Player 3 60% #1.0") {
+		t.Errorf("Expected cleaned comp and rival threat, got '%s'", result)
+	}
+	if !containsString(result, "Bastion + Mentor** (30% of your games, #4.0) ✅\n↳ Open") {
+		t.Errorf("Expected least contested comp marked open, got '%s'", result)
+	}
+}
+
+func TestLobbyPlayerLabels(t *testing.T) {
+	game := &riot.CurrentGameInfo{Participants: []riot.CurrentGameParticipant{{PUUID: "me"}, {PUUID: "named"}, {PUUID: "unknown"}}}
+	profiles := []*riot.PlayerProfile{
+		{PUUID: "named", GameName: "Rival", TagLine: "EUW"},
+		nil,
+	}
+
+	labels := lobbyPlayerLabels(game, profiles, "me")
+	if labels["me"] != "You" || labels["named"] != "Rival#EUW" || labels["unknown"] != "Player 3" {
+		t.Errorf("Unexpected labels %v", labels)
+	}
+}
@@ -0,0 +1,233 @@
+package discord
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hunterjsb/tft/internal/riot"
+)
+
+// matchMenuPlaceholder prompts the /match participant menu
+const matchMenuPlaceholder = "Drill into a player's game"
+
+// matchOptions are the /match options besides the player
+type matchOptions struct {
+	Match string `option:"match" default:"last"` // a match ID, or "last" for the player's latest game
+}
+
+// handleMatchCommand handles the /match command
+func (b *DiscordBot) handleMatchCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}); err != nil {
+		fmt.Printf("Error acknowledging interaction: %v\n", err)
+		return
+	}
+
+	var opts matchOptions
+	if !b.bindOptions(s, i, &opts) {
+		return // Error already sent to Discord
+	}
+
+	matchID, highlight := "", ""
+	if strings.EqualFold(opts.Match, "last") {
+		params := ParsePlayerParams(i.ApplicationCommandData().Options)
+		playerResult, err := b.LookupPlayer(s, i, params)
+		if err != nil {
+			return // Error already sent to Discord
+		}
+
+		matchIDs, err := riot.GetTFTMatchIDsByPUUIDWithRegion(playerResult.Account.PUUID, platformOrDefault(playerResult.Params.Region), 0, 1, nil, nil)
+		if err != nil {
+			b.sendError(s, i, "API Error", "Error fetching match history from Riot API")
+			return
+		}
+		if len(matchIDs) == 0 {
+			b.sendError(s, i, "No Games Found", fmt.Sprintf("No TFT games found for `%s`", playerResult.GetDisplayName()))
+			return
+		}
+		matchID, highlight = matchIDs[0], playerResult.Account.PUUID
+	} else {
+		normalized, ok := normalizeMatchID(opts.Match)
+		if !ok {
+			b.sendError(s, i, "Invalid Input", fmt.Sprintf("`%s` isn't a match ID. Match IDs look like `NA1_5359015242`.", opts.Match))
+			return
+		}
+		matchID = normalized
+	}
+
+	match, err := riot.GetTFTMatchByID(matchID)
+	if err != nil {
+		if strings.Contains(err.Error(), "status 404") {
+			b.sendError(s, i, "Match Not Found", fmt.Sprintf("No TFT match `%s`", matchID))
+		} else {
+			b.sendError(s, i, "API Error", "Could not load match data")
+		}
+		return
+	}
+
+	embed := b.formatMatchBreakdown(match, highlight)
+	components := participantMenu(match, "", "matchplayer", matchMenuPlaceholder)
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds:     &[]*discordgo.MessageEmbed{embed},
+		Components: &components,
+	}); err != nil {
+		fmt.Printf("Error editing interaction response: %v\n", err)
+	}
+}
+
+// handleMatchPlayer handles the /match participant menu by showing that player's game
+// privately, leaving the breakdown in place; args hold the match ID
+func (b *DiscordBot) handleMatchPlayer(s *discordgo.Session, i *discordgo.InteractionCreate, args []string) {
+	puuid := selectedValue(i)
+	if len(args) != 1 || puuid == "" {
+		return
+	}
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+		Data: &discordgo.InteractionResponseData{Flags: discordgo.MessageFlagsEphemeral},
+	}); err != nil {
+		fmt.Printf("Error acknowledging interaction: %v\n", err)
+		return
+	}
+
+	match, err := riot.GetTFTMatchByID(args[0])
+	if err != nil {
+		b.sendError(s, i, "API Error", "Could not load match data")
+		return
+	}
+
+	embed := b.formatMatchForPlayer(i.GuildID, participantResult(match, puuid), match)
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{embed},
+	}); err != nil {
+		fmt.Printf("Error editing interaction response: %v\n", err)
+	}
+}
+
+// normalizeMatchID checks that id looks like "<platform>_<number>" for a platform with known
+// routing, e.g. "na1_5359015242" -> "NA1_5359015242"
+func normalizeMatchID(id string) (string, bool) {
+	platform, number, ok := strings.Cut(strings.TrimSpace(id), "_")
+	platform = strings.ToUpper(platform)
+	if _, known := riot.RegionalRouting[platform]; !ok || !known || number == "" {
+		return "", false
+	}
+	for _, r := range number {
+		if !unicode.IsDigit(r) {
+			return "", false
+		}
+	}
+	return platform + "_" + number, true
+}
+
+// formatMatchBreakdown formats every participant's placement, comp, carry and items,
+// marking the highlighted player
+func (b *DiscordBot) formatMatchBreakdown(match *riot.MatchDto, highlight string) *discordgo.MessageEmbed {
+	participants := append([]riot.ParticipantDto(nil), match.Info.Participants...)
+	sort.SliceStable(participants, func(a, c int) bool {
+		return participants[a].Placement < participants[c].Placement
+	})
+
+	var fields []*discordgo.MessageEmbedField
+	for idx, participant := range participants {
+		name := fmt.Sprintf("Player %d", idx+1)
+		if participant.RiotIDGameName != "" {
+			name = fmt.Sprintf("%s#%s", participant.RiotIDGameName, participant.RiotIDTagline)
+		}
+		if participant.PUUID == highlight {
+			name = fmt.Sprintf("__%s__", name)
+		}
+
+		fields = append(fields, &discordgo.MessageEmbedField{
+			Name:   fmt.Sprintf("%s #%d %s • L%d", b.getPlacementEmoji(participant.Placement), participant.Placement, name, participant.Level),
+			Value:  fmt.Sprintf("**Comp:** %s\n**Carry:** %s", b.formatMatchComp(participant.Traits), b.formatMatchCarry(participant.Units)),
+			Inline: false,
+		})
+	}
+
+	duration := int(match.Info.GameLength)
+	details := []string{matchQueueName(match.Info.QueueID), fmt.Sprintf("%dm %ds", duration/60, duration%60)}
+	if patch := riot.PatchFromGameVersion(match.Info.GameVersion); patch != "" {
+		details = append(details, "Patch "+patch)
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("Match %s", match.Metadata.MatchID),
+		Description: strings.Join(details, " • "),
+		Color:       0x5865f2,
+		Fields:      fields,
+		Footer: &discordgo.MessageEmbedFooter{
+			Text: time.Unix(match.Info.GameCreation/1000, 0).Format("Jan 2 3:04PM"),
+		},
+	}
+}
+
+// formatMatchComp names a board's strongest active traits, e.g. "6 Duelist • 4 Sniper"
+func (b *DiscordBot) formatMatchComp(traits []riot.TraitDto) string {
+	var active []riot.TraitDto
+	for _, trait := range traits {
+		if trait.TierCurrent > 0 {
+			active = append(active, trait)
+		}
+	}
+	sort.SliceStable(active, func(a, c int) bool {
+		if active[a].Style != active[c].Style {
+			return active[a].Style > active[c].Style
+		}
+		return active[a].NumUnits > active[c].NumUnits
+	})
+
+	var parts []string
+	for idx, trait := range active {
+		if idx >= 3 {
+			break
+		}
+		parts = append(parts, fmt.Sprintf("%d %s", trait.NumUnits, b.Static.TraitName(trait.Name)))
+	}
+	if len(parts) == 0 {
+		return "No active traits"
+	}
+	return strings.Join(parts, " • ")
+}
+
+// formatMatchCarry names a board's main carry with its star level and items
+func (b *DiscordBot) formatMatchCarry(units []riot.UnitDto) string {
+	carry, ok := mainCarry(units)
+	if !ok {
+		return "None"
+	}
+
+	// Item names replaced numeric IDs in match data; older matches only have the numbers
+	var items []string
+	for _, itemName := range carry.ItemNames {
+		items = append(items, b.Static.ItemName(itemName))
+	}
+	if len(items) == 0 {
+		for _, itemID := range carry.Items {
+			if itemID > 0 {
+				items = append(items, fmt.Sprintf("%d", itemID))
+			}
+		}
+	}
+
+	text := fmt.Sprintf("%s ★%d", b.Static.ChampionName(carry.CharacterID), carry.Tier)
+	if len(items) > 0 {
+		text += fmt.Sprintf(" [%s]", strings.Join(items, ", "))
+	}
+	return text
+}
+
+// matchQueueName names a queue from the /playstyle queue choices
+func matchQueueName(queueID int) string {
+	for _, choice := range queueChoices {
+		if choice.Value == queueID {
+			return choice.Name
+		}
+	}
+	return fmt.Sprintf("Queue %d", queueID)
+}
@@ -0,0 +1,67 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/hunterjsb/tft/internal/riot"
+)
+
+func TestNormalizeMatchID(t *testing.T) {
+	tests := map[string]string{
+		"NA1_5359015242": "NA1_5359015242",
+		" sg2_123 ":      "SG2_123",
+		"la2_42":         "LA2_42",
+		"5359015242":     "",
+		"XX1_123":        "",
+		"NA1_12a":        "",
+		"NA1_":           "",
+		"NA1_123_456":    "",
+	}
+	for input, want := range tests {
+		got, ok := normalizeMatchID(input)
+		if got != want || ok != (want != "") {
+			t.Errorf("normalizeMatchID(%q) = %q, %v; want %q", input, got, ok, want)
+		}
+	}
+}
+
+func TestFormatMatchBreakdown(t *testing.T) {
+	bot := &DiscordBot{}
+	match := &riot.MatchDto{
+		Metadata: riot.MetadataDto{MatchID: "NA1_1"},
+		Info: riot.InfoDto{
+			GameLength: 2052,
+			QueueID:    1100,
+			Participants: []riot.ParticipantDto{
+				{
+					PUUID: "second", Placement: 2, Level: 8, RiotIDGameName: "Runner", RiotIDTagline: "Up",
+					Units: []riot.UnitDto{{CharacterID: "TFT15_KaiSa", Tier: 2, ItemNames: []string{"TFT_Item_GuinsoosRageblade"}}},
+				},
+				{
+					PUUID: "first", Placement: 1, Level: 9, RiotIDGameName: "Winner", RiotIDTagline: "NA1",
+					Traits: []riot.TraitDto{
+						{Name: "TFT15_Sniper", NumUnits: 4, Style: 2, TierCurrent: 2},
+						{Name: "TFT15_Duelist", NumUnits: 6, Style: 3, TierCurrent: 3},
+						{Name: "TFT15_Bastion", NumUnits: 1, TierCurrent: 0},
+					},
+					Units: []riot.UnitDto{{CharacterID: "TFT15_Yasuo", Tier: 3, Items: []int{37, 46}}},
+				},
+			},
+		},
+	}
+
+	embed := bot.formatMatchBreakdown(match, "second")
+	if embed.Title != "Match NA1_1" || !containsString(embed.Description, "Ranked • 34m 12s") {
+		t.Errorf("Unexpected header %q / %q", embed.Title, embed.Description)
+	}
+	if len(embed.Fields) != 2 {
+		t.Fatalf("Expected a field per participant, got %d", len(embed.Fields))
+	}
+	first, second := embed.Fields[0], embed.Fields[1]
+	if !containsString(first.Name, "#1 Winner#NA1 • L9") || first.Value != "**Comp:** 6 Duelist • 4 Sniper\n**Carry:** Yasuo ★3 [37, 46]" {
+		t.Errorf("Unexpected winner field %q: %q", first.Name, first.Value)
+	}
+	if !containsString(second.Name, "__Runner#Up__") || !containsString(second.Value, "Kai Sa ★2 [Guinsoos Rageblade]") {
+		t.Errorf("Unexpected highlighted field %q: %q", second.Name, second.Value)
+	}
+}
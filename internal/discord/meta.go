@@ -0,0 +1,91 @@
+package discord
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/hunterjsb/tft/internal/riot"
+)
+
+// metaRefreshInterval is how often the archive is checked for new matches to add to the meta
+const metaRefreshInterval = time.Hour
+
+// currentMeta returns the latest meta snapshot, or nil before one has been built
+func (b *DiscordBot) currentMeta() *riot.MetaSnapshot {
+	b.metaMu.RLock()
+	defer b.metaMu.RUnlock()
+	return b.meta
+}
+
+// refreshMeta adds matches archived since the last refresh to the meta and publishes the
+// updated snapshot. With a configured rank band, only players in that band are counted.
+// When stop closes, matches not yet added are left for the next refresh.
+func (b *DiscordBot) refreshMeta(stop <-chan struct{}) {
+	if b.Archive == nil {
+		return
+	}
+	b.metaRefreshMu.Lock()
+	defer b.metaRefreshMu.Unlock()
+
+	ids, err := b.Archive.MatchIDs()
+	if err != nil {
+		fmt.Printf("Error listing archived matches: %v\n", err)
+		return
+	}
+
+	if b.metaTally == nil {
+		filter := riot.MetaFilter{}
+		if b.Config != nil && b.Config.MetaRankBand != "" && b.MetaRanks != nil {
+			filter.RankBand = b.Config.MetaRankBand
+			filter.RankOf = b.MetaRanks.Band
+		}
+		b.metaTally = riot.NewLatestMetaTally(filter)
+		b.metaSeen = make(map[string]bool)
+	}
+
+	added := 0
+	for _, id := range ids {
+		if b.metaSeen[id] {
+			continue
+		}
+		match, err := b.Archive.Get(id)
+		if err != nil {
+			fmt.Printf("Error reading archived match %s: %v\n", id, err)
+			continue
+		}
+		if !b.metaTally.Add(match, stop) {
+			return
+		}
+		b.metaSeen[id] = true
+		added++
+	}
+	if added == 0 {
+		return
+	}
+
+	meta := b.metaTally.Snapshot()
+	if meta.SampleSize == 0 {
+		return
+	}
+	b.metaMu.Lock()
+	b.meta = meta
+	b.metaMu.Unlock()
+}
+
+// startMetaRefresher builds the meta snapshot in the background, then keeps it current as
+// the archive grows. The returned stop function waits for a refresh in progress to end.
+func (b *DiscordBot) startMetaRefresher() func() {
+	stop := make(chan struct{})
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		b.refreshMeta(stop)
+	}()
+	stopTicker := runEvery(metaRefreshInterval, b.refreshMeta)
+
+	return func() {
+		close(stop)
+		<-done
+		stopTicker()
+	}
+}
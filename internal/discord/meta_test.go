@@ -0,0 +1,74 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/hunterjsb/tft/internal/riot"
+)
+
+func TestRefreshMeta(t *testing.T) {
+	archive, err := riot.NewMatchArchive(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+	bot := &DiscordBot{Archive: archive, Config: &Config{}}
+
+	board := func(matchID, puuid string) *riot.MatchDto {
+		return &riot.MatchDto{
+			Metadata: riot.MetadataDto{MatchID: matchID},
+			Info: riot.InfoDto{GameVersion: "<Releases/15.17>", Participants: []riot.ParticipantDto{{
+				PUUID:     puuid,
+				Placement: 1,
+				Traits:    []riot.TraitDto{{Name: "TFT15_Mage", NumUnits: 3, Style: 1, TierCurrent: 1}},
+			}}},
+		}
+	}
+
+	bot.refreshMeta(nil)
+	if bot.currentMeta() != nil {
+		t.Fatal("Expected no meta from an empty archive")
+	}
+
+	if err := archive.Put(board("NA1_1", "a")); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	bot.refreshMeta(nil)
+	if meta := bot.currentMeta(); meta == nil || meta.SampleSize != 1 {
+		t.Fatalf("Expected a meta of 1 board, got %+v", meta)
+	}
+
+	// New matches are picked up on the next refresh
+	if err := archive.Put(board("NA1_2", "b")); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+	bot.refreshMeta(nil)
+	if meta := bot.currentMeta(); meta.SampleSize != 2 {
+		t.Errorf("Expected the new match added to the meta, got %d boards", meta.SampleSize)
+	}
+
+	// A stopped refresh leaves its matches for the next one
+	bot = &DiscordBot{Archive: archive, Config: &Config{}}
+	stop := make(chan struct{})
+	close(stop)
+	bot.refreshMeta(stop)
+	if bot.currentMeta() != nil {
+		t.Fatal("Expected a stopped refresh not to publish a meta")
+	}
+	bot.refreshMeta(nil)
+	if meta := bot.currentMeta(); meta == nil || meta.SampleSize != 2 {
+		t.Errorf("Expected the next refresh to add both matches, got %+v", meta)
+	}
+
+	// A rank band only counts players whose league tier falls in it
+	bot = &DiscordBot{Archive: archive, Config: &Config{MetaRankBand: riot.RankBandApex}, MetaRanks: riot.NewLeagueRanks()}
+	bot.MetaRanks.Lookup = func(puuid, platform string) ([]riot.LeagueEntry, error) {
+		if puuid == "a" && platform == "NA1" {
+			return []riot.LeagueEntry{{QueueType: riot.RankedQueue, Tier: "CHALLENGER", Rank: "I"}}, nil
+		}
+		return []riot.LeagueEntry{{QueueType: riot.RankedQueue, Tier: "GOLD", Rank: "I"}}, nil
+	}
+	bot.refreshMeta(nil)
+	if meta := bot.currentMeta(); meta == nil || meta.SampleSize != 1 || meta.RankBand != riot.RankBandApex {
+		t.Errorf("Expected a %s meta of 1 board, got %+v", riot.RankBandApex, meta)
+	}
+}
@@ -0,0 +1,191 @@
+package discord
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+// dateLayout is the format of date options such as /playstyle since and until
+const dateLayout = "2006-01-02"
+
+var timeType = reflect.TypeOf(time.Time{})
+
+// OptionError is a problem with a command option, phrased for the Discord user
+type OptionError struct {
+	Option  string
+	Message string
+}
+
+func (e *OptionError) Error() string {
+	return fmt.Sprintf("`%s` %s", e.Option, e.Message)
+}
+
+// BindOptions copies command options into the tagged fields of dst, a pointer to a struct.
+// Options are matched by name, so their order and presence don't matter:
+//
+//	Count  int       `option:"count" default:"5" min:"1" max:"10"`
+//	Since  time.Time `option:"since"`                    // YYYY-MM-DD
+//	Prompt string    `option:"prompt" required:"true"`
+//	Region string    `option:"region,shared"`            // see BindPrefixedOptions
+//
+// Supported field types are string, int, int64, float64, bool and time.Time. String values
+// are trimmed; user, channel and role options bind to a string holding the ID. Range and
+// required checks fail with an *OptionError.
+func BindOptions(options []*discordgo.ApplicationCommandInteractionDataOption, dst any) error {
+	return BindPrefixedOptions(options, "", dst)
+}
+
+// BindPrefixedOptions is BindOptions for a second set of options named with a prefix,
+// e.g. "partner_gamename" for `option:"gamename"`. Fields tagged shared keep their plain name.
+func BindPrefixedOptions(options []*discordgo.ApplicationCommandInteractionDataOption, prefix string, dst any) error {
+	rv := reflect.ValueOf(dst)
+	if rv.Kind() != reflect.Pointer || rv.Elem().Kind() != reflect.Struct {
+		return fmt.Errorf("options must bind to a struct pointer, got %T", dst)
+	}
+	rv = rv.Elem()
+
+	byName := make(map[string]*discordgo.ApplicationCommandInteractionDataOption, len(options))
+	for _, option := range options {
+		byName[option.Name] = option
+	}
+
+	rt := rv.Type()
+	for idx := 0; idx < rt.NumField(); idx++ {
+		field := rt.Field(idx)
+		tag, ok := field.Tag.Lookup("option")
+		if !ok {
+			continue
+		}
+		name, flags, _ := strings.Cut(tag, ",")
+		if prefix != "" && flags != "shared" {
+			name = prefix + "_" + name
+		}
+
+		var raw any
+		if option, ok := byName[name]; ok {
+			raw = option.Value
+		} else if def, ok := field.Tag.Lookup("default"); ok {
+			raw = def
+		} else if field.Tag.Get("required") == "true" {
+			return &OptionError{Option: name, Message: "is required"}
+		} else {
+			continue
+		}
+
+		if err := setOption(rv.Field(idx), raw); err != nil {
+			return &OptionError{Option: name, Message: err.Error()}
+		}
+		if err := checkRange(rv.Field(idx), field.Tag); err != nil {
+			return &OptionError{Option: name, Message: err.Error()}
+		}
+	}
+	return nil
+}
+
+// setOption converts a raw option value (or a default from a tag) into the field's type
+func setOption(field reflect.Value, raw any) error {
+	if field.Type() == timeType {
+		text, _ := raw.(string)
+		date, err := time.Parse(dateLayout, strings.TrimSpace(text))
+		if err != nil {
+			return fmt.Errorf("must be a date like 2025-08-31")
+		}
+		field.Set(reflect.ValueOf(date))
+		return nil
+	}
+
+	switch field.Kind() {
+	case reflect.String:
+		text, ok := raw.(string)
+		if !ok {
+			return fmt.Errorf("must be text")
+		}
+		field.SetString(strings.TrimSpace(text))
+	case reflect.Int, reflect.Int64:
+		number, err := optionNumber(raw)
+		if err != nil || number != float64(int64(number)) {
+			return fmt.Errorf("must be a whole number")
+		}
+		field.SetInt(int64(number))
+	case reflect.Float64:
+		number, err := optionNumber(raw)
+		if err != nil {
+			return fmt.Errorf("must be a number")
+		}
+		field.SetFloat(number)
+	case reflect.Bool:
+		switch value := raw.(type) {
+		case bool:
+			field.SetBool(value)
+		case string:
+			parsed, err := strconv.ParseBool(value)
+			if err != nil {
+				return fmt.Errorf("must be true or false")
+			}
+			field.SetBool(parsed)
+		default:
+			return fmt.Errorf("must be true or false")
+		}
+	default:
+		return fmt.Errorf("has unsupported type %s", field.Type())
+	}
+	return nil
+}
+
+// optionNumber reads a number from an option value; Discord sends numbers as JSON floats
+func optionNumber(raw any) (float64, error) {
+	switch value := raw.(type) {
+	case float64:
+		return value, nil
+	case int:
+		return float64(value), nil
+	case int64:
+		return float64(value), nil
+	case string:
+		return strconv.ParseFloat(strings.TrimSpace(value), 64)
+	default:
+		return 0, fmt.Errorf("not a number: %v", raw)
+	}
+}
+
+// checkRange enforces the min and max tags on numeric fields
+func checkRange(field reflect.Value, tag reflect.StructTag) error {
+	var value float64
+	switch field.Kind() {
+	case reflect.Int, reflect.Int64:
+		value = float64(field.Int())
+	case reflect.Float64:
+		value = field.Float()
+	default:
+		return nil
+	}
+
+	minTag, hasMin := tag.Lookup("min")
+	maxTag, hasMax := tag.Lookup("max")
+	lo, _ := strconv.ParseFloat(minTag, 64)
+	hi, _ := strconv.ParseFloat(maxTag, 64)
+	switch {
+	case hasMin && hasMax && (value < lo || value > hi):
+		return fmt.Errorf("must be between %s and %s", minTag, maxTag)
+	case hasMin && value < lo:
+		return fmt.Errorf("must be at least %s", minTag)
+	case hasMax && value > hi:
+		return fmt.Errorf("must be at most %s", maxTag)
+	}
+	return nil
+}
+
+// bindOptions binds the interaction's options into dst, sending validation errors to Discord.
+// Returns false when the command should stop.
+func (b *DiscordBot) bindOptions(s *discordgo.Session, i *discordgo.InteractionCreate, dst any) bool {
+	if err := BindOptions(i.ApplicationCommandData().Options, dst); err != nil {
+		b.sendError(s, i, "Invalid Input", err.Error())
+		return false
+	}
+	return true
+}
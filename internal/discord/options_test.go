@@ -0,0 +1,72 @@
+package discord
+
+import (
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestBindOptions(t *testing.T) {
+	type testOptions struct {
+		Count  int       `option:"count" default:"5" min:"1" max:"10"`
+		Ratio  float64   `option:"ratio" max:"1"`
+		Since  time.Time `option:"since"`
+		Prompt string    `option:"prompt" required:"true"`
+		Region string    `option:"region,shared"`
+		Name   string    `option:"name"`
+		Loud   bool      `option:"loud"`
+	}
+
+	var opts testOptions
+	err := BindOptions([]*discordgo.ApplicationCommandInteractionDataOption{
+		{Name: "prompt", Type: discordgo.ApplicationCommandOptionString, Value: "  hi  "},
+		{Name: "since", Type: discordgo.ApplicationCommandOptionString, Value: "2025-08-01"},
+		{Name: "loud", Type: discordgo.ApplicationCommandOptionBoolean, Value: true},
+	}, &opts)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if opts.Count != 5 || opts.Prompt != "hi" || !opts.Loud || opts.Since.Day() != 1 {
+		t.Errorf("Unexpected bound options: %+v", opts)
+	}
+
+	errorCases := map[string][]*discordgo.ApplicationCommandInteractionDataOption{
+		"`prompt` is required": {},
+		"`count` must be between 1 and 10": {
+			{Name: "prompt", Type: discordgo.ApplicationCommandOptionString, Value: "hi"},
+			{Name: "count", Type: discordgo.ApplicationCommandOptionInteger, Value: float64(11)},
+		},
+		"`ratio` must be at most 1": {
+			{Name: "prompt", Type: discordgo.ApplicationCommandOptionString, Value: "hi"},
+			{Name: "ratio", Type: discordgo.ApplicationCommandOptionNumber, Value: 1.5},
+		},
+		"`since` must be a date like 2025-08-31": {
+			{Name: "prompt", Type: discordgo.ApplicationCommandOptionString, Value: "hi"},
+			{Name: "since", Type: discordgo.ApplicationCommandOptionString, Value: "yesterday"},
+		},
+	}
+	for want, options := range errorCases {
+		var opts testOptions
+		if err := BindOptions(options, &opts); err == nil || err.Error() != want {
+			t.Errorf("Expected %q, got %v", want, err)
+		}
+	}
+
+	var prefixed testOptions
+	if err := BindPrefixedOptions([]*discordgo.ApplicationCommandInteractionDataOption{
+		{Name: "name", Type: discordgo.ApplicationCommandOptionString, Value: "first"},
+		{Name: "other_name", Type: discordgo.ApplicationCommandOptionString, Value: "second"},
+		{Name: "other_prompt", Type: discordgo.ApplicationCommandOptionString, Value: "hi"},
+		{Name: "region", Type: discordgo.ApplicationCommandOptionString, Value: "EUW1"},
+	}, "other", &prefixed); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if prefixed.Name != "second" || prefixed.Region != "EUW1" {
+		t.Errorf("Expected prefixed name and shared region, got %+v", prefixed)
+	}
+
+	if err := BindOptions(nil, testOptions{}); err == nil {
+		t.Error("Expected error binding to a non-pointer")
+	}
+}
@@ -10,9 +10,13 @@ import (
 
 // PlayerParams holds parsed player information from Discord command options
 type PlayerParams struct {
-	GameName string
-	TagLine  string
-	Region   string // Optional, empty string means auto-detect
+	RiotID   string `option:"riotid"` // "name#tag" in one field; gamename and tagline take precedence
+	GameName string `option:"gamename"`
+	TagLine  string `option:"tagline"`
+	Region   string `option:"region,shared"` // Optional, empty string means auto-detect
+	UserID   string `option:"user"`          // Discord user whose linked account is used when no Riot ID is given
+	// Secondary players (a partner or opponent) never default to the caller's own linked account
+	Secondary bool
 }
 
 // PlayerLookupResult contains the result of looking up a player
@@ -23,26 +27,46 @@ type PlayerLookupResult struct {
 }
 
 // ParsePlayerParams extracts player information from Discord command options.
-// Expects options in order: [gamename, tagline, region (optional)]
+// Options are matched by name (riotid, gamename, tagline, region, user) so other options may appear in any order.
 func ParsePlayerParams(options []*discordgo.ApplicationCommandInteractionDataOption) PlayerParams {
 	params := PlayerParams{}
+	_ = BindOptions(options, &params) // text options can't fail to bind
+	params.splitRiotID()
+	return params
+}
 
-	if len(options) > 0 {
-		params.GameName = options[0].StringValue()
-	}
-	if len(options) > 1 {
-		params.TagLine = options[1].StringValue()
+// ParsePrefixedPlayerParams extracts a second player's parameters from options
+// named with a prefix, e.g. "partner_gamename", "partner_tagline" and "partner_user".
+// The region is shared with the first player.
+func ParsePrefixedPlayerParams(options []*discordgo.ApplicationCommandInteractionDataOption, prefix string) PlayerParams {
+	params := PlayerParams{Secondary: true}
+	_ = BindPrefixedOptions(options, prefix, &params)
+	params.splitRiotID()
+	return params
+}
+
+// splitRiotID fills the game name and tagline from a combined "name#tag" Riot ID
+// when neither was given separately
+func (params *PlayerParams) splitRiotID() {
+	if params.RiotID == "" || params.GameName != "" || params.TagLine != "" {
+		return
 	}
-	if len(options) > 2 && options[2].StringValue() != "" {
-		params.Region = options[2].StringValue()
+	if idx := strings.LastIndex(params.RiotID, "#"); idx >= 0 {
+		params.GameName = strings.TrimSpace(params.RiotID[:idx])
+		params.TagLine = strings.TrimSpace(params.RiotID[idx+1:])
+	} else {
+		params.GameName = params.RiotID
 	}
-
-	return params
 }
 
 // LookupPlayer performs account and summoner lookup for the given player parameters.
 // Returns nil error on success, or sends appropriate error message to Discord on failure.
 func (b *DiscordBot) LookupPlayer(s *discordgo.Session, i *discordgo.InteractionCreate, params PlayerParams) (*PlayerLookupResult, error) {
+	// Without a Riot ID, use the mentioned user's or the caller's linked account
+	if params.GameName == "" && params.TagLine == "" {
+		return b.lookupLinkedPlayer(s, i, params)
+	}
+
 	// Validate required parameters
 	if params.GameName == "" {
 		b.sendError(s, i, "Invalid Input", "Player name is required")
@@ -63,17 +87,23 @@ func (b *DiscordBot) LookupPlayer(s *discordgo.Session, i *discordgo.Interaction
 	// Try to get summoner info (optional, non-fatal if it fails)
 	summoner, _ := riot.GetSummonerByPUUID(account.PUUID)
 
-	return &PlayerLookupResult{
+	if params.Region == "" {
+		params.Region = b.guildSettings(i.GuildID).Region
+	}
+
+	result := &PlayerLookupResult{
 		Account:  account,
 		Summoner: summoner,
 		Params:   params,
-	}, nil
+	}
+	b.History.AddLookup(i.GuildID, result.GetDisplayName())
+	return result, nil
 }
 
 // GetActiveGame fetches the active TFT game for a player, respecting the region parameter.
 // Returns nil error on success, or sends appropriate error message to Discord on failure.
 func (b *DiscordBot) GetActiveGame(s *discordgo.Session, i *discordgo.InteractionCreate, result *PlayerLookupResult) (*riot.CurrentGameInfo, error) {
-	gameInfo, err := riot.GetActiveTFTGameByPUUIDWithRegionOrDefault(result.Account.PUUID, result.Params.Region)
+	gameInfo, err := b.newProfileAnalyzer().GetActiveGame(result.Account.PUUID, result.Params.Region)
 	if err != nil {
 		if strings.Contains(err.Error(), "status 404") {
 			errorMsg := fmt.Sprintf("`%s#%s` is not currently in a TFT game.", result.Account.GameName, result.Account.TagLine)
@@ -99,7 +129,7 @@ func (result *PlayerLookupResult) GetProfileIconURL() string {
 		iconID = result.Summoner.ProfileIconID
 	}
 	if iconID > 0 {
-		return fmt.Sprintf("https://ddragon.leagueoflegends.com/cdn/15.17.1/img/profileicon/%d.png", iconID)
+		return fmt.Sprintf("https://ddragon.leagueoflegends.com/cdn/%s/img/profileicon/%d.png", riot.DataDragonVersion, iconID)
 	}
 	return ""
 }
@@ -0,0 +1,61 @@
+package discord
+
+import (
+	"testing"
+
+	"github.com/bwmarrin/discordgo"
+)
+
+func TestParsePrefixedPlayerParams(t *testing.T) {
+	options := []*discordgo.ApplicationCommandInteractionDataOption{
+		{Name: "gamename", Type: discordgo.ApplicationCommandOptionString, Value: "PlayerOne"},
+		{Name: "tagline", Type: discordgo.ApplicationCommandOptionString, Value: "NA1"},
+		{Name: "opponent_gamename", Type: discordgo.ApplicationCommandOptionString, Value: "PlayerTwo"},
+		{Name: "opponent_tagline", Type: discordgo.ApplicationCommandOptionString, Value: "EUW"},
+		{Name: "region", Type: discordgo.ApplicationCommandOptionString, Value: "NA1"},
+	}
+
+	player := ParsePlayerParams(options)
+	opponent := ParsePrefixedPlayerParams(options, "opponent")
+	if player.GameName != "PlayerOne" || player.TagLine != "NA1" {
+		t.Errorf("Unexpected player params: %+v", player)
+	}
+	if opponent.GameName != "PlayerTwo" || opponent.TagLine != "EUW" || opponent.Region != "NA1" {
+		t.Errorf("Unexpected opponent params: %+v", opponent)
+	}
+}
+
+func TestParsePlayerParams_UserOptions(t *testing.T) {
+	options := []*discordgo.ApplicationCommandInteractionDataOption{
+		{Name: "user", Type: discordgo.ApplicationCommandOptionUser, Value: "111"},
+		{Name: "partner_user", Type: discordgo.ApplicationCommandOptionUser, Value: "222"},
+	}
+
+	player := ParsePlayerParams(options)
+	partner := ParsePrefixedPlayerParams(options, "partner")
+	if player.UserID != "111" || player.Secondary {
+		t.Errorf("Unexpected player params: %+v", player)
+	}
+	if partner.UserID != "222" || !partner.Secondary {
+		t.Errorf("Unexpected partner params: %+v", partner)
+	}
+}
+
+func TestParsePlayerParams_RiotID(t *testing.T) {
+	options := []*discordgo.ApplicationCommandInteractionDataOption{
+		{Name: "riotid", Type: discordgo.ApplicationCommandOptionString, Value: "Name With#Hash #NA1"},
+		{Name: "partner_riotid", Type: discordgo.ApplicationCommandOptionString, Value: "NoTag"},
+		{Name: "opponent_riotid", Type: discordgo.ApplicationCommandOptionString, Value: "Ignored#NA1"},
+		{Name: "opponent_gamename", Type: discordgo.ApplicationCommandOptionString, Value: "Explicit"},
+	}
+
+	if player := ParsePlayerParams(options); player.GameName != "Name With#Hash" || player.TagLine != "NA1" {
+		t.Errorf("Expected split on the last #, got %+v", player)
+	}
+	if partner := ParsePrefixedPlayerParams(options, "partner"); partner.GameName != "NoTag" || partner.TagLine != "" {
+		t.Errorf("Expected a Riot ID without a tag to fill only the name, got %+v", partner)
+	}
+	if opponent := ParsePrefixedPlayerParams(options, "opponent"); opponent.GameName != "Explicit" || opponent.TagLine != "" {
+		t.Errorf("Expected separate options to take precedence, got %+v", opponent)
+	}
+}
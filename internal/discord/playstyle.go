@@ -29,9 +29,16 @@ func (b *DiscordBot) handlePlaystyleCommand(s *discordgo.Session, i *discordgo.I
 		return // Error already sent to Discord
 	}
 
+	// Parse the analysis window
+	opts, err := parseAnalysisOptions(i.ApplicationCommandData().Options)
+	if err != nil {
+		b.sendError(s, i, "Invalid Input", err.Error())
+		return
+	}
+
 	// Analyze the player's playstyle using our profiling system
-	analyzer := riot.NewProfileAnalyzer()
-	profile, err := analyzer.AnalyzePlayer(playerResult.Account.PUUID)
+	analyzer := b.newProfileAnalyzer()
+	profile, err := analyzer.AnalyzePlayerWithOptions(playerResult.Account.PUUID, opts)
 	if err != nil {
 		b.sendError(s, i, "Analysis Error", fmt.Sprintf("Could not analyze playstyle: %v", err))
 		return
@@ -58,6 +65,15 @@ func (b *DiscordBot) formatPlaystyleAnalysis(playerResult *PlayerLookupResult, p
 		b.getConsistencyDescription(profile.Performance.ConsistencyScore),
 		capitalizeFirst(profile.Performance.ClimbingTrend),
 	)
+	if profile.Performance.TiltIndicator >= 0.3 {
+		performanceSummary += fmt.Sprintf("\n**Tilt:** %s", b.getTiltDescription(profile.Performance.TiltIndicator))
+	}
+
+	// Placement stats only come from solo games; Double Up is summarized below
+	if profile.SoloGames == 0 {
+		embedColor = 0x808080 // Gray
+		performanceSummary = "_No solo games_"
+	}
 
 	// Build playstyle description
 	playstyleDesc := fmt.Sprintf("**Economy:** %s\n**Leveling:** %s\n**High Rolls:** %d games\n**Low Rolls:** %d games",
@@ -66,6 +82,12 @@ func (b *DiscordBot) formatPlaystyleAnalysis(playerResult *PlayerLookupResult, p
 		profile.Performance.HighRollGames,
 		profile.Performance.LowRollGames,
 	)
+	if profile.CompPreference.MetaFollower > 0 || profile.PlayStyle.ContestRate > 0 {
+		playstyleDesc += fmt.Sprintf("\n**Meta Follower:** %.0f%%\n**Contested Comps:** %.0f%%",
+			profile.CompPreference.MetaFollower*100,
+			profile.PlayStyle.ContestRate*100,
+		)
+	}
 
 	// Get favorite traits (top 5)
 	favoriteTraits := b.formatFavoriteTraits(profile.CompPreference.FavoriteTraits, 5)
@@ -84,7 +106,7 @@ func (b *DiscordBot) formatPlaystyleAnalysis(playerResult *PlayerLookupResult, p
 			Name:    playerResult.GetDisplayName(),
 			IconURL: playerResult.GetProfileIconURL(),
 		},
-		Description: fmt.Sprintf("Analysis based on **%d recent games**", profile.AnalyzedGames),
+		Description: fmt.Sprintf("Analysis based on **%d recent games**%s", profile.AnalyzedGames, formatAnalysisScope(profile.Options)),
 		Fields: []*discordgo.MessageEmbedField{
 			{
 				Name:   "📊 Performance",
@@ -118,9 +140,97 @@ func (b *DiscordBot) formatPlaystyleAnalysis(playerResult *PlayerLookupResult, p
 		Timestamp: time.Now().Format(time.RFC3339),
 	}
 
+	// Double Up is reported separately since placement is shared with a partner
+	if profile.DoubleUp.Games > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name: "👥 Double Up",
+			Value: fmt.Sprintf("**%d games** • Avg team #%.1f • Win %.0f%%\n%s",
+				profile.DoubleUp.Games,
+				profile.DoubleUp.AvgTeamPlacement,
+				profile.DoubleUp.WinRate*100,
+				b.formatDuoPartners(profile.DoubleUp.FavoritePartners, 3),
+			),
+			Inline: false,
+		})
+	}
+
+	// Augments are only present when the match data includes them
+	if len(profile.Augments.Augments) > 0 {
+		embed.Fields = append(embed.Fields, &discordgo.MessageEmbedField{
+			Name:   "🧩 Best & Worst Augments",
+			Value:  b.formatAugmentSummary(profile.Augments),
+			Inline: false,
+		})
+	}
+
 	return embed
 }
 
+// playstyleOptions are the /playstyle analysis window options
+type playstyleOptions struct {
+	Count int       `option:"count" min:"5" max:"50"`
+	Since time.Time `option:"since"`
+	Until time.Time `option:"until"`
+	Patch string    `option:"patch"`
+	Queue int       `option:"queue"`
+	Set   int       `option:"set"`
+}
+
+// parseAnalysisOptions builds the analysis window from /playstyle options
+func parseAnalysisOptions(options []*discordgo.ApplicationCommandInteractionDataOption) (riot.AnalysisOptions, error) {
+	var bound playstyleOptions
+	if err := BindOptions(options, &bound); err != nil {
+		return riot.AnalysisOptions{}, err
+	}
+
+	opts := riot.AnalysisOptions{
+		Count:     bound.Count,
+		StartTime: bound.Since,
+		EndTime:   bound.Until,
+		Patch:     bound.Patch,
+		QueueID:   bound.Queue,
+		SetNumber: bound.Set,
+	}
+	if !opts.StartTime.IsZero() && !opts.EndTime.IsZero() && !opts.StartTime.Before(opts.EndTime) {
+		return opts, &OptionError{Option: "since", Message: "must be before `until`"}
+	}
+	return opts, nil
+}
+
+// formatAnalysisScope describes any filters applied to an analysis, e.g. " • Patch 15.17 • Ranked"
+func formatAnalysisScope(opts riot.AnalysisOptions) string {
+	var parts []string
+	if opts.Patch != "" {
+		parts = append(parts, "Patch "+opts.Patch)
+	}
+	if opts.SetNumber != 0 {
+		parts = append(parts, fmt.Sprintf("Set %d", opts.SetNumber))
+	}
+	if opts.QueueID != 0 {
+		parts = append(parts, queueName(opts.QueueID))
+	}
+	if !opts.StartTime.IsZero() {
+		parts = append(parts, "since "+opts.StartTime.Format("Jan 2"))
+	}
+	if !opts.EndTime.IsZero() {
+		parts = append(parts, "until "+opts.EndTime.Format("Jan 2"))
+	}
+	if len(parts) == 0 {
+		return ""
+	}
+	return " • " + strings.Join(parts, " • ")
+}
+
+// queueName returns the display name of a TFT queue
+func queueName(queueID int) string {
+	for _, choice := range queueChoices {
+		if id, ok := choice.Value.(int); ok && id == queueID {
+			return choice.Name
+		}
+	}
+	return fmt.Sprintf("Queue %d", queueID)
+}
+
 // getConsistencyDescription converts consistency score to readable text
 func (b *DiscordBot) getConsistencyDescription(score float64) string {
 	switch {
@@ -187,19 +297,47 @@ func (b *DiscordBot) formatFavoriteUnits(units []riot.UnitFrequency, limit int)
 	return strings.Join(unitLines, "\n")
 }
 
+// formatAugmentSummary formats the best and worst augments
+func (b *DiscordBot) formatAugmentSummary(augments riot.AugmentProfile) string {
+	formatStats := func(stats []riot.AugmentStat) string {
+		var parts []string
+		for _, stat := range stats {
+			parts = append(parts, fmt.Sprintf("**%s** #%.1f (%+.1f, %dx)", b.cleanAugmentName(stat.ID), stat.AvgPlacement, stat.Delta, stat.Picks))
+		}
+		return strings.Join(parts, " • ")
+	}
+
+	var lines []string
+	if len(augments.Best) > 0 {
+		lines = append(lines, "✅ "+formatStats(augments.Best))
+	}
+	if len(augments.Worst) > 0 {
+		lines = append(lines, "❌ "+formatStats(augments.Worst))
+	}
+	if len(lines) == 0 {
+		lines = append(lines, "Not enough repeat picks to rank augments yet")
+	}
+
+	return strings.Join(lines, "\n")
+}
+
 // formatRecentForm formats recent game placements with emojis
 func (b *DiscordBot) formatRecentForm(recentForm []int) string {
-	if len(recentForm) == 0 {
+	return formatPlacements(recentForm, b.getPlacementEmoji)
+}
+
+// formatPlacements formats placements, oldest first, each with its emoji
+func formatPlacements(placements []int, emoji func(int) string) string {
+	if len(placements) == 0 {
 		return "No recent games"
 	}
 
 	var formEmojis []string
-	for _, placement := range recentForm {
-		emoji := b.getPlacementEmoji(placement)
-		formEmojis = append(formEmojis, fmt.Sprintf("%s%d", emoji, placement))
+	for _, placement := range placements {
+		formEmojis = append(formEmojis, fmt.Sprintf("%s%d", emoji(placement), placement))
 	}
 
-	// Show most recent games first, but limit to 8 to fit in embed
+	// Keep the 8 most recent games to fit in embed
 	if len(formEmojis) > 8 {
 		formEmojis = formEmojis[len(formEmojis)-8:]
 	}
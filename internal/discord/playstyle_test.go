@@ -4,6 +4,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/bwmarrin/discordgo"
 	"github.com/hunterjsb/tft/internal/riot"
 )
 
@@ -26,6 +27,7 @@ func TestFormatPlaystyleAnalysis(t *testing.T) {
 	profile := &riot.PlayerProfile{
 		PUUID:         "test-puuid",
 		AnalyzedGames: 20,
+		SoloGames:     20,
 		LastUpdated:   time.Now(),
 		PlayStyle: riot.PlayStyleProfile{
 			AveragePlacement: 3.5,
@@ -94,6 +96,13 @@ func TestFormatPlaystyleAnalysis(t *testing.T) {
 	} else if embed.Footer.Text == "" {
 		t.Error("Expected footer text to be set")
 	}
+
+	// Double Up games alone have no placements to show
+	profile.SoloGames = 0
+	embed = bot.formatPlaystyleAnalysis(playerResult, profile)
+	if embed.Fields[0].Value != "_No solo games_" || embed.Color != 0x808080 {
+		t.Errorf("Expected no placement stats without solo games, got %q / %x", embed.Fields[0].Value, embed.Color)
+	}
 }
 
 func TestGetConsistencyDescription(t *testing.T) {
@@ -201,6 +210,73 @@ func TestFormatRecentForm(t *testing.T) {
 	}
 }
 
+func TestParseAnalysisOptions(t *testing.T) {
+	options := []*discordgo.ApplicationCommandInteractionDataOption{
+		{Name: "gamename", Type: discordgo.ApplicationCommandOptionString, Value: "TestPlayer"},
+		{Name: "count", Type: discordgo.ApplicationCommandOptionInteger, Value: float64(30)},
+		{Name: "since", Type: discordgo.ApplicationCommandOptionString, Value: "2025-08-01"},
+		{Name: "patch", Type: discordgo.ApplicationCommandOptionString, Value: "15.17"},
+		{Name: "queue", Type: discordgo.ApplicationCommandOptionInteger, Value: float64(1100)},
+	}
+
+	opts, err := parseAnalysisOptions(options)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if opts.Count != 30 || opts.Patch != "15.17" || opts.QueueID != 1100 {
+		t.Errorf("Unexpected options: %+v", opts)
+	}
+	if opts.StartTime.Format("2006-01-02") != "2025-08-01" {
+		t.Errorf("Expected since 2025-08-01, got %s", opts.StartTime)
+	}
+
+	scope := formatAnalysisScope(opts)
+	if scope != " • Patch 15.17 • Ranked • since Aug 1" {
+		t.Errorf("Unexpected scope description '%s'", scope)
+	}
+	if formatAnalysisScope(riot.AnalysisOptions{Count: 20}) != "" {
+		t.Error("Expected no scope description without filters")
+	}
+
+	// Invalid dates and inverted ranges are rejected
+	if _, err := parseAnalysisOptions([]*discordgo.ApplicationCommandInteractionDataOption{
+		{Name: "since", Type: discordgo.ApplicationCommandOptionString, Value: "last week"},
+	}); err == nil {
+		t.Error("Expected error for invalid date")
+	}
+	if _, err := parseAnalysisOptions([]*discordgo.ApplicationCommandInteractionDataOption{
+		{Name: "since", Type: discordgo.ApplicationCommandOptionString, Value: "2025-08-10"},
+		{Name: "until", Type: discordgo.ApplicationCommandOptionString, Value: "2025-08-01"},
+	}); err == nil {
+		t.Error("Expected error when since is after until")
+	}
+}
+
+func TestFormatAugmentSummary(t *testing.T) {
+	bot := &DiscordBot{}
+
+	augments := riot.AugmentProfile{
+		Augments: []riot.AugmentStat{{ID: "TFT9_Augment_Good1"}, {ID: "TFT9_Augment_Bad2"}},
+		Best:     []riot.AugmentStat{{ID: "TFT9_Augment_Good1", AvgPlacement: 2.5, Delta: -2.0, Picks: 4}},
+		Worst:    []riot.AugmentStat{{ID: "TFT9_Augment_Bad2", AvgPlacement: 6.0, Delta: 1.5, Picks: 3}},
+	}
+
+	result := bot.formatAugmentSummary(augments)
+	expected := "✅ **Good1** #2.5 (-2.0, 4x)\n❌ **Bad2** #6.0 (+1.5, 3x)"
+	if result != expected {
+		t.Errorf("Expected '%s', got '%s'", expected, result)
+	}
+
+	// The augments field is only shown when there is augment data
+	profile := &riot.PlayerProfile{AnalyzedGames: 5, Augments: augments}
+	playerResult := &PlayerLookupResult{Account: &riot.Account{GameName: "TestPlayer", TagLine: "NA1"}}
+	embed := bot.formatPlaystyleAnalysis(playerResult, profile)
+	last := embed.Fields[len(embed.Fields)-1]
+	if last.Name != "🧩 Best & Worst Augments" {
+		t.Errorf("Expected augments field last, got '%s'", last.Name)
+	}
+}
+
 // Helper function for string containment check
 func containsString(s, substr string) bool {
 	return len(s) >= len(substr) && (s == substr || len(s) > len(substr) && (s[:len(substr)] == substr || s[len(s)-len(substr):] == substr || containsString(s[1:], substr)))
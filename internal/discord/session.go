@@ -0,0 +1,122 @@
+package discord
+
+import (
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hunterjsb/tft/internal/riot"
+)
+
+// handleSessionCommand handles the /session command
+func (b *DiscordBot) handleSessionCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	// Acknowledge the interaction immediately
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}); err != nil {
+		fmt.Printf("Error acknowledging interaction: %v\n", err)
+		return
+	}
+
+	// Parse and lookup player
+	params := ParsePlayerParams(i.ApplicationCommandData().Options)
+	playerResult, err := b.LookupPlayer(s, i, params)
+	if err != nil {
+		return // Error already sent to Discord
+	}
+
+	analyzer := b.newProfileAnalyzer()
+	session, err := analyzer.AnalyzeLatestSession(playerResult.Account.PUUID)
+	if err != nil {
+		b.sendError(s, i, "No Session Found", fmt.Sprintf("Could not find a recent session for `%s`: %v", playerResult.GetDisplayName(), err))
+		return
+	}
+
+	embed := b.formatSessionSummary(playerResult, session)
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{embed},
+	}); err != nil {
+		fmt.Printf("Error editing interaction response: %v\n", err)
+	}
+}
+
+// formatSessionSummary formats a play session into a Discord embed
+func (b *DiscordBot) formatSessionSummary(playerResult *PlayerLookupResult, session *riot.Session) *discordgo.MessageEmbed {
+	results := fmt.Sprintf("**Avg Placement:** #%.1f\n**Top 4 Rate:** %.0f%%\n**Trend:** %s",
+		session.AvgPlacement,
+		session.TopFourRate*100,
+		b.getSessionTrendDescription(session.PlacementTrend),
+	)
+
+	mood := fmt.Sprintf("**Tilt:** %s\n**Bot 4 Streak:** %d (longest %d)",
+		b.getTiltDescription(session.TiltIndicator),
+		session.CurrentBotFourStreak,
+		session.LongestBotFourStreak,
+	)
+
+	return &discordgo.MessageEmbed{
+		Title: fmt.Sprintf("🕹️ %s's Session", playerResult.Account.GameName),
+		Color: b.getColorByPerformance(session.AvgPlacement),
+		Author: &discordgo.MessageEmbedAuthor{
+			Name:    playerResult.GetDisplayName(),
+			IconURL: playerResult.GetProfileIconURL(),
+		},
+		Description: fmt.Sprintf("**%d games** over %s, started <t:%d:R>",
+			session.Games(),
+			formatSessionDuration(session.Duration()),
+			session.Start.Unix(),
+		),
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "📊 Results",
+				Value:  results,
+				Inline: true,
+			},
+			{
+				Name:   "🧠 Mental",
+				Value:  mood,
+				Inline: true,
+			},
+			{
+				Name:   "📈 Games",
+				Value:  b.formatRecentForm(session.Placements),
+				Inline: false,
+			},
+		},
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+}
+
+// getTiltDescription converts a 0-1 tilt indicator to a label
+func (b *DiscordBot) getTiltDescription(tilt float64) string {
+	switch {
+	case tilt >= 0.6:
+		return "🔥 Tilted"
+	case tilt >= 0.3:
+		return "😬 Warming up"
+	default:
+		return "😌 Calm"
+	}
+}
+
+// getSessionTrendDescription describes a placement slope, where positive means finishing lower
+func (b *DiscordBot) getSessionTrendDescription(slope float64) string {
+	switch {
+	case slope <= -0.3:
+		return "📈 Heating up"
+	case slope >= 0.3:
+		return "📉 Falling off"
+	default:
+		return "➡️ Steady"
+	}
+}
+
+// formatSessionDuration formats a duration as hours and minutes
+func formatSessionDuration(d time.Duration) string {
+	minutes := int(math.Round(d.Minutes()))
+	if minutes < 60 {
+		return fmt.Sprintf("%dm", minutes)
+	}
+	return fmt.Sprintf("%dh %dm", minutes/60, minutes%60)
+}
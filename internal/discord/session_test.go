@@ -0,0 +1,15 @@
+package discord
+
+import (
+	"testing"
+	"time"
+)
+
+func TestFormatSessionDuration(t *testing.T) {
+	if got := formatSessionDuration(45 * time.Minute); got != "45m" {
+		t.Errorf("Expected '45m', got '%s'", got)
+	}
+	if got := formatSessionDuration(2*time.Hour + 5*time.Minute); got != "2h 5m" {
+		t.Errorf("Expected '2h 5m', got '%s'", got)
+	}
+}
@@ -3,6 +3,7 @@ package discord
 import (
 	"context"
 	"fmt"
+	"strconv"
 	"strings"
 	"time"
 
@@ -10,6 +11,14 @@ import (
 	"github.com/hunterjsb/tft/internal/riot"
 )
 
+// lastGameMenuPlaceholder prompts the /lastgame participant menu
+const lastGameMenuPlaceholder = "Show another player's game"
+
+// recentOptions are the /tftrecent options besides the player
+type recentOptions struct {
+	Count int `option:"count" default:"5" min:"1" max:"10"`
+}
+
 // handleTFTRecentCommand handles the /tftrecent command
 func (b *DiscordBot) handleTFTRecentCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	// Acknowledge the interaction immediately
@@ -20,8 +29,12 @@ func (b *DiscordBot) handleTFTRecentCommand(s *discordgo.Session, i *discordgo.I
 		return
 	}
 
-	// Parse player parameters
+	// Parse player parameters and how many games to show
 	params := ParsePlayerParams(i.ApplicationCommandData().Options)
+	var opts recentOptions
+	if !b.bindOptions(s, i, &opts) {
+		return // Error already sent to Discord
+	}
 
 	// Look up player account and summoner info
 	playerResult, err := b.LookupPlayer(s, i, params)
@@ -29,20 +42,8 @@ func (b *DiscordBot) handleTFTRecentCommand(s *discordgo.Session, i *discordgo.I
 		return // Error already sent to Discord
 	}
 
-	// Parse count parameter
-	count := 5 // default
-	for _, option := range i.ApplicationCommandData().Options {
-		if option.Name == "count" {
-			count = int(option.IntValue())
-			if count < 1 || count > 10 {
-				count = 5
-			}
-			break
-		}
-	}
-
-	// Get recent TFT match IDs
-	matchIDs, err := riot.GetTFTMatchIDsByPUUID(playerResult.Account.PUUID, 0, count, nil, nil)
+	// Get the first page of recent TFT games, plus one to know whether there are older ones
+	matchIDs, err := riot.GetTFTMatchIDsByPUUID(playerResult.Account.PUUID, 0, opts.Count+1, nil, nil)
 	if err != nil {
 		b.sendError(s, i, "API Error", "Error fetching match history from Riot API")
 		return
@@ -54,26 +55,70 @@ func (b *DiscordBot) handleTFTRecentCommand(s *discordgo.Session, i *discordgo.I
 	}
 
 	// Format and send the response
-	embed := b.formatTFTMatches(playerResult, matchIDs)
+	embed, components := b.formatRecentPage(i.GuildID, playerResult, matchIDs, 0, opts.Count)
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds:     &[]*discordgo.MessageEmbed{embed},
+		Components: &components,
+	}); err != nil {
+		fmt.Printf("Error editing interaction response: %v\n", err)
+	}
+}
+
+// handleRecentPage handles the /tftrecent page buttons; args are the PUUID, first game and page size
+func (b *DiscordBot) handleRecentPage(s *discordgo.Session, i *discordgo.InteractionCreate, args []string) {
+	if len(args) != 3 {
+		return
+	}
+	puuid := args[0]
+	start, errStart := strconv.Atoi(args[1])
+	count, errCount := strconv.Atoi(args[2])
+	if errStart != nil || errCount != nil || start < 0 || count < 1 {
+		return
+	}
+	if !deferComponentUpdate(s, i) {
+		return
+	}
+
+	matchIDs, err := riot.GetTFTMatchIDsByPUUID(puuid, start, count+1, nil, nil)
+	if err != nil {
+		b.sendComponentError(s, i, "API Error", "Error fetching match history from Riot API")
+		return
+	}
+	if len(matchIDs) == 0 {
+		b.sendComponentError(s, i, "No Games Found", "No older TFT games found")
+		return
+	}
+
+	embed, components := b.formatRecentPage(i.GuildID, b.playerByPUUID(puuid), matchIDs, start, count)
 	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
-		Embeds: &[]*discordgo.MessageEmbed{embed},
+		Embeds:     &[]*discordgo.MessageEmbed{embed},
+		Components: &components,
 	}); err != nil {
 		fmt.Printf("Error editing interaction response: %v\n", err)
 	}
 }
 
-// formatTFTMatches formats TFT match data into a single embed
-func (b *DiscordBot) formatTFTMatches(playerResult *PlayerLookupResult, matchIDs []string) *discordgo.MessageEmbed {
+// formatRecentPage formats a page of recent games starting at start, with buttons to page
+// through the rest. matchIDs holds up to count games plus one more when there are older games.
+func (b *DiscordBot) formatRecentPage(guildID string, playerResult *PlayerLookupResult, matchIDs []string, start, count int) (*discordgo.MessageEmbed, []discordgo.MessageComponent) {
+	hasOlder := len(matchIDs) > count
+	if hasOlder {
+		matchIDs = matchIDs[:count]
+	}
+
+	embed := b.formatTFTMatches(guildID, playerResult, matchIDs)
+	embed.Footer = &discordgo.MessageEmbedFooter{Text: fmt.Sprintf("Games %d-%d", start+1, start+len(matchIDs))}
+	return embed, recentPageButtons(playerResult.Account.PUUID, start, count, hasOlder)
+}
+
+// formatTFTMatches formats TFT match data into a single embed, naming comps with AI when the guild allows it
+func (b *DiscordBot) formatTFTMatches(guildID string, playerResult *PlayerLookupResult, matchIDs []string) *discordgo.MessageEmbed {
 	var gamesSummary []string
 	var gameData []GameData
 	avgPlacement := 0.0
 	top4Count := 0
 
 	for i, matchID := range matchIDs {
-		if i >= 5 { // Limit to 5 games
-			break
-		}
-
 		// Get detailed match data
 		match, err := riot.GetTFTMatchByID(matchID)
 		if err != nil {
@@ -123,7 +168,7 @@ func (b *DiscordBot) formatTFTMatches(playerResult *PlayerLookupResult, matchIDs
 
 	// Generate AI comp names for all games at once
 	if validGames > 0 && len(gameData) > 0 {
-		gamesSummary = b.generateAllCompNames(gameData)
+		gamesSummary = b.generateAllCompNames(guildID, gameData)
 	}
 
 	embed := &discordgo.MessageEmbed{
@@ -166,8 +211,8 @@ func (b *DiscordBot) getColorByPerformance(avgPlacement float64) int {
 }
 
 // generateAllCompNames uses AI to create comp names for all games in one call
-func (b *DiscordBot) generateAllCompNames(games []GameData) []string {
-	if b.OpenAI == nil || len(games) == 0 {
+func (b *DiscordBot) generateAllCompNames(guildID string, games []GameData) []string {
+	if !b.aiEnabled(guildID) || len(games) == 0 {
 		// Fallback without AI
 		result := make([]string, len(games))
 		for i, game := range games {
@@ -219,7 +264,7 @@ func (b *DiscordBot) generateAllCompNames(games []GameData) []string {
 	ctx, cancel := context.WithTimeout(context.Background(), 8*time.Second)
 	defer cancel()
 
-	response, err := b.OpenAI.GenerateResponse(ctx, prompt.String())
+	response, err := b.OpenAI.GenerateResponse(ctx, b.localizePrompt(guildID, prompt.String()))
 	if err != nil {
 		// Fallback without AI
 		result := make([]string, len(games))
@@ -276,6 +321,15 @@ func (b *DiscordBot) cleanTraitName(traitName string) string {
 	return traitName
 }
 
+// cleanCompName cleans each trait in a comp signature, e.g. "TFT15_Duelist+TFT15_Sniper" -> "Duelist + Sniper"
+func (b *DiscordBot) cleanCompName(comp string) string {
+	traits := strings.Split(comp, "+")
+	for i, trait := range traits {
+		traits[i] = b.cleanTraitName(trait)
+	}
+	return strings.Join(traits, " + ")
+}
+
 // cleanChampionName removes API prefixes from champion names
 func (b *DiscordBot) cleanChampionName(championID string) string {
 	// Remove TFT15_ prefix
@@ -287,6 +341,14 @@ func (b *DiscordBot) cleanChampionName(championID string) string {
 	return championID
 }
 
+// cleanAugmentName removes API prefixes from augment IDs (e.g. "TFT9_Augment_CyberneticImplants2" -> "CyberneticImplants2")
+func (b *DiscordBot) cleanAugmentName(augmentID string) string {
+	if index := strings.Index(augmentID, "Augment_"); index != -1 {
+		return augmentID[index+len("Augment_"):]
+	}
+	return b.cleanChampionName(augmentID)
+}
+
 // handleLastGameCommand handles the /lastgame command
 func (b *DiscordBot) handleLastGameCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
 	// Acknowledge the interaction immediately
@@ -318,17 +380,54 @@ func (b *DiscordBot) handleLastGameCommand(s *discordgo.Session, i *discordgo.In
 		return
 	}
 
+	// Get the match so other participants can be picked from it
+	match, err := riot.GetTFTMatchByID(matchIDs[0])
+	if err != nil {
+		b.sendError(s, i, "API Error", "Could not load match data")
+		return
+	}
+
 	// Format and send the detailed response
-	embed := b.formatLastGame(playerResult, matchIDs[0])
+	embed := b.formatMatchForPlayer(i.GuildID, playerResult, match)
+	components := participantMenu(match, playerResult.Account.PUUID, "lastgame", lastGameMenuPlaceholder)
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds:     &[]*discordgo.MessageEmbed{embed},
+		Components: &components,
+	}); err != nil {
+		fmt.Printf("Error editing interaction response: %v\n", err)
+	}
+}
+
+// handleLastGameParticipant handles the /lastgame participant menu; args hold the match ID
+func (b *DiscordBot) handleLastGameParticipant(s *discordgo.Session, i *discordgo.InteractionCreate, args []string) {
+	puuid := selectedValue(i)
+	if len(args) != 1 || puuid == "" {
+		return
+	}
+	if !deferComponentUpdate(s, i) {
+		return
+	}
+
+	match, err := riot.GetTFTMatchByID(args[0])
+	if err != nil {
+		b.sendComponentError(s, i, "API Error", "Could not load match data")
+		return
+	}
+
+	playerResult := participantResult(match, puuid)
+
+	embed := b.formatMatchForPlayer(i.GuildID, playerResult, match)
+	components := participantMenu(match, puuid, "lastgame", lastGameMenuPlaceholder)
 	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
-		Embeds: &[]*discordgo.MessageEmbed{embed},
+		Embeds:     &[]*discordgo.MessageEmbed{embed},
+		Components: &components,
 	}); err != nil {
 		fmt.Printf("Error editing interaction response: %v\n", err)
 	}
 }
 
 // formatLastGame formats detailed info for a single TFT match
-func (b *DiscordBot) formatLastGame(playerResult *PlayerLookupResult, matchID string) *discordgo.MessageEmbed {
+func (b *DiscordBot) formatLastGame(guildID string, playerResult *PlayerLookupResult, matchID string) *discordgo.MessageEmbed {
 	// Get detailed match data
 	match, err := riot.GetTFTMatchByID(matchID)
 	if err != nil {
@@ -338,7 +437,12 @@ func (b *DiscordBot) formatLastGame(playerResult *PlayerLookupResult, matchID st
 			Color:       0xff0000,
 		}
 	}
+	return b.formatMatchForPlayer(guildID, playerResult, match)
+}
 
+// formatMatchForPlayer formats detailed info for one player's game in a match, with an AI
+// summary when the guild allows it
+func (b *DiscordBot) formatMatchForPlayer(guildID string, playerResult *PlayerLookupResult, match *riot.MatchDto) *discordgo.MessageEmbed {
 	// Find the player's data in the match
 	var player *riot.ParticipantDto
 	for _, participant := range match.Info.Participants {
@@ -367,7 +471,7 @@ func (b *DiscordBot) formatLastGame(playerResult *PlayerLookupResult, matchID st
 	embedColor := b.getColorByPerformance(float64(player.Placement))
 
 	// Generate AI analysis
-	analysis := b.generateGameAnalysis(player)
+	analysis := b.generateGameAnalysis(guildID, player)
 
 	// Find the main carry (highest damage dealer or best unit)
 	mainCarryIcon := b.getMainCarryIcon(player.Units)
@@ -418,7 +522,11 @@ func (b *DiscordBot) getPlacementEmoji(placement int) string {
 }
 
 // generateGameAnalysis creates a 2-sentence AI analysis of the game
-func (b *DiscordBot) generateGameAnalysis(player *riot.ParticipantDto) string {
+func (b *DiscordBot) generateGameAnalysis(guildID string, player *riot.ParticipantDto) string {
+	if !b.aiEnabled(guildID) {
+		return ""
+	}
+
 	// Build descriptive analysis prompt
 	var prompt strings.Builder
 	prompt.WriteString("Describe this TFT Set 15 game in 1-2 short sentences. Bold key units/items. Focus on build and lobby performance:\n\n")
@@ -471,14 +579,21 @@ func (b *DiscordBot) generateGameAnalysis(player *riot.ParticipantDto) string {
 		prompt.WriteString(fmt.Sprintf("Key Units: %s\n", strings.Join(unitStrs, ", ")))
 	}
 
-	// Note: Augments not available in current API response
+	// Add augments when the match data includes them
+	if len(player.Augments) > 0 {
+		var augments []string
+		for _, augment := range player.Augments {
+			augments = append(augments, b.cleanAugmentName(augment))
+		}
+		prompt.WriteString(fmt.Sprintf("Augments: %s\n", strings.Join(augments, ", ")))
+	}
 
 	prompt.WriteString("\nBe concise. Bold important units/items using **bold**.")
 
 	ctx, cancel := context.WithTimeout(context.Background(), 8*time.Second)
 	defer cancel()
 
-	analysis, err := b.OpenAI.GenerateResponse(ctx, prompt.String())
+	analysis, err := b.OpenAI.GenerateResponse(ctx, b.localizePrompt(guildID, prompt.String()))
 	if err != nil {
 		fmt.Printf("AI Analysis error: %v\n", err)
 		return "Analysis failed"
@@ -493,44 +608,48 @@ func (b *DiscordBot) generateGameAnalysis(player *riot.ParticipantDto) string {
 	return analysis
 }
 
-// getMainCarryIcon finds the main carry champion (highest damage or best unit)
+// getMainCarryIcon returns the icon of the player's main carry, if any
 func (b *DiscordBot) getMainCarryIcon(units []riot.UnitDto) string {
-	var mainCarry riot.UnitDto
+	if carry, ok := mainCarry(units); ok {
+		cleanName := b.cleanChampionName(carry.CharacterID)
+		return fmt.Sprintf("https://ddragon.leagueoflegends.com/cdn/%s/img/champion/%s.png", riot.DataDragonVersion, cleanName)
+	}
+	return ""
+}
+
+// mainCarry finds the main carry champion (highest damage or best unit)
+func mainCarry(units []riot.UnitDto) (riot.UnitDto, bool) {
+	var carry riot.UnitDto
 
 	// First priority: 3-star units with items (likely main carry)
 	for _, unit := range units {
 		if unit.Tier >= 3 && len(unit.Items) >= 2 {
-			if len(mainCarry.Items) < len(unit.Items) || mainCarry.Tier < unit.Tier {
-				mainCarry = unit
+			if len(carry.Items) < len(unit.Items) || carry.Tier < unit.Tier {
+				carry = unit
 			}
 		}
 	}
 
 	// Second priority: any 3-star unit
-	if mainCarry.CharacterID == "" {
+	if carry.CharacterID == "" {
 		for _, unit := range units {
 			if unit.Tier >= 3 {
-				mainCarry = unit
+				carry = unit
 				break
 			}
 		}
 	}
 
 	// Fallback: highest tier unit with most items
-	if mainCarry.CharacterID == "" {
+	if carry.CharacterID == "" {
 		for _, unit := range units {
-			if unit.Tier > mainCarry.Tier || (unit.Tier == mainCarry.Tier && len(unit.Items) > len(mainCarry.Items)) {
-				mainCarry = unit
+			if unit.Tier > carry.Tier || (unit.Tier == carry.Tier && len(unit.Items) > len(carry.Items)) {
+				carry = unit
 			}
 		}
 	}
 
-	if mainCarry.CharacterID != "" {
-		cleanName := b.cleanChampionName(mainCarry.CharacterID)
-		return fmt.Sprintf("https://ddragon.leagueoflegends.com/cdn/15.17.1/img/champion/%s.png", cleanName)
-	}
-
-	return ""
+	return carry, carry.CharacterID != ""
 }
 
 // formatKeyChampions formats champions in a simple, readable way
@@ -0,0 +1,428 @@
+package discord
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hunterjsb/tft/internal/riot"
+)
+
+// Tracking poller settings
+const (
+	defaultPollInterval   = 5 * time.Minute
+	trackRequestSpacing   = 1500 * time.Millisecond // between Riot requests, well under the rate limit
+	maxTrackedMatchesPost = 3                       // per player per poll, so a long absence doesn't flood the channel
+	trackedMatchWindow    = 5                       // match IDs fetched per player per poll
+	liveResultTimeout     = 2 * time.Hour           // how long to wait for a live game's match to appear
+	maxLiveLobbyAnalyses  = 3                       // uncached players profiled per scouted lobby; the rest show as unknown
+)
+
+// TrackedPlayer is a player whose new games are posted to subscribed channels
+type TrackedPlayer struct {
+	PUUID       string    `json:"puuid"`
+	GameName    string    `json:"gameName"`
+	TagLine     string    `json:"tagLine"`
+	Region      string    `json:"region,omitempty"`
+	ChannelIDs  []string  `json:"channelIds"`
+	LastMatchID string    `json:"lastMatchId,omitempty"` // newest match already seen
+	AddedAt     time.Time `json:"addedAt"`
+
+	// The game the player was last seen in, so its result can be posted as a reply
+	// to the lobby scouting message once the match appears
+	LiveGameID     int64             `json:"liveGameId,omitempty"`
+	LiveSince      time.Time         `json:"liveSince,omitempty"`
+	LiveMessageIDs map[string]string `json:"liveMessageIds,omitempty"` // channel ID -> scouting message ID
+}
+
+// RiotID returns the tracked player as "name#tag"
+func (p TrackedPlayer) RiotID() string {
+	return fmt.Sprintf("%s#%s", p.GameName, p.TagLine)
+}
+
+// hasChannel reports whether a channel is subscribed to the player
+func (p TrackedPlayer) hasChannel(channelID string) bool {
+	for _, id := range p.ChannelIDs {
+		if id == channelID {
+			return true
+		}
+	}
+	return false
+}
+
+// withoutChannel returns the player's channels minus channelID
+func (p TrackedPlayer) withoutChannel(channelID string) []string {
+	var channels []string
+	for _, id := range p.ChannelIDs {
+		if id != channelID {
+			channels = append(channels, id)
+		}
+	}
+	return channels
+}
+
+// lookupResult describes the tracked player the way command handlers see a looked-up player
+func (p TrackedPlayer) lookupResult() *PlayerLookupResult {
+	return &PlayerLookupResult{
+		Account: &riot.Account{PUUID: p.PUUID, GameName: p.GameName, TagLine: p.TagLine},
+		Params:  PlayerParams{GameName: p.GameName, TagLine: p.TagLine, Region: p.Region},
+	}
+}
+
+// clearLiveGame forgets the player's live game
+func (p *TrackedPlayer) clearLiveGame() {
+	p.LiveGameID = 0
+	p.LiveSince = time.Time{}
+	p.LiveMessageIDs = nil
+}
+
+// isGameMatch reports whether a match ID (e.g. "NA1_5359015242") is the match of a spectator game ID
+func isGameMatch(matchID string, gameID int64) bool {
+	return gameID != 0 && strings.HasSuffix(matchID, fmt.Sprintf("_%d", gameID))
+}
+
+// newTrackedMatches returns the matches to post for a player given their latest match IDs
+// (newest first), oldest first, and the ID to remember as the newest seen. Without a
+// previous match nothing is posted; the list only sets the baseline.
+func newTrackedMatches(player TrackedPlayer, matchIDs []string) (toPost []string, latest string) {
+	if len(matchIDs) == 0 {
+		return nil, player.LastMatchID
+	}
+	if player.LastMatchID == "" {
+		return nil, matchIDs[0]
+	}
+
+	var fresh []string
+	for _, id := range matchIDs {
+		if id == player.LastMatchID {
+			break
+		}
+		fresh = append(fresh, id)
+	}
+	if len(fresh) > maxTrackedMatchesPost {
+		fresh = fresh[:maxTrackedMatchesPost]
+	}
+	for idx := len(fresh) - 1; idx >= 0; idx-- {
+		toPost = append(toPost, fresh[idx])
+	}
+	return toPost, matchIDs[0]
+}
+
+// handleTrackCommand handles the /track command
+func (b *DiscordBot) handleTrackCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}); err != nil {
+		fmt.Printf("Error acknowledging interaction: %v\n", err)
+		return
+	}
+
+	if !b.guildSettings(i.GuildID).featureEnabled(featureTracking) {
+		b.sendError(s, i, "Feature Disabled", "Player tracking is turned off in this server")
+		return
+	}
+
+	params := ParsePlayerParams(i.ApplicationCommandData().Options)
+	playerResult, err := b.LookupPlayer(s, i, params)
+	if err != nil {
+		return // Error already sent to Discord
+	}
+	puuid := playerResult.Account.PUUID
+
+	if player, ok := b.Tracked.Get(puuid); ok && player.hasChannel(i.ChannelID) {
+		b.sendError(s, i, "Already Tracked", fmt.Sprintf("`%s` is already tracked in this channel", playerResult.GetDisplayName()))
+		return
+	}
+
+	// Remember the latest match now so only games finished after tracking are posted
+	latest := ""
+	if matchIDs, err := riot.GetTFTMatchIDsByPUUIDWithRegion(puuid, platformOrDefault(playerResult.Params.Region), 0, 1, nil, nil); err == nil && len(matchIDs) > 0 {
+		latest = matchIDs[0]
+	}
+
+	err = b.Tracked.Update(puuid, func(player TrackedPlayer, ok bool) TrackedPlayer {
+		if !ok {
+			player = TrackedPlayer{PUUID: puuid, LastMatchID: latest, AddedAt: time.Now()}
+		}
+		player.GameName = playerResult.Account.GameName
+		player.TagLine = playerResult.Account.TagLine
+		if playerResult.Params.Region != "" {
+			player.Region = strings.ToUpper(playerResult.Params.Region)
+		}
+		if player.LastMatchID == "" {
+			player.LastMatchID = latest
+		}
+		if !player.hasChannel(i.ChannelID) {
+			player.ChannelIDs = append(player.ChannelIDs, i.ChannelID)
+		}
+		return player
+	})
+	if err != nil {
+		fmt.Printf("Error saving tracked player: %v\n", err)
+		b.sendError(s, i, "Track Failed", "Could not save the subscription, please try again later")
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "📡 Player Tracked",
+		Description: fmt.Sprintf("Lobbies and results for games by **%s** will be posted in <#%s>.", playerResult.GetDisplayName(), i.ChannelID),
+		Color:       0x00ff00,
+		Timestamp:   time.Now().Format(time.RFC3339),
+	}
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{embed},
+	}); err != nil {
+		fmt.Printf("Error editing interaction response: %v\n", err)
+	}
+}
+
+// handleUntrackCommand handles the /untrack command
+func (b *DiscordBot) handleUntrackCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}); err != nil {
+		fmt.Printf("Error acknowledging interaction: %v\n", err)
+		return
+	}
+
+	params := ParsePlayerParams(i.ApplicationCommandData().Options)
+	playerResult, err := b.LookupPlayer(s, i, params)
+	if err != nil {
+		return // Error already sent to Discord
+	}
+	puuid := playerResult.Account.PUUID
+
+	player, ok := b.Tracked.Get(puuid)
+	if !ok || !player.hasChannel(i.ChannelID) {
+		b.sendError(s, i, "Not Tracked", fmt.Sprintf("`%s` isn't tracked in this channel", playerResult.GetDisplayName()))
+		return
+	}
+
+	// Drop the player entirely once no channel is subscribed
+	if channels := player.withoutChannel(i.ChannelID); len(channels) > 0 {
+		player.ChannelIDs = channels
+		err = b.Tracked.Set(puuid, player)
+	} else {
+		_, err = b.Tracked.Delete(puuid)
+	}
+	if err != nil {
+		fmt.Printf("Error removing tracked player: %v\n", err)
+		b.sendError(s, i, "Untrack Failed", "Could not remove the subscription, please try again later")
+		return
+	}
+
+	embed := &discordgo.MessageEmbed{
+		Title:       "📴 Player Untracked",
+		Description: fmt.Sprintf("Stopped posting games by **%s** in <#%s>.", playerResult.GetDisplayName(), i.ChannelID),
+		Color:       0x00ff00,
+		Timestamp:   time.Now().Format(time.RFC3339),
+	}
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{embed},
+	}); err != nil {
+		fmt.Printf("Error editing interaction response: %v\n", err)
+	}
+}
+
+// startTrackingPoller checks tracked players for live and finished games every interval until the
+// returned stop function is called. Stop waits for an in-progress poll to finish.
+func (b *DiscordBot) startTrackingPoller(interval time.Duration) func() {
+	if interval <= 0 {
+		interval = defaultPollInterval
+	}
+
+	return runEvery(interval, b.pollTrackedPlayers)
+}
+
+// pollTrackedPlayers checks every tracked player for new and finished games, spacing
+// requests to respect the Riot rate limit. A rate limited response ends the poll early.
+func (b *DiscordBot) pollTrackedPlayers(stop <-chan struct{}) {
+	for _, puuid := range b.Tracked.Keys() {
+		if !waitOrStop(trackRequestSpacing, stop) {
+			return
+		}
+
+		player, ok := b.Tracked.Get(puuid)
+		if !ok || len(player.ChannelIDs) == 0 {
+			continue
+		}
+		if !b.pollTrackedPlayer(player, stop) {
+			return
+		}
+	}
+}
+
+// pollTrackedPlayer posts a lobby scouting report when the player enters a game, and
+// reports for matches finished since the last poll. Returns false when polling should stop.
+func (b *DiscordBot) pollTrackedPlayer(player TrackedPlayer, stop <-chan struct{}) bool {
+	before := player
+	region := platformOrDefault(player.Region)
+
+	game, err := riot.GetActiveTFTGameByPUUIDWithRegion(player.PUUID, region)
+	switch {
+	case err == nil:
+		// No match can finish while the player is in a game, so skip the match list
+		if game.GameID != player.LiveGameID {
+			// Scouting the lobby profiles every player in it, so give the rate limit room first
+			if !waitOrStop(trackRequestSpacing, stop) {
+				return false
+			}
+			if !b.postLiveGame(&player, game) {
+				fmt.Printf("Rate limited scouting the lobby for %s, ending poll\n", player.RiotID())
+				return false
+			}
+			b.saveTrackedPlayer(player)
+		}
+		return true
+	case strings.Contains(err.Error(), "status 429"):
+		fmt.Printf("Rate limited checking live game for %s, ending poll\n", player.RiotID())
+		return false
+	case !strings.Contains(err.Error(), "status 404"):
+		fmt.Printf("Error checking live game for %s: %v\n", player.RiotID(), err)
+		return true
+	}
+
+	if !waitOrStop(trackRequestSpacing, stop) {
+		return false
+	}
+	matchIDs, err := riot.GetTFTMatchIDsByPUUIDWithRegion(player.PUUID, region, 0, trackedMatchWindow, nil, nil)
+	if err != nil {
+		fmt.Printf("Error polling matches for %s: %v\n", player.RiotID(), err)
+		return !strings.Contains(err.Error(), "status 429")
+	}
+
+	toPost, latest := newTrackedMatches(player, matchIDs)
+	for _, matchID := range toPost {
+		if isGameMatch(matchID, player.LiveGameID) {
+			b.postTrackedMatch(player, matchID, "🏁 Game over for **%s**", player.LiveMessageIDs)
+			player.clearLiveGame()
+		} else {
+			b.postTrackedMatch(player, matchID, "📣 New game for **%s**", nil)
+		}
+	}
+	player.LastMatchID = latest
+
+	// Give up on a live game whose match never showed up
+	if player.LiveGameID != 0 && time.Since(player.LiveSince) > liveResultTimeout {
+		player.clearLiveGame()
+	}
+
+	if player.LastMatchID != before.LastMatchID || player.LiveGameID != before.LiveGameID {
+		b.saveTrackedPlayer(player)
+	}
+	return true
+}
+
+// postLiveGame posts a lobby scouting report for the game the player just entered
+// and remembers the game so its result can be posted when it ends. Players without a
+// cached profile are analyzed a few at a time, spaced to respect the Riot rate limit.
+// Returns false when rate limited, leaving the game to be scouted on a later poll.
+func (b *DiscordBot) postLiveGame(player *TrackedPlayer, game *riot.CurrentGameInfo) bool {
+	analyzer := b.newProfileAnalyzer()
+	analyzer.MaxLobbyAnalyses = maxLiveLobbyAnalyses
+	analyzer.LobbySpacing = trackRequestSpacing
+
+	lobby, err := analyzer.AnalyzeLobbyAggregated(game)
+	if err != nil && strings.Contains(err.Error(), "status 429") {
+		return false
+	}
+
+	player.LiveGameID = game.GameID
+	player.LiveSince = time.Now()
+	player.LiveMessageIDs = make(map[string]string)
+	if err != nil {
+		fmt.Printf("Error analyzing live lobby for %s: %v\n", player.RiotID(), err)
+		return true
+	}
+	embed := b.formatLobbyAnalysisEmbed(player.lookupResult(), game, lobby)
+
+	for _, channelID := range player.ChannelIDs {
+		if _, enabled := b.trackingGuild(channelID); !enabled {
+			continue
+		}
+		message, err := b.Session.ChannelMessageSendComplex(channelID, &discordgo.MessageSend{
+			Content: fmt.Sprintf("🎮 **%s** just started a game", player.RiotID()),
+			Embeds:  []*discordgo.MessageEmbed{embed},
+		})
+		if err != nil {
+			fmt.Printf("Error posting live game to channel %s: %v\n", channelID, err)
+			continue
+		}
+		player.LiveMessageIDs[channelID] = message.ID
+	}
+	return true
+}
+
+// postTrackedMatch posts a match report to every channel subscribed to the player.
+// The content format receives the player's Riot ID; replyTo maps channels to the
+// message the report should reply to.
+func (b *DiscordBot) postTrackedMatch(player TrackedPlayer, matchID, content string, replyTo map[string]string) {
+	// Servers can turn off the AI summary, so reports are formatted once per server
+	embeds := make(map[string]*discordgo.MessageEmbed)
+
+	for _, channelID := range player.ChannelIDs {
+		guildID, enabled := b.trackingGuild(channelID)
+		if !enabled {
+			continue
+		}
+		embed, ok := embeds[guildID]
+		if !ok {
+			embed = b.formatLastGame(guildID, player.lookupResult(), matchID)
+			embeds[guildID] = embed
+		}
+
+		message := &discordgo.MessageSend{
+			Content: fmt.Sprintf(content, player.RiotID()),
+			Embeds:  []*discordgo.MessageEmbed{embed},
+		}
+		if messageID, ok := replyTo[channelID]; ok {
+			message.Reference = &discordgo.MessageReference{MessageID: messageID, ChannelID: channelID}
+		}
+		if _, err := b.Session.ChannelMessageSendComplex(channelID, message); err != nil {
+			fmt.Printf("Error posting tracked match to channel %s: %v\n", channelID, err)
+		}
+	}
+}
+
+// trackingGuild returns the server of a channel subscribed to a tracked player,
+// and whether the server allows tracking
+func (b *DiscordBot) trackingGuild(channelID string) (guildID string, enabled bool) {
+	guildID = b.channelGuildID(channelID)
+	return guildID, b.guildSettings(guildID).featureEnabled(featureTracking)
+}
+
+// saveTrackedPlayer stores the poller's view of a player's games, keeping any
+// channel changes made by /track and /untrack since the poll started
+func (b *DiscordBot) saveTrackedPlayer(player TrackedPlayer) {
+	if _, ok := b.Tracked.Get(player.PUUID); !ok {
+		return // untracked while polling
+	}
+	if err := b.Tracked.Update(player.PUUID, func(current TrackedPlayer, _ bool) TrackedPlayer {
+		current.LastMatchID = player.LastMatchID
+		current.LiveGameID = player.LiveGameID
+		current.LiveSince = player.LiveSince
+		current.LiveMessageIDs = player.LiveMessageIDs
+		return current
+	}); err != nil {
+		fmt.Printf("Error saving tracked player: %v\n", err)
+	}
+}
+
+// waitOrStop waits for d, returning false if stop closes first
+func waitOrStop(d time.Duration, stop <-chan struct{}) bool {
+	select {
+	case <-time.After(d):
+		return true
+	case <-stop:
+		return false
+	}
+}
+
+// platformOrDefault returns the platform for a player's region option or link, defaulting to NA1
+func platformOrDefault(region string) string {
+	if region == "" {
+		return "NA1"
+	}
+	return strings.ToUpper(region)
+}
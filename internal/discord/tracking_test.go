@@ -0,0 +1,259 @@
+package discord
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hunterjsb/tft/internal/riot"
+	"github.com/hunterjsb/tft/internal/riot/riottest"
+	"github.com/hunterjsb/tft/internal/store"
+)
+
+func TestNewTrackedMatches(t *testing.T) {
+	ids := []string{"NA1_6", "NA1_5", "NA1_4", "NA1_3", "NA1_2"}
+	tests := []struct {
+		name   string
+		last   string
+		ids    []string
+		post   []string
+		latest string
+	}{
+		{"baseline only", "", ids, nil, "NA1_6"},
+		{"no new games", "NA1_6", ids, nil, "NA1_6"},
+		{"new games oldest first", "NA1_4", ids, []string{"NA1_5", "NA1_6"}, "NA1_6"},
+		{"capped after long absence", "NA1_1", ids, []string{"NA1_4", "NA1_5", "NA1_6"}, "NA1_6"},
+		{"empty match list", "NA1_4", nil, nil, "NA1_4"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			post, latest := newTrackedMatches(TrackedPlayer{LastMatchID: tt.last}, tt.ids)
+			if fmt.Sprint(post) != fmt.Sprint(tt.post) || latest != tt.latest {
+				t.Errorf("Expected %v and %s, got %v and %s", tt.post, tt.latest, post, latest)
+			}
+		})
+	}
+}
+
+func TestTrackedPlayerChannels(t *testing.T) {
+	player := TrackedPlayer{ChannelIDs: []string{"a", "b"}}
+	if !player.hasChannel("a") || player.hasChannel("c") {
+		t.Errorf("Unexpected channel membership for %v", player.ChannelIDs)
+	}
+	if channels := player.withoutChannel("a"); fmt.Sprint(channels) != "[b]" {
+		t.Errorf("Expected [b], got %v", channels)
+	}
+	if channels := (TrackedPlayer{ChannelIDs: []string{"a"}}).withoutChannel("a"); len(channels) != 0 {
+		t.Errorf("Expected no channels, got %v", channels)
+	}
+}
+
+func TestTrackingPollerStops(t *testing.T) {
+	tracked, err := store.Open[TrackedPlayer]("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	bot := &DiscordBot{Tracked: tracked}
+
+	stop := bot.startTrackingPoller(time.Millisecond)
+	stopped := make(chan struct{})
+	go func() {
+		stop()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("Poller did not stop")
+	}
+}
+
+func TestIsGameMatch(t *testing.T) {
+	if !isGameMatch("NA1_5359015242", 5359015242) {
+		t.Error("Expected match ID to match its game ID")
+	}
+	if isGameMatch("NA1_15359015242", 5359015242) || isGameMatch("NA1_5359015242", 0) {
+		t.Error("Expected other games and an unset game ID not to match")
+	}
+}
+
+func TestClearLiveGame(t *testing.T) {
+	player := TrackedPlayer{LastMatchID: "NA1_1", LiveGameID: 2, LiveSince: time.Now(), LiveMessageIDs: map[string]string{"c": "m"}}
+	player.clearLiveGame()
+	if player.LiveGameID != 0 || !player.LiveSince.IsZero() || player.LiveMessageIDs != nil || player.LastMatchID != "NA1_1" {
+		t.Errorf("Expected only the live game cleared, got %+v", player)
+	}
+}
+
+// fakeDiscord records the messages a session sends and answers each with a new message ID
+type fakeDiscord struct {
+	mu       sync.Mutex
+	channels []string
+	messages []*discordgo.MessageSend
+}
+
+func (f *fakeDiscord) RoundTrip(req *http.Request) (*http.Response, error) {
+	channelID, ok := strings.CutSuffix(req.URL.Path, "/messages")
+	if req.Method != http.MethodPost || !ok {
+		return nil, fmt.Errorf("unexpected Discord request %s %s", req.Method, req.URL.Path)
+	}
+	channelID = channelID[strings.LastIndex(channelID, "/")+1:]
+
+	var message discordgo.MessageSend
+	if err := json.NewDecoder(req.Body).Decode(&message); err != nil {
+		return nil, err
+	}
+	f.mu.Lock()
+	f.channels = append(f.channels, channelID)
+	f.messages = append(f.messages, &message)
+	body := fmt.Sprintf(`{"id":"m%d","channel_id":%q}`, len(f.messages), channelID)
+	f.mu.Unlock()
+
+	return &http.Response{
+		StatusCode: http.StatusOK,
+		Header:     http.Header{"Content-Type": []string{"application/json"}},
+		Body:       io.NopCloser(strings.NewReader(body)),
+		Request:    req,
+	}, nil
+}
+
+// sent returns the messages sent so far
+func (f *fakeDiscord) sent() []*discordgo.MessageSend {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]*discordgo.MessageSend(nil), f.messages...)
+}
+
+// newFakeDiscordSession returns a session whose requests go to a fakeDiscord,
+// knowing channel c1 belongs to guild g1
+func newFakeDiscordSession(t *testing.T) (*discordgo.Session, *fakeDiscord) {
+	t.Helper()
+	session, err := discordgo.New("Bot test")
+	if err != nil {
+		t.Fatal(err)
+	}
+	fake := &fakeDiscord{}
+	session.Client = &http.Client{Transport: fake}
+	if err := session.State.GuildAdd(&discordgo.Guild{ID: "g1", Channels: []*discordgo.Channel{{ID: "c1", GuildID: "g1"}}}); err != nil {
+		t.Fatal(err)
+	}
+	return session, fake
+}
+
+// readSample reads a file from the riot samples
+func readSample(t *testing.T, name string) []byte {
+	t.Helper()
+	data, err := os.ReadFile(filepath.Join("..", "riot", "samples", name))
+	if err != nil {
+		t.Fatal(err)
+	}
+	return data
+}
+
+func TestPollTrackedPlayer_LiveGame(t *testing.T) {
+	const (
+		puuid     = "S7385disFDbPwDqOGvxT2q_V0-TtIrhTolM55G97WtT4APix8dQtU_fnOYI_OkSNn7HxDDu-dS1AFA"
+		liveMatch = "NA1_5359015242"
+	)
+
+	// Serve every sample match but the one the live game becomes
+	server := riottest.NewServer()
+	matches, err := filepath.Glob(filepath.Join("..", "riot", "samples", "matches", "*.json"))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, path := range matches {
+		if filepath.Base(path) == liveMatch+".json" {
+			continue
+		}
+		data, err := os.ReadFile(path)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err := server.AddMatch(data); err != nil {
+			t.Fatal(err)
+		}
+	}
+	t.Setenv("RIOT_API_KEY", "riottest-key")
+	restore := riot.SetBaseURL(server.URL)
+	t.Cleanup(func() {
+		restore()
+		server.Close()
+	})
+
+	tracked, err := store.Open[TrackedPlayer]("")
+	if err != nil {
+		t.Fatal(err)
+	}
+	session, fake := newFakeDiscordSession(t)
+	bot := &DiscordBot{Session: session, Tracked: tracked}
+	player := TrackedPlayer{PUUID: puuid, GameName: "Koalafied", TagLine: "NA1", ChannelIDs: []string{"c1"}, LastMatchID: "NA1_5359015205"}
+	if err := tracked.Set(puuid, player); err != nil {
+		t.Fatal(err)
+	}
+	poll := func() TrackedPlayer {
+		t.Helper()
+		current, _ := tracked.Get(puuid)
+		if !bot.pollTrackedPlayer(current, make(chan struct{})) {
+			t.Fatal("Expected polling to continue")
+		}
+		current, _ = tracked.Get(puuid)
+		return current
+	}
+
+	// Not in a game and no new matches: nothing to post
+	poll()
+	if sent := fake.sent(); len(sent) != 0 {
+		t.Fatalf("Expected no messages, got %d", len(sent))
+	}
+
+	// The player starts the game that becomes the live match
+	game := strings.Replace(string(readSample(t, "active_game_sample.json")), "5359015295", strings.TrimPrefix(liveMatch, "NA1_"), 1)
+	if err := server.AddActiveGame([]byte(game)); err != nil {
+		t.Fatal(err)
+	}
+
+	// Rate limited while scouting: nothing is posted and the game is scouted on the next poll
+	server.Inject(riottest.Fault{Path: "/ids", Status: http.StatusTooManyRequests, Times: 1})
+	current, _ := tracked.Get(puuid)
+	if bot.pollTrackedPlayer(current, make(chan struct{})) {
+		t.Fatal("Expected a rate limited scouting to end the poll")
+	}
+	if current, _ = tracked.Get(puuid); current.LiveGameID != 0 || len(fake.sent()) != 0 {
+		t.Fatalf("Expected nothing posted or remembered, got %+v and %d messages", current, len(fake.sent()))
+	}
+
+	current = poll()
+	sent := fake.sent()
+	if len(sent) != 1 || !strings.Contains(sent[0].Content, "just started a game") || len(sent[0].Embeds) != 1 {
+		t.Fatalf("Expected one lobby scouting message, got %+v", sent)
+	}
+	if current.LiveGameID != 5359015242 || current.LiveMessageIDs["c1"] != "m1" {
+		t.Fatalf("Expected the live game and its message to be remembered, got %+v", current)
+	}
+
+	// The game ends and its match appears
+	server.Inject(riottest.Fault{Path: "active-games", Status: http.StatusNotFound})
+	if err := server.AddMatch(readSample(t, filepath.Join("matches", liveMatch+".json"))); err != nil {
+		t.Fatal(err)
+	}
+	current = poll()
+	sent = fake.sent()
+	if len(sent) != 2 || !strings.Contains(sent[1].Content, "Game over") {
+		t.Fatalf("Expected a game over message, got %+v", sent)
+	}
+	if sent[1].Reference == nil || sent[1].Reference.MessageID != "m1" || sent[1].Reference.ChannelID != "c1" {
+		t.Errorf("Expected the result to reply to the scouting message, got %+v", sent[1].Reference)
+	}
+	if current.LastMatchID != liveMatch || current.LiveGameID != 0 {
+		t.Errorf("Expected the match seen and the live game cleared, got %+v", current)
+	}
+}
@@ -1,8 +1,12 @@
 package discord
 
 import (
+	"sync"
+	"time"
+
 	"github.com/bwmarrin/discordgo"
 	"github.com/hunterjsb/tft/internal/riot"
+	"github.com/hunterjsb/tft/internal/store"
 	"github.com/sashabaranov/go-openai"
 )
 
@@ -15,6 +19,28 @@ type DiscordBot struct {
 	GuildID         string
 	Commands        []*discordgo.ApplicationCommand
 	CommandHandlers map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate)
+	// Button and select menu handlers, keyed by the name their custom IDs start with
+	ComponentHandlers map[string]func(s *discordgo.Session, i *discordgo.InteractionCreate, args []string)
+	Cache             *riot.Cache                       // shared across commands so profiles refresh incrementally
+	Archive           *riot.MatchArchive                // optional on-disk match archive
+	Static            *riot.StaticData                  // champion, trait and item names; nil falls back to IDs
+	Ranks             *riot.LeagueRanks                 // remembered ranked entries, for lobby predictions
+	MetaRanks         *riot.LeagueRanks                 // ranked entries for the rank-banded meta, apart from Ranks so meta builds don't hold up lobbies
+	Links             *store.Store[LinkedAccount]       // Discord user ID -> linked Riot account
+	History           *PlayerHistory                    // Riot IDs suggested by autocomplete
+	Tracked           *store.Store[TrackedPlayer]       // PUUID -> player whose new games are posted
+	Leaderboards      *store.Store[LeaderboardSnapshot] // "guild/week" -> weekly leaderboard history
+	Guilds            *store.Store[GuildSettings]       // guild ID -> settings managed with /config
+	stopJanitor       func()
+	stopPoller        func()
+	stopLeaderboard   func()
+	stopMeta          func()
+
+	metaMu        sync.RWMutex
+	meta          *riot.MetaSnapshot // meta snapshot built from the archive, see currentMeta
+	metaRefreshMu sync.Mutex         // serializes refreshMeta
+	metaTally     *riot.MetaTally    // counts behind the meta, grown with newly archived matches
+	metaSeen      map[string]bool    // archived match IDs already added to metaTally
 }
 
 // Config holds Discord bot configuration
@@ -22,7 +48,11 @@ type Config struct {
 	DiscordToken string
 	OpenAIToken  string
 	GuildID      string
-	ChannelID    string
+	ChannelID    string // where the weekly leaderboard of GuildID is posted, unless /config reports overrides it
+	ArchiveDir   string
+	MetaRankBand string        // only players in this rank band (see riot.RankBandOf) feed the meta; empty for all
+	DataDir      string        // persistent bot state such as account links
+	PollInterval time.Duration // how often tracked players are checked for new games
 	MaxTokens    int
 	Temperature  float64
 }
@@ -0,0 +1,110 @@
+package discord
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/bwmarrin/discordgo"
+	"github.com/hunterjsb/tft/internal/riot"
+)
+
+// handleVersusCommand handles the /versus command
+func (b *DiscordBot) handleVersusCommand(s *discordgo.Session, i *discordgo.InteractionCreate) {
+	// Acknowledge the interaction immediately
+	if err := s.InteractionRespond(i.Interaction, &discordgo.InteractionResponse{
+		Type: discordgo.InteractionResponseDeferredChannelMessageWithSource,
+	}); err != nil {
+		fmt.Printf("Error acknowledging interaction: %v\n", err)
+		return
+	}
+
+	// Parse both players' parameters
+	options := i.ApplicationCommandData().Options
+	params := ParsePlayerParams(options)
+	opponentParams := ParsePrefixedPlayerParams(options, "opponent")
+
+	// Look up both accounts
+	playerResult, err := b.LookupPlayer(s, i, params)
+	if err != nil {
+		return // Error already sent to Discord
+	}
+	opponentResult, err := b.LookupPlayer(s, i, opponentParams)
+	if err != nil {
+		return // Error already sent to Discord
+	}
+
+	analyzer := b.newProfileAnalyzer()
+	h2h, err := analyzer.AnalyzeHeadToHead(playerResult.Account.PUUID, opponentResult.Account.PUUID)
+	if err != nil {
+		b.sendError(s, i, "Analysis Error", fmt.Sprintf("Could not compare `%s` and `%s`: %v", playerResult.GetDisplayName(), opponentResult.GetDisplayName(), err))
+		return
+	}
+
+	embed := b.formatHeadToHead(playerResult, opponentResult, h2h)
+	if _, err := s.InteractionResponseEdit(i.Interaction, &discordgo.WebhookEdit{
+		Embeds: &[]*discordgo.MessageEmbed{embed},
+	}); err != nil {
+		fmt.Printf("Error editing interaction response: %v\n", err)
+	}
+}
+
+// formatHeadToHead formats a head-to-head record into a Discord embed
+func (b *DiscordBot) formatHeadToHead(playerResult, opponentResult *PlayerLookupResult, h2h *riot.HeadToHead) *discordgo.MessageEmbed {
+	nameA := playerResult.Account.GameName
+	nameB := opponentResult.Account.GameName
+
+	record := fmt.Sprintf("**%s:** %d\n**%s:** %d", nameA, h2h.AWins, nameB, h2h.BWins)
+	if ties := h2h.Games - h2h.AWins - h2h.BWins; ties > 0 {
+		record += fmt.Sprintf("\n**Tied:** %d", ties)
+	}
+
+	placements := fmt.Sprintf("**%s:** #%.1f\n**%s:** #%.1f", nameA, h2h.AvgPlacementA, nameB, h2h.AvgPlacementB)
+
+	// Color by who's ahead
+	color := 0xffff00
+	if h2h.AWins > h2h.BWins {
+		color = 0x00ff00
+	} else if h2h.BWins > h2h.AWins {
+		color = 0xff0000
+	}
+
+	return &discordgo.MessageEmbed{
+		Title:       fmt.Sprintf("⚔️ %s vs %s", nameA, nameB),
+		Color:       color,
+		Description: fmt.Sprintf("%s based on **%d shared games**", b.formatPlacementEdge(nameA, nameB, h2h.AvgPlacementDiff), h2h.Games),
+		Author: &discordgo.MessageEmbedAuthor{
+			Name:    playerResult.GetDisplayName(),
+			IconURL: playerResult.GetProfileIconURL(),
+		},
+		Fields: []*discordgo.MessageEmbedField{
+			{
+				Name:   "🏆 Finished Higher",
+				Value:  record,
+				Inline: true,
+			},
+			{
+				Name:   "📊 Avg Placement",
+				Value:  placements,
+				Inline: true,
+			},
+			{
+				Name:   "🔀 Trait Overlap",
+				Value:  fmt.Sprintf("**%.0f%%** of active traits shared\n%s", h2h.TraitOverlap*100, b.formatFavoriteTraits(h2h.SharedTraits, 3)),
+				Inline: true,
+			},
+		},
+		Timestamp: time.Now().Format(time.RFC3339),
+	}
+}
+
+// formatPlacementEdge describes who places better on average when both are in the lobby
+func (b *DiscordBot) formatPlacementEdge(nameA, nameB string, diff float64) string {
+	switch {
+	case diff < 0:
+		return fmt.Sprintf("**%s** places %.1f better on average", nameA, -diff)
+	case diff > 0:
+		return fmt.Sprintf("**%s** places %.1f better on average", nameB, diff)
+	default:
+		return "Dead even on average placement"
+	}
+}
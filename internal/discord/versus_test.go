@@ -0,0 +1,17 @@
+package discord
+
+import "testing"
+
+func TestFormatPlacementEdge(t *testing.T) {
+	bot := &DiscordBot{}
+
+	if edge := bot.formatPlacementEdge("A", "B", -1.5); edge != "**A** places 1.5 better on average" {
+		t.Errorf("Unexpected edge '%s'", edge)
+	}
+	if edge := bot.formatPlacementEdge("A", "B", 0.5); edge != "**B** places 0.5 better on average" {
+		t.Errorf("Unexpected edge '%s'", edge)
+	}
+	if edge := bot.formatPlacementEdge("A", "B", 0); edge != "Dead even on average placement" {
+		t.Errorf("Unexpected edge '%s'", edge)
+	}
+}
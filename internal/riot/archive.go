@@ -0,0 +1,210 @@
+package riot
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"sync"
+)
+
+// MatchArchive persists raw match data on disk so it can be analyzed offline.
+// Each match is stored as <dir>/<matchID>.json. Matches never change once played,
+// so archived entries do not expire.
+type MatchArchive struct {
+	mu  sync.RWMutex
+	dir string
+
+	// Index of archived match IDs by player, built on first use and kept current by Put
+	indexMu sync.Mutex
+	players map[string][]string // PUUID -> match IDs
+	indexed map[string]bool     // match IDs in players
+}
+
+// NewMatchArchive opens (and creates if needed) a match archive rooted at dir.
+func NewMatchArchive(dir string) (*MatchArchive, error) {
+	if dir == "" {
+		return nil, fmt.Errorf("archive directory is required")
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("error creating archive directory: %w", err)
+	}
+	return &MatchArchive{dir: dir}, nil
+}
+
+// Dir returns the directory backing the archive.
+func (a *MatchArchive) Dir() string {
+	return a.dir
+}
+
+// path returns the file path for a match ID.
+func (a *MatchArchive) path(matchID string) string {
+	return filepath.Join(a.dir, matchID+".json")
+}
+
+// Put stores a match in the archive, replacing any previous copy.
+func (a *MatchArchive) Put(match *MatchDto) error {
+	if a == nil || match == nil {
+		return nil
+	}
+	matchID := match.Metadata.MatchID
+	if matchID == "" || strings.ContainsAny(matchID, `/\`) {
+		return fmt.Errorf("invalid match ID %q", matchID)
+	}
+
+	data, err := json.Marshal(match)
+	if err != nil {
+		return err
+	}
+
+	if err := a.write(matchID, data); err != nil {
+		return err
+	}
+
+	a.indexMu.Lock()
+	if a.players != nil {
+		a.index(match)
+	}
+	a.indexMu.Unlock()
+	return nil
+}
+
+// write stores a match's encoded data under its ID
+func (a *MatchArchive) write(matchID string, data []byte) error {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+
+	// Write to a temp file first so readers never see a partial match
+	tmp, err := os.CreateTemp(a.dir, matchID+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), a.path(matchID))
+}
+
+// Get loads a single match from the archive.
+func (a *MatchArchive) Get(matchID string) (*MatchDto, error) {
+	if a == nil {
+		return nil, fmt.Errorf("archive not configured")
+	}
+
+	a.mu.RLock()
+	data, err := os.ReadFile(a.path(matchID))
+	a.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	var match MatchDto
+	if err := json.Unmarshal(data, &match); err != nil {
+		return nil, fmt.Errorf("error decoding archived match %s: %w", matchID, err)
+	}
+	return &match, nil
+}
+
+// Has reports whether a match is present in the archive.
+func (a *MatchArchive) Has(matchID string) bool {
+	if a == nil {
+		return false
+	}
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+	_, err := os.Stat(a.path(matchID))
+	return err == nil
+}
+
+// MatchIDs lists all archived match IDs in lexical order.
+func (a *MatchArchive) MatchIDs() ([]string, error) {
+	if a == nil {
+		return nil, fmt.Errorf("archive not configured")
+	}
+
+	a.mu.RLock()
+	entries, err := os.ReadDir(a.dir)
+	a.mu.RUnlock()
+	if err != nil {
+		return nil, err
+	}
+
+	var ids []string
+	for _, entry := range entries {
+		name := entry.Name()
+		if entry.IsDir() || !strings.HasSuffix(name, ".json") {
+			continue
+		}
+		ids = append(ids, strings.TrimSuffix(name, ".json"))
+	}
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// Load returns every archived match accepted by filter. A nil filter accepts all matches.
+// Unreadable entries are skipped so a single corrupt file doesn't poison the archive.
+func (a *MatchArchive) Load(filter func(*MatchDto) bool) ([]*MatchDto, error) {
+	ids, err := a.MatchIDs()
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*MatchDto
+	for _, id := range ids {
+		match, err := a.Get(id)
+		if err != nil {
+			continue
+		}
+		if filter == nil || filter(match) {
+			matches = append(matches, match)
+		}
+	}
+	return matches, nil
+}
+
+// MatchIDsFor lists the archived match IDs a player played in, in lexical order.
+// The first call reads the whole archive to build the index; later calls don't touch the disk.
+func (a *MatchArchive) MatchIDsFor(puuid string) ([]string, error) {
+	if a == nil {
+		return nil, fmt.Errorf("archive not configured")
+	}
+
+	a.indexMu.Lock()
+	defer a.indexMu.Unlock()
+
+	if a.players == nil {
+		matches, err := a.Load(nil)
+		if err != nil {
+			return nil, err
+		}
+		a.players = make(map[string][]string)
+		a.indexed = make(map[string]bool)
+		for _, match := range matches {
+			a.index(match)
+		}
+	}
+
+	ids := append([]string(nil), a.players[puuid]...)
+	sort.Strings(ids)
+	return ids, nil
+}
+
+// index adds a match to the player index. The caller must hold indexMu.
+func (a *MatchArchive) index(match *MatchDto) {
+	matchID := match.Metadata.MatchID
+	if a.indexed[matchID] {
+		return
+	}
+	a.indexed[matchID] = true
+	for _, participant := range match.Info.Participants {
+		a.players[participant.PUUID] = append(a.players[participant.PUUID], matchID)
+	}
+}
@@ -0,0 +1,147 @@
+package riot
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+func TestMatchArchive_PutGet(t *testing.T) {
+	archive, err := NewMatchArchive(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+
+	match := &MatchDto{
+		Metadata: MetadataDto{MatchID: "NA1_123"},
+		Info: InfoDto{
+			GameDatetime: 1000,
+			Participants: []ParticipantDto{{PUUID: "test", Placement: 3}},
+		},
+	}
+	if err := archive.Put(match); err != nil {
+		t.Fatalf("Put failed: %v", err)
+	}
+
+	if !archive.Has("NA1_123") {
+		t.Error("Expected archive to contain NA1_123")
+	}
+	if archive.Has("NA1_456") {
+		t.Error("Expected archive not to contain NA1_456")
+	}
+
+	got, err := archive.Get("NA1_123")
+	if err != nil {
+		t.Fatalf("Get failed: %v", err)
+	}
+	if got.Info.Participants[0].Placement != 3 {
+		t.Errorf("Expected placement 3, got %d", got.Info.Participants[0].Placement)
+	}
+
+	if _, err := archive.Get("NA1_456"); err == nil {
+		t.Error("Expected error for missing match")
+	}
+}
+
+func TestMatchArchive_LoadSkipsCorruptEntries(t *testing.T) {
+	dir := t.TempDir()
+	archive, err := NewMatchArchive(dir)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+
+	for _, id := range []string{"NA1_2", "NA1_1"} {
+		if err := archive.Put(&MatchDto{Metadata: MetadataDto{MatchID: id}, Info: InfoDto{QueueID: 1100}}); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+	if err := os.WriteFile(filepath.Join(dir, "NA1_3.json"), []byte("{not json"), 0o644); err != nil {
+		t.Fatalf("Failed to write corrupt entry: %v", err)
+	}
+
+	ids, err := archive.MatchIDs()
+	if err != nil {
+		t.Fatalf("MatchIDs failed: %v", err)
+	}
+	if len(ids) != 3 || ids[0] != "NA1_1" {
+		t.Errorf("Expected 3 sorted IDs, got %v", ids)
+	}
+
+	matches, err := archive.Load(nil)
+	if err != nil {
+		t.Fatalf("Load failed: %v", err)
+	}
+	if len(matches) != 2 {
+		t.Errorf("Expected 2 readable matches, got %d", len(matches))
+	}
+
+	matches, err = archive.Load(func(m *MatchDto) bool { return m.Metadata.MatchID == "NA1_2" })
+	if err != nil {
+		t.Fatalf("Load with filter failed: %v", err)
+	}
+	if len(matches) != 1 {
+		t.Errorf("Expected 1 filtered match, got %d", len(matches))
+	}
+}
+
+func TestMatchArchive_RejectsInvalidMatchID(t *testing.T) {
+	archive, err := NewMatchArchive(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+
+	if err := archive.Put(&MatchDto{}); err == nil {
+		t.Error("Expected error for empty match ID")
+	}
+	if err := archive.Put(&MatchDto{Metadata: MetadataDto{MatchID: "../escape"}}); err == nil {
+		t.Error("Expected error for match ID containing a path separator")
+	}
+}
+
+func TestMatchArchive_MatchIDsFor(t *testing.T) {
+	dir := t.TempDir()
+	archive, err := NewMatchArchive(dir)
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+
+	put := func(matchID string, puuids ...string) {
+		match := &MatchDto{Metadata: MetadataDto{MatchID: matchID}}
+		for _, puuid := range puuids {
+			match.Info.Participants = append(match.Info.Participants, ParticipantDto{PUUID: puuid})
+		}
+		if err := archive.Put(match); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+	put("NA1_1", "a", "b")
+	put("NA1_2", "a")
+
+	// Matches archived before the index is built are found
+	reopened, err := NewMatchArchive(dir)
+	if err != nil {
+		t.Fatalf("Failed to reopen archive: %v", err)
+	}
+	ids, err := reopened.MatchIDsFor("a")
+	if err != nil {
+		t.Fatalf("MatchIDsFor failed: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != "NA1_1" || ids[1] != "NA1_2" {
+		t.Errorf("Expected [NA1_1 NA1_2], got %v", ids)
+	}
+
+	// And so are matches archived after, without duplicating replaced ones
+	archive = reopened
+	put("NA1_3", "b")
+	put("NA1_1", "a", "b")
+	ids, err = archive.MatchIDsFor("b")
+	if err != nil {
+		t.Fatalf("MatchIDsFor failed: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != "NA1_1" || ids[1] != "NA1_3" {
+		t.Errorf("Expected [NA1_1 NA1_3], got %v", ids)
+	}
+	if ids, _ := archive.MatchIDsFor("nobody"); len(ids) != 0 {
+		t.Errorf("Expected no matches, got %v", ids)
+	}
+}
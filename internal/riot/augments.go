@@ -0,0 +1,101 @@
+package riot
+
+import (
+	"sort"
+)
+
+// minAugmentPicks is the number of picks needed before an augment is ranked best or worst
+const minAugmentPicks = 2
+
+// AugmentStat summarizes how an augment performs
+type AugmentStat struct {
+	ID           string  `json:"id"`
+	Picks        int     `json:"picks"`        // times the augment was taken
+	PickRate     float64 `json:"pickRate"`     // 0-1, share of games it was taken in
+	AvgPlacement float64 `json:"avgPlacement"` // 1-8 average placement when taken
+	Delta        float64 `json:"delta"`        // AvgPlacement minus the baseline, negative is better
+}
+
+// AugmentProfile shows a player's augment choices and how they perform with them
+type AugmentProfile struct {
+	Augments []AugmentStat `json:"augments"` // sorted by pick count
+	Best     []AugmentStat `json:"best"`     // best performers relative to the baseline
+	Worst    []AugmentStat `json:"worst"`    // worst performers relative to the baseline
+}
+
+// buildAugmentStats computes pick counts and average placement per augment
+func buildAugmentStats(boards []ParticipantDto) map[string]AugmentStat {
+	picks := make(map[string]int)
+	placements := make(map[string]int)
+	for _, board := range boards {
+		for _, augment := range board.Augments {
+			picks[augment]++
+			placements[augment] += board.Placement
+		}
+	}
+
+	stats := make(map[string]AugmentStat, len(picks))
+	for augment, count := range picks {
+		stats[augment] = AugmentStat{
+			ID:           augment,
+			Picks:        count,
+			PickRate:     float64(count) / float64(len(boards)),
+			AvgPlacement: float64(placements[augment]) / float64(count),
+		}
+	}
+	return stats
+}
+
+// analyzeAugments summarizes a player's augment picks. When a meta snapshot is
+// available, each augment is compared against its lobby-wide average placement;
+// otherwise against the neutral 4.5.
+func (pa *ProfileAnalyzer) analyzeAugments(playerData []ParticipantDto, meta *MetaSnapshot) AugmentProfile {
+	if len(playerData) == 0 {
+		return AugmentProfile{}
+	}
+
+	stats := buildAugmentStats(playerData)
+	if len(stats) == 0 {
+		return AugmentProfile{}
+	}
+
+	var profile AugmentProfile
+	for _, stat := range stats {
+		baseline := 4.5
+		if meta != nil {
+			if lobby, ok := meta.Augments[stat.ID]; ok {
+				baseline = lobby.AvgPlacement
+			}
+		}
+		stat.Delta = stat.AvgPlacement - baseline
+		profile.Augments = append(profile.Augments, stat)
+	}
+
+	sort.Slice(profile.Augments, func(i, j int) bool {
+		if profile.Augments[i].Picks != profile.Augments[j].Picks {
+			return profile.Augments[i].Picks > profile.Augments[j].Picks
+		}
+		return profile.Augments[i].ID < profile.Augments[j].ID
+	})
+
+	// Rank augments with enough picks to be meaningful
+	var ranked []AugmentStat
+	for _, stat := range profile.Augments {
+		if stat.Picks >= minAugmentPicks {
+			ranked = append(ranked, stat)
+		}
+	}
+	sort.SliceStable(ranked, func(i, j int) bool {
+		return ranked[i].Delta < ranked[j].Delta
+	})
+
+	limit := 3
+	for i := 0; i < len(ranked) && i < limit && ranked[i].Delta < 0; i++ {
+		profile.Best = append(profile.Best, ranked[i])
+	}
+	for i := len(ranked) - 1; i >= 0 && len(profile.Worst) < limit && ranked[i].Delta > 0; i-- {
+		profile.Worst = append(profile.Worst, ranked[i])
+	}
+
+	return profile
+}
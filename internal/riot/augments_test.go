@@ -0,0 +1,65 @@
+package riot
+
+import "testing"
+
+func TestAnalyzeAugments(t *testing.T) {
+	analyzer := NewProfileAnalyzer()
+
+	playerData := []ParticipantDto{
+		{Placement: 1, Augments: []string{"TFT_Augment_Good1", "TFT_Augment_Bad2"}},
+		{Placement: 2, Augments: []string{"TFT_Augment_Good1", "TFT_Augment_Once3"}},
+		{Placement: 7, Augments: []string{"TFT_Augment_Bad2"}},
+		{Placement: 8, Augments: []string{"TFT_Augment_Bad2"}},
+	}
+
+	profile := analyzer.analyzeAugments(playerData, nil)
+
+	if len(profile.Augments) != 3 {
+		t.Fatalf("Expected 3 augments, got %d", len(profile.Augments))
+	}
+	if profile.Augments[0].ID != "TFT_Augment_Bad2" || profile.Augments[0].Picks != 3 {
+		t.Errorf("Expected most picked augment first, got %+v", profile.Augments[0])
+	}
+	if profile.Augments[0].PickRate != 0.75 {
+		t.Errorf("Expected pick rate 0.75, got %.2f", profile.Augments[0].PickRate)
+	}
+
+	if len(profile.Best) != 1 || profile.Best[0].ID != "TFT_Augment_Good1" {
+		t.Errorf("Expected Good1 as the only best augment, got %+v", profile.Best)
+	}
+	if len(profile.Worst) != 1 || profile.Worst[0].ID != "TFT_Augment_Bad2" {
+		t.Errorf("Expected Bad2 as the only worst augment, got %+v", profile.Worst)
+	}
+
+	// Against a lobby baseline where Good1 averages 1.0, the player is worse than the lobby
+	meta := &MetaSnapshot{Augments: map[string]AugmentStat{
+		"TFT_Augment_Good1": {AvgPlacement: 1.0},
+	}}
+	withMeta := analyzer.analyzeAugments(playerData, meta)
+	for _, stat := range withMeta.Augments {
+		if stat.ID == "TFT_Augment_Good1" && stat.Delta != 0.5 {
+			t.Errorf("Expected delta 0.5 against lobby baseline, got %.2f", stat.Delta)
+		}
+	}
+
+	if empty := analyzer.analyzeAugments([]ParticipantDto{{Placement: 4}}, nil); len(empty.Augments) != 0 {
+		t.Error("Expected empty augment profile without augment data")
+	}
+}
+
+func TestBuildMetaSnapshot_AugmentBaseline(t *testing.T) {
+	matches := []*MatchDto{{Info: InfoDto{Participants: []ParticipantDto{
+		{PUUID: "a", Placement: 1, Augments: []string{"TFT_Augment_X1"}},
+		{PUUID: "b", Placement: 5, Augments: []string{"TFT_Augment_X1"}},
+		{PUUID: "c", Placement: 8},
+	}}}}
+
+	meta := BuildMetaSnapshot(matches, MetaFilter{})
+	stat, ok := meta.Augments["TFT_Augment_X1"]
+	if !ok {
+		t.Fatal("Expected augment baseline for X1")
+	}
+	if stat.AvgPlacement != 3.0 || stat.Picks != 2 {
+		t.Errorf("Unexpected augment baseline %+v", stat)
+	}
+}
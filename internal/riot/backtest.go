@@ -0,0 +1,137 @@
+package riot
+
+import (
+	"math"
+	"sort"
+)
+
+// calibrationBins is how many equal-width bins top-4 probabilities are grouped into
+const calibrationBins = 10
+
+// BacktestReport measures how well a placement model predicted past matches
+type BacktestReport struct {
+	Matches           int              `json:"matches"`           // matches predicted
+	Predictions       int              `json:"predictions"`       // player placements predicted
+	MeanAbsoluteError float64          `json:"meanAbsoluteError"` // places between expected and actual placement
+	LogLoss           float64          `json:"logLoss"`           // mean negative log probability of the actual placement
+	TopFourBrier      float64          `json:"topFourBrier"`      // mean squared error of the top-4 probability
+	Calibration       []CalibrationBin `json:"calibration"`       // predicted vs observed top-4 rate
+}
+
+// CalibrationBin compares predicted and observed top-4 rates for predictions in a probability range
+type CalibrationBin struct {
+	Lower        float64 `json:"lower"`
+	Upper        float64 `json:"upper"`
+	Count        int     `json:"count"`
+	AvgPredicted float64 `json:"avgPredicted"`
+	Observed     float64 `json:"observed"`
+}
+
+// Backtest replays matches in chronological order and predicts each solo match the way
+// a live lobby is predicted: every participant is profiled from their most recent games
+// before it, using the default analyzer's window, and rated with ratingForProfile.
+// Players with too few games for a profile are rated as average. Matches where fewer than
+// minHistory participants have a profile are used only to build history.
+func (m *PlacementModel) Backtest(matches []*MatchDto, minHistory int) *BacktestReport {
+	var ordered []*MatchDto
+	for _, match := range matches {
+		if match != nil && len(match.Info.Participants) > 0 {
+			ordered = append(ordered, match)
+		}
+	}
+	sort.SliceStable(ordered, func(i, j int) bool {
+		return ordered[i].Info.GameDatetime < ordered[j].Info.GameDatetime
+	})
+
+	report := &BacktestReport{}
+	bins := make([]CalibrationBin, calibrationBins)
+	for i := range bins {
+		bins[i].Lower = float64(i) / calibrationBins
+		bins[i].Upper = float64(i+1) / calibrationBins
+	}
+
+	analyzer := NewProfileAnalyzer()
+	histories := make(map[string][]playerGame) // PUUID -> most recent games, oldest first
+
+	for _, match := range ordered {
+		participants := match.Info.Participants
+		if !IsDoubleUp(match) {
+			platform := extractRegionFromMatchID(match.Metadata.MatchID)
+			ratings := make([]float64, len(participants))
+			withHistory := 0
+			for i, participant := range participants {
+				var profile *PlayerProfile
+				if games := histories[participant.PUUID]; len(games) >= analyzer.MinGamesRequired {
+					profile = analyzer.profileFromGames(participant.PUUID, games, AnalysisOptions{Count: analyzer.MaxGamesToAnalyze})
+					withHistory++
+				}
+				ratings[i] = m.ratingForProfile(participant.PUUID, platform, profile)
+			}
+
+			if withHistory >= minHistory {
+				report.Matches++
+				distributions := PlacementDistribution(ratings)
+				for i, participant := range participants {
+					if participant.Placement < 1 || participant.Placement > len(participants) {
+						continue
+					}
+					prediction := newPlacementPrediction(participant.PUUID, ratings[i], distributions[i])
+					report.Predictions++
+					report.MeanAbsoluteError += math.Abs(prediction.ExpectedPlacement - float64(participant.Placement))
+					report.LogLoss -= math.Log(math.Max(distributions[i][participant.Placement-1], 1e-12))
+
+					topFour := 0.0
+					if participant.Placement <= 4 {
+						topFour = 1.0
+					}
+					report.TopFourBrier += math.Pow(prediction.TopFourProbability-topFour, 2)
+
+					bin := int(prediction.TopFourProbability * calibrationBins)
+					if bin >= calibrationBins {
+						bin = calibrationBins - 1
+					}
+					bins[bin].Count++
+					bins[bin].AvgPredicted += prediction.TopFourProbability
+					bins[bin].Observed += topFour
+				}
+			}
+		}
+
+		// Record results after predicting so a match never informs its own prediction.
+		// Double Up games count toward history like they do in a live profile.
+		for _, participant := range participants {
+			games := append(histories[participant.PUUID], extractPlayerGames(participant.PUUID, []*MatchDto{match})...)
+			if len(games) > analyzer.MaxGamesToAnalyze {
+				games = games[len(games)-analyzer.MaxGamesToAnalyze:]
+			}
+			histories[participant.PUUID] = games
+		}
+	}
+
+	if report.Predictions > 0 {
+		n := float64(report.Predictions)
+		report.MeanAbsoluteError /= n
+		report.LogLoss /= n
+		report.TopFourBrier /= n
+	}
+	for _, bin := range bins {
+		if bin.Count == 0 {
+			continue
+		}
+		bin.AvgPredicted /= float64(bin.Count)
+		bin.Observed /= float64(bin.Count)
+		report.Calibration = append(report.Calibration, bin)
+	}
+
+	return report
+}
+
+// BacktestPlacementModel backtests a placement model against every archived solo match,
+// with players' archived Double Up games counting toward their history
+func (a *MatchArchive) BacktestPlacementModel(m *PlacementModel, minHistory int) (*BacktestReport, error) {
+	matches, err := a.Load(nil)
+	if err != nil {
+		return nil, err
+	}
+	return m.Backtest(matches, minHistory), nil
+}
@@ -14,11 +14,15 @@ type Cache struct {
 	profileTTL  time.Duration
 	matchTTL    time.Duration
 	matchIDsTTL time.Duration
+	stateTTL    time.Duration
+	accountTTL  time.Duration
 
 	// Data stores
-	profiles map[string]cachedItem[*PlayerProfile] // key: PUUID
+	profiles map[string]cachedItem[*PlayerProfile] // key: PUUID, optionally scoped by analysis options
 	matches  map[string]cachedItem[*MatchDto]      // key: matchID
-	matchIDs map[string]cachedItem[[]string]       // key: PUUID
+	matchIDs map[string]cachedItem[[]string]       // key: PUUID, optionally scoped by analysis options
+	states   map[string]cachedItem[*profileState]  // key: PUUID scoped by analysis options
+	accounts map[string]cachedItem[*Account]       // key: PUUID
 
 	// janitor
 	janitorStop chan struct{}
@@ -35,6 +39,8 @@ type cachedItem[T any] struct {
 // - profileTTL: 1 hour
 // - matchTTL: 24 hours
 // - matchIDsTTL: 15 minutes
+// Profile aggregate state is kept for 7 days so expired profiles can be refreshed incrementally.
+// Riot IDs rarely change, so accounts are kept for 24 hours.
 func NewCache(profileTTL, matchTTL, matchIDsTTL time.Duration) *Cache {
 	if profileTTL <= 0 {
 		profileTTL = time.Hour
@@ -50,9 +56,13 @@ func NewCache(profileTTL, matchTTL, matchIDsTTL time.Duration) *Cache {
 		profileTTL:  profileTTL,
 		matchTTL:    matchTTL,
 		matchIDsTTL: matchIDsTTL,
+		stateTTL:    7 * 24 * time.Hour,
+		accountTTL:  24 * time.Hour,
 		profiles:    make(map[string]cachedItem[*PlayerProfile]),
 		matches:     make(map[string]cachedItem[*MatchDto]),
 		matchIDs:    make(map[string]cachedItem[[]string]),
+		states:      make(map[string]cachedItem[*profileState]),
+		accounts:    make(map[string]cachedItem[*Account]),
 	}
 }
 
@@ -179,6 +189,79 @@ func (c *Cache) GetMatchIDs(puuid string) ([]string, bool) {
 	return out, true
 }
 
+// setProfileState stores the aggregate state a profile was built from.
+func (c *Cache) setProfileState(key string, state *profileState) {
+	if c == nil || state == nil || key == "" {
+		return
+	}
+	exp := time.Now().Add(c.stateTTL)
+
+	c.mu.Lock()
+	c.states[key] = cachedItem[*profileState]{value: state, expiresAt: exp}
+	c.mu.Unlock()
+}
+
+// getProfileState returns the stored aggregate state for a profile, if present and not expired.
+// Callers must treat the returned state as read-only and clone it before updating.
+func (c *Cache) getProfileState(key string) (*profileState, bool) {
+	if c == nil || key == "" {
+		return nil, false
+	}
+
+	c.mu.RLock()
+	item, ok := c.states[key]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(item.expiresAt) {
+		// Expired - evict eagerly
+		c.mu.Lock()
+		delete(c.states, key)
+		c.mu.Unlock()
+		return nil, false
+	}
+
+	return item.value, true
+}
+
+// SetAccount caches an Account by its PUUID.
+func (c *Cache) SetAccount(account *Account) {
+	if c == nil || account == nil || account.PUUID == "" {
+		return
+	}
+	exp := time.Now().Add(c.accountTTL)
+
+	c.mu.Lock()
+	c.accounts[account.PUUID] = cachedItem[*Account]{value: account, expiresAt: exp}
+	c.mu.Unlock()
+}
+
+// GetAccount returns a cached Account for a PUUID, if present and not expired.
+func (c *Cache) GetAccount(puuid string) (*Account, bool) {
+	if c == nil || puuid == "" {
+		return nil, false
+	}
+
+	c.mu.RLock()
+	item, ok := c.accounts[puuid]
+	c.mu.RUnlock()
+	if !ok {
+		return nil, false
+	}
+
+	if time.Now().After(item.expiresAt) {
+		// Expired - evict eagerly
+		c.mu.Lock()
+		delete(c.accounts, puuid)
+		c.mu.Unlock()
+		return nil, false
+	}
+
+	return item.value, true
+}
+
 // PurgeExpired removes expired entries from all caches.
 // This can be called manually or via the janitor.
 func (c *Cache) PurgeExpired() {
@@ -206,6 +289,18 @@ func (c *Cache) PurgeExpired() {
 			delete(c.matchIDs, k)
 		}
 	}
+	// Profile states
+	for k, v := range c.states {
+		if now.After(v.expiresAt) {
+			delete(c.states, k)
+		}
+	}
+	// Accounts
+	for k, v := range c.accounts {
+		if now.After(v.expiresAt) {
+			delete(c.accounts, k)
+		}
+	}
 	c.mu.Unlock()
 }
 
@@ -0,0 +1,358 @@
+package riot
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// DoubleUpQueueID is the queue ID of Double Up games
+const DoubleUpQueueID = 1160
+
+// maxDuoPartners caps the favorite partner list
+const maxDuoPartners = 5
+
+// DoubleUpProfile summarizes a player's Double Up games, where placement is shared by a team of two
+type DoubleUpProfile struct {
+	Games            int          `json:"games"`
+	AvgTeamPlacement float64      `json:"avgTeamPlacement"` // 1-4 average team placement
+	TopTwoRate       float64      `json:"topTwoRate"`       // 0-1, team finished 1st or 2nd
+	WinRate          float64      `json:"winRate"`          // 0-1, team finished 1st
+	FavoritePartners []DuoPartner `json:"favoritePartners"` // most frequent partners first
+}
+
+// DuoPartner describes how a player does with one Double Up partner
+type DuoPartner struct {
+	PUUID            string  `json:"puuid"`
+	GameName         string  `json:"gameName,omitempty"`
+	TagLine          string  `json:"tagLine,omitempty"`
+	Games            int     `json:"games"`
+	AvgTeamPlacement float64 `json:"avgTeamPlacement"`
+	WinRate          float64 `json:"winRate"`
+	TopTwoRate       float64 `json:"topTwoRate"`
+}
+
+// DuoProfile summarizes a specific pair's Double Up games together
+type DuoProfile struct {
+	PlayerA          string           `json:"playerA"`
+	PlayerB          string           `json:"playerB"`
+	Games            int              `json:"games"`
+	AvgTeamPlacement float64          `json:"avgTeamPlacement"`
+	TopTwoRate       float64          `json:"topTwoRate"`
+	WinRate          float64          `json:"winRate"`
+	RecentForm       []int            `json:"recentForm"`     // team placements, oldest first
+	AvgDamageA       float64          `json:"avgDamageA"`     // player A's average damage to players
+	AvgDamageB       float64          `json:"avgDamageB"`     // player B's average damage to players
+	FavoriteTraits   []TraitFrequency `json:"favoriteTraits"` // traits across both boards
+	LastUpdated      time.Time        `json:"lastUpdated"`
+}
+
+// LobbyTeam groups Double Up teammates in a lobby
+type LobbyTeam struct {
+	TeamID           int64    `json:"teamId"`
+	PUUIDs           []string `json:"puuids"`
+	AvgTeamPlacement float64  `json:"avgTeamPlacement"` // 1-4, from members' Double Up history
+}
+
+// IsDoubleUp reports whether a match was played in Double Up
+func IsDoubleUp(match *MatchDto) bool {
+	if match == nil {
+		return false
+	}
+	if match.Info.QueueID == DoubleUpQueueID {
+		return true
+	}
+	for _, participant := range match.Info.Participants {
+		if participant.PartnerGroupID != 0 {
+			return true
+		}
+	}
+	return false
+}
+
+// TeamPlacement converts an individual Double Up placement (1-8) to the team's placement (1-4)
+func TeamPlacement(placement int) int {
+	return (placement + 1) / 2
+}
+
+// FindPartner returns the participant sharing a partner group with puuid, if any
+func FindPartner(match *MatchDto, puuid string) (*ParticipantDto, bool) {
+	if match == nil {
+		return nil, false
+	}
+
+	group := 0
+	for _, participant := range match.Info.Participants {
+		if participant.PUUID == puuid {
+			group = participant.PartnerGroupID
+			break
+		}
+	}
+	if group == 0 {
+		return nil, false
+	}
+
+	for i := range match.Info.Participants {
+		participant := &match.Info.Participants[i]
+		if participant.PartnerGroupID == group && participant.PUUID != puuid {
+			return participant, true
+		}
+	}
+	return nil, false
+}
+
+// analyzeDoubleUp summarizes Double Up games and partners. Games must come from extractPlayerGames.
+func (pa *ProfileAnalyzer) analyzeDoubleUp(games []playerGame) DoubleUpProfile {
+	var profile DoubleUpProfile
+	totalPlacement := 0
+	topTwos := 0
+	wins := 0
+
+	type partnerTally struct {
+		partner   DuoPartner
+		placement int
+		wins      int
+		topTwos   int
+	}
+	partners := make(map[string]*partnerTally)
+
+	for _, game := range games {
+		if !game.DoubleUp {
+			continue
+		}
+		team := TeamPlacement(game.Participant.Placement)
+		profile.Games++
+		totalPlacement += team
+		if team <= 2 {
+			topTwos++
+		}
+		if team == 1 {
+			wins++
+		}
+
+		if game.Partner.PUUID == "" {
+			continue
+		}
+		tally, ok := partners[game.Partner.PUUID]
+		if !ok {
+			tally = &partnerTally{partner: DuoPartner{PUUID: game.Partner.PUUID}}
+			partners[game.Partner.PUUID] = tally
+		}
+		// Games are oldest first, so the latest Riot ID wins
+		tally.partner.GameName = game.Partner.GameName
+		tally.partner.TagLine = game.Partner.TagLine
+		tally.partner.Games++
+		tally.placement += team
+		if team <= 2 {
+			tally.topTwos++
+		}
+		if team == 1 {
+			tally.wins++
+		}
+	}
+
+	if profile.Games == 0 {
+		return profile
+	}
+
+	profile.AvgTeamPlacement = float64(totalPlacement) / float64(profile.Games)
+	profile.TopTwoRate = float64(topTwos) / float64(profile.Games)
+	profile.WinRate = float64(wins) / float64(profile.Games)
+
+	for _, tally := range partners {
+		partner := tally.partner
+		partner.AvgTeamPlacement = float64(tally.placement) / float64(partner.Games)
+		partner.WinRate = float64(tally.wins) / float64(partner.Games)
+		partner.TopTwoRate = float64(tally.topTwos) / float64(partner.Games)
+		profile.FavoritePartners = append(profile.FavoritePartners, partner)
+	}
+	sort.Slice(profile.FavoritePartners, func(i, j int) bool {
+		a, b := profile.FavoritePartners[i], profile.FavoritePartners[j]
+		if a.Games != b.Games {
+			return a.Games > b.Games
+		}
+		return a.AvgTeamPlacement < b.AvgTeamPlacement
+	})
+	if len(profile.FavoritePartners) > maxDuoPartners {
+		profile.FavoritePartners = profile.FavoritePartners[:maxDuoPartners]
+	}
+
+	return profile
+}
+
+// AnalyzeDuo profiles two players' Double Up games together, found in player A's recent Double Up history
+func (pa *ProfileAnalyzer) AnalyzeDuo(puuidA, puuidB string) (*DuoProfile, error) {
+	opts := pa.normalizeOptions(AnalysisOptions{QueueID: DoubleUpQueueID})
+	matchIDs, _, err := pa.listMatchIDs(puuidA, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*MatchDto
+	for _, matchID := range matchIDs {
+		match, err := pa.getMatch(matchID)
+		if err != nil {
+			continue // skip failed matches
+		}
+		if partner, ok := FindPartner(match, puuidA); ok && partner.PUUID == puuidB {
+			matches = append(matches, match)
+		}
+	}
+
+	duo := buildDuoProfile(puuidA, puuidB, matches)
+	if duo.Games == 0 {
+		return nil, fmt.Errorf("no recent Double Up games found together")
+	}
+	return duo, nil
+}
+
+// buildDuoProfile aggregates a pair's shared Double Up games
+func buildDuoProfile(puuidA, puuidB string, matches []*MatchDto) *DuoProfile {
+	duo := &DuoProfile{
+		PlayerA:     puuidA,
+		PlayerB:     puuidB,
+		LastUpdated: time.Now(),
+	}
+
+	gamesA := extractPlayerGames(puuidA, matches)
+	gamesB := extractPlayerGames(puuidB, matches)
+	if len(gamesA) == 0 || len(gamesA) != len(gamesB) {
+		return duo
+	}
+
+	totalPlacement := 0
+	topTwos := 0
+	wins := 0
+	damageA := 0
+	damageB := 0
+	traitCounts := make(map[string]int)
+
+	for i, game := range gamesA {
+		team := TeamPlacement(game.Participant.Placement)
+		duo.RecentForm = append(duo.RecentForm, team)
+		totalPlacement += team
+		if team <= 2 {
+			topTwos++
+		}
+		if team == 1 {
+			wins++
+		}
+		damageA += game.Participant.TotalDamageToPlayers
+		damageB += gamesB[i].Participant.TotalDamageToPlayers
+
+		for _, board := range []ParticipantDto{game.Participant, gamesB[i].Participant} {
+			for _, trait := range board.Traits {
+				if trait.TierCurrent > 0 {
+					traitCounts[trait.Name]++
+				}
+			}
+		}
+	}
+
+	duo.Games = len(gamesA)
+	duo.AvgTeamPlacement = float64(totalPlacement) / float64(duo.Games)
+	duo.TopTwoRate = float64(topTwos) / float64(duo.Games)
+	duo.WinRate = float64(wins) / float64(duo.Games)
+	duo.AvgDamageA = float64(damageA) / float64(duo.Games)
+	duo.AvgDamageB = float64(damageB) / float64(duo.Games)
+
+	for name, count := range traitCounts {
+		duo.FavoriteTraits = append(duo.FavoriteTraits, TraitFrequency{
+			Name:      name,
+			Frequency: float64(count) / float64(duo.Games),
+		})
+	}
+	sort.Slice(duo.FavoriteTraits, func(i, j int) bool {
+		if duo.FavoriteTraits[i].Frequency != duo.FavoriteTraits[j].Frequency {
+			return duo.FavoriteTraits[i].Frequency > duo.FavoriteTraits[j].Frequency
+		}
+		return duo.FavoriteTraits[i].Name < duo.FavoriteTraits[j].Name
+	})
+
+	if len(duo.RecentForm) > 10 {
+		duo.RecentForm = duo.RecentForm[len(duo.RecentForm)-10:]
+	}
+
+	return duo
+}
+
+// groupLobbyTeams pairs Double Up lobby participants into teams. The spectator
+// team ID is used when it distinguishes teams; otherwise players are paired
+// with a favorite partner who is also in the lobby.
+func groupLobbyTeams(gameInfo *CurrentGameInfo, profiles []*PlayerProfile) []LobbyTeam {
+	byPUUID := make(map[string]*PlayerProfile, len(profiles))
+	for _, p := range profiles {
+		if p != nil {
+			byPUUID[p.PUUID] = p
+		}
+	}
+
+	var teams []LobbyTeam
+	teamIndex := make(map[int64]int)
+	for _, participant := range gameInfo.Participants {
+		if idx, ok := teamIndex[participant.TeamID]; ok {
+			teams[idx].PUUIDs = append(teams[idx].PUUIDs, participant.PUUID)
+			continue
+		}
+		teamIndex[participant.TeamID] = len(teams)
+		teams = append(teams, LobbyTeam{TeamID: participant.TeamID, PUUIDs: []string{participant.PUUID}})
+	}
+
+	usable := len(teams) > 1
+	for _, team := range teams {
+		if len(team.PUUIDs) > 2 {
+			usable = false
+		}
+	}
+	if !usable {
+		teams = pairByFavoritePartners(gameInfo, byPUUID)
+	}
+
+	for i := range teams {
+		total := 0.0
+		count := 0
+		for _, puuid := range teams[i].PUUIDs {
+			if p, ok := byPUUID[puuid]; ok && p.DoubleUp.Games > 0 {
+				total += p.DoubleUp.AvgTeamPlacement
+				count++
+			}
+		}
+		if count > 0 {
+			teams[i].AvgTeamPlacement = total / float64(count)
+		}
+	}
+
+	return teams
+}
+
+// pairByFavoritePartners pairs lobby players who list each other as recent partners.
+// Players without a match in the lobby are left in single-member teams.
+func pairByFavoritePartners(gameInfo *CurrentGameInfo, byPUUID map[string]*PlayerProfile) []LobbyTeam {
+	inLobby := make(map[string]bool, len(gameInfo.Participants))
+	for _, participant := range gameInfo.Participants {
+		inLobby[participant.PUUID] = true
+	}
+
+	paired := make(map[string]bool)
+	var teams []LobbyTeam
+	for _, participant := range gameInfo.Participants {
+		puuid := participant.PUUID
+		if paired[puuid] {
+			continue
+		}
+		paired[puuid] = true
+		team := LobbyTeam{TeamID: int64(len(teams) + 1), PUUIDs: []string{puuid}}
+
+		if p, ok := byPUUID[puuid]; ok {
+			for _, partner := range p.DoubleUp.FavoritePartners {
+				if inLobby[partner.PUUID] && !paired[partner.PUUID] {
+					paired[partner.PUUID] = true
+					team.PUUIDs = append(team.PUUIDs, partner.PUUID)
+					break
+				}
+			}
+		}
+		teams = append(teams, team)
+	}
+	return teams
+}
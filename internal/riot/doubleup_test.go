@@ -0,0 +1,165 @@
+package riot
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// duoTestMatch builds a Double Up match at hour h where "a" and partner share a team
+// finishing in the given individual placements.
+func duoTestMatch(h int, partner string, placementA, placementB int) *MatchDto {
+	return &MatchDto{
+		Metadata: MetadataDto{MatchID: fmt.Sprintf("NA1_%d", h)},
+		Info: InfoDto{
+			GameDatetime: time.Date(2025, 8, 1, h, 0, 0, 0, time.UTC).UnixMilli(),
+			QueueID:      DoubleUpQueueID,
+			Participants: []ParticipantDto{
+				{PUUID: "a", Placement: placementA, PartnerGroupID: 1, TotalDamageToPlayers: 100,
+					Traits: []TraitDto{{Name: "TFT15_Duelist", TierCurrent: 1}}},
+				{PUUID: partner, RiotIDGameName: partner, RiotIDTagline: "NA1", Placement: placementB, PartnerGroupID: 1, TotalDamageToPlayers: 50,
+					Traits: []TraitDto{{Name: "TFT15_Duelist", TierCurrent: 1}}},
+				{PUUID: "other", Placement: 8, PartnerGroupID: 2},
+			},
+		},
+	}
+}
+
+func TestIsDoubleUpAndTeamPlacement(t *testing.T) {
+	if !IsDoubleUp(duoTestMatch(1, "b", 1, 2)) {
+		t.Error("Expected Double Up queue to be detected")
+	}
+	if IsDoubleUp(stateTestMatch(1, 1, 1100)) {
+		t.Error("Expected ranked match not to be Double Up")
+	}
+
+	for placement, expected := range map[int]int{1: 1, 2: 1, 3: 2, 4: 2, 7: 4, 8: 4} {
+		if got := TeamPlacement(placement); got != expected {
+			t.Errorf("For placement %d, expected team placement %d, got %d", placement, expected, got)
+		}
+	}
+}
+
+func TestFindPartner(t *testing.T) {
+	match := duoTestMatch(1, "b", 1, 2)
+
+	partner, ok := FindPartner(match, "a")
+	if !ok || partner.PUUID != "b" {
+		t.Fatalf("Expected partner b, got %+v", partner)
+	}
+	if _, ok := FindPartner(stateTestMatch(1, 1, 1100), "test"); ok {
+		t.Error("Expected no partner in a solo match")
+	}
+}
+
+func TestExtractPlayerGames_Partner(t *testing.T) {
+	match := duoTestMatch(1, "b", 3, 4)
+	games := extractPlayerGames("a", []*MatchDto{match})
+	if len(games) != 1 {
+		t.Fatalf("Expected 1 game, got %d", len(games))
+	}
+	want := teammate{PUUID: "b", Placement: 4, GameName: "b", TagLine: "NA1"}
+	if games[0].Partner != want {
+		t.Errorf("Expected partner %+v, got %+v", want, games[0].Partner)
+	}
+
+	// The partner is a copy, not a view into the match
+	match.Info.Participants[1].PUUID = "changed"
+	if games[0].Partner.PUUID != "b" {
+		t.Error("Expected the partner to be copied out of the match")
+	}
+}
+
+func TestAnalyzeDoubleUp(t *testing.T) {
+	analyzer := NewProfileAnalyzer()
+	matches := []*MatchDto{
+		duoTestMatch(1, "b", 1, 2),
+		duoTestMatch(2, "b", 3, 4),
+		duoTestMatch(3, "c", 7, 8),
+		stateTestMatch(4, 1, 1100),
+	}
+	for _, match := range matches[3:] {
+		match.Info.Participants[0].PUUID = "a"
+	}
+
+	profile := analyzer.analyzeDoubleUp(extractPlayerGames("a", matches))
+	if profile.Games != 3 {
+		t.Fatalf("Expected 3 Double Up games, got %d", profile.Games)
+	}
+	if profile.AvgTeamPlacement != 7.0/3.0 {
+		t.Errorf("Expected avg team placement 2.33, got %.2f", profile.AvgTeamPlacement)
+	}
+	if len(profile.FavoritePartners) != 2 || profile.FavoritePartners[0].PUUID != "b" {
+		t.Fatalf("Expected b as favorite partner, got %+v", profile.FavoritePartners)
+	}
+	b := profile.FavoritePartners[0]
+	if b.Games != 2 || b.WinRate != 0.5 || b.TopTwoRate != 1.0 || b.GameName != "b" {
+		t.Errorf("Unexpected partner stats %+v", b)
+	}
+}
+
+func TestProfileFromGames_ExcludesDoubleUpFromSoloStats(t *testing.T) {
+	analyzer := NewProfileAnalyzer()
+	matches := []*MatchDto{duoTestMatch(1, "b", 8, 7), duoTestMatch(2, "b", 8, 7)}
+	for h := 3; h <= 5; h++ {
+		match := stateTestMatch(h, 2, 1100)
+		match.Info.Participants[0].PUUID = "a"
+		matches = append(matches, match)
+	}
+
+	profile := analyzer.profileFromGames("a", extractPlayerGames("a", matches), analyzer.normalizeOptions(AnalysisOptions{}))
+	if profile.PlayStyle.AveragePlacement != 2.0 {
+		t.Errorf("Expected solo average placement 2.00, got %.2f", profile.PlayStyle.AveragePlacement)
+	}
+	if profile.AnalyzedGames != 5 || profile.SoloGames != 3 {
+		t.Errorf("Expected 5 games with 3 solo, got %d and %d", profile.AnalyzedGames, profile.SoloGames)
+	}
+	if profile.DoubleUp.Games != 2 || profile.DoubleUp.AvgTeamPlacement != 4.0 {
+		t.Errorf("Expected 2 Double Up games at team #4, got %+v", profile.DoubleUp)
+	}
+}
+
+func TestBuildDuoProfile(t *testing.T) {
+	matches := []*MatchDto{duoTestMatch(2, "b", 3, 4), duoTestMatch(1, "b", 1, 2)}
+
+	duo := buildDuoProfile("a", "b", matches)
+	if duo.Games != 2 {
+		t.Fatalf("Expected 2 games, got %d", duo.Games)
+	}
+	if fmt.Sprint(duo.RecentForm) != "[1 2]" {
+		t.Errorf("Expected recent form [1 2] oldest first, got %v", duo.RecentForm)
+	}
+	if duo.WinRate != 0.5 || duo.AvgDamageA != 100 || duo.AvgDamageB != 50 {
+		t.Errorf("Unexpected duo stats %+v", duo)
+	}
+	if len(duo.FavoriteTraits) != 1 || duo.FavoriteTraits[0].Name != "TFT15_Duelist" {
+		t.Errorf("Expected shared Duelist trait, got %+v", duo.FavoriteTraits)
+	}
+}
+
+func TestGroupLobbyTeams(t *testing.T) {
+	byTeamID := &CurrentGameInfo{Participants: []CurrentGameParticipant{
+		{PUUID: "a", TeamID: 100}, {PUUID: "b", TeamID: 100},
+		{PUUID: "c", TeamID: 200}, {PUUID: "d", TeamID: 200},
+	}}
+	teams := groupLobbyTeams(byTeamID, nil)
+	if len(teams) != 2 || fmt.Sprint(teams[1].PUUIDs) != "[c d]" {
+		t.Errorf("Expected teams grouped by team ID, got %+v", teams)
+	}
+
+	// Without distinguishing team IDs, pair players by recent partners
+	sameTeam := &CurrentGameInfo{Participants: []CurrentGameParticipant{
+		{PUUID: "a"}, {PUUID: "c"}, {PUUID: "b"}, {PUUID: "d"},
+	}}
+	profiles := []*PlayerProfile{
+		{PUUID: "a", DoubleUp: DoubleUpProfile{Games: 4, AvgTeamPlacement: 2.0, FavoritePartners: []DuoPartner{{PUUID: "x"}, {PUUID: "b"}}}},
+		{PUUID: "b", DoubleUp: DoubleUpProfile{Games: 4, AvgTeamPlacement: 3.0}},
+	}
+	teams = groupLobbyTeams(sameTeam, profiles)
+	if len(teams) != 3 || fmt.Sprint(teams[0].PUUIDs) != "[a b]" {
+		t.Fatalf("Expected a and b paired by partner history, got %+v", teams)
+	}
+	if teams[0].AvgTeamPlacement != 2.5 {
+		t.Errorf("Expected team avg placement 2.50, got %.2f", teams[0].AvgTeamPlacement)
+	}
+}
@@ -0,0 +1,181 @@
+package riot
+
+import (
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RankedQueue is the queue type of standard TFT ranked entries
+const RankedQueue = "RANKED_TFT"
+
+// tierOrder lists ranked tiers from lowest to highest
+var tierOrder = []string{"IRON", "BRONZE", "SILVER", "GOLD", "PLATINUM", "EMERALD", "DIAMOND", "MASTER", "GRANDMASTER", "CHALLENGER"}
+
+// divisionOrder lists divisions within a tier from lowest to highest
+var divisionOrder = []string{"IV", "III", "II", "I"}
+
+// GetTFTLeagueEntriesByPUUID returns a player's TFT ranked entries on a platform, one per
+// ranked queue they have played. Unranked players have no entries.
+func GetTFTLeagueEntriesByPUUID(puuid, region string) ([]LeagueEntry, error) {
+	endpoint := fmt.Sprintf("/tft/league/v1/by-puuid/%s", puuid)
+	url := buildRegionalURL(region, endpoint)
+
+	var entries []LeagueEntry
+	if err := makeAPIRequest(url, &entries); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+// RankedEntry returns the standard ranked entry from a player's entries, or nil if unranked
+func RankedEntry(entries []LeagueEntry) *LeagueEntry {
+	for idx := range entries {
+		if entries[idx].QueueType == RankedQueue {
+			return &entries[idx]
+		}
+	}
+	return nil
+}
+
+// Score orders entries on a single ladder: each division is worth 100 LP, and apex
+// tiers, which have no divisions, are ranked by LP above Diamond I.
+func (e LeagueEntry) Score() int {
+	tier := indexOf(tierOrder, strings.ToUpper(e.Tier))
+	if tier < 0 {
+		return 0
+	}
+	score := tier*len(divisionOrder)*100 + e.LeaguePoints
+	if !e.IsApex() {
+		score += max(indexOf(divisionOrder, e.Rank), 0) * 100
+	}
+	return score
+}
+
+// IsApex reports whether the entry is in Master, Grandmaster or Challenger
+func (e LeagueEntry) IsApex() bool {
+	return indexOf(tierOrder, strings.ToUpper(e.Tier)) >= indexOf(tierOrder, "MASTER")
+}
+
+// String formats the entry like "Gold II 45 LP" or "Master 312 LP"
+func (e LeagueEntry) String() string {
+	tier := strings.ToUpper(e.Tier)
+	if tier == "" {
+		return "Unranked"
+	}
+	name := tier[:1] + strings.ToLower(tier[1:])
+	if e.IsApex() {
+		return fmt.Sprintf("%s %d LP", name, e.LeaguePoints)
+	}
+	return fmt.Sprintf("%s %s %d LP", name, e.Rank, e.LeaguePoints)
+}
+
+// Rank bands group players for meta snapshots: each tier below Master is its own band,
+// and the apex tiers share one since they are too small to split.
+const (
+	RankBandApex     = "MASTER+"
+	RankBandUnranked = "UNRANKED"
+)
+
+// RankBandOf returns the rank band of a ranked entry, e.g. "GOLD" or "MASTER+"
+func RankBandOf(entry *LeagueEntry) string {
+	if entry == nil || indexOf(tierOrder, strings.ToUpper(entry.Tier)) < 0 {
+		return RankBandUnranked
+	}
+	if entry.IsApex() {
+		return RankBandApex
+	}
+	return strings.ToUpper(entry.Tier)
+}
+
+// leagueRankTTL is how long a looked up rank is reused before it is fetched again
+const leagueRankTTL = 24 * time.Hour
+
+// LeagueRanks looks up players' standard ranked entries and remembers them, so
+// banding an archive or rating a lobby only asks the API about each player once a day.
+type LeagueRanks struct {
+	// Lookup fetches a player's entries on a platform; defaults to GetTFTLeagueEntriesByPUUID
+	Lookup func(puuid, platform string) ([]LeagueEntry, error)
+	// Spacing is the minimum time between lookups, to stay under the API rate limit
+	Spacing time.Duration
+
+	mu         sync.Mutex
+	entries    map[string]leagueRank
+	pending    map[string]chan struct{} // lookups in flight, closed when they finish
+	nextLookup time.Time                // earliest time the next lookup may start
+}
+
+// leagueRank is a remembered lookup; entry is nil for unranked players
+type leagueRank struct {
+	entry     *LeagueEntry
+	fetchedAt time.Time
+}
+
+// NewLeagueRanks creates an empty rank lookup backed by the Riot API
+func NewLeagueRanks() *LeagueRanks {
+	return &LeagueRanks{
+		Lookup:  GetTFTLeagueEntriesByPUUID,
+		entries: make(map[string]leagueRank),
+		pending: make(map[string]chan struct{}),
+	}
+}
+
+// Entry returns a player's standard ranked entry, or nil when they are unranked or the
+// lookup fails. Failed lookups are not remembered so they are retried next time. The lock
+// isn't held while waiting for a lookup slot or fetching, so remembered ranks are served
+// right away and a player is only looked up once at a time.
+func (r *LeagueRanks) Entry(puuid, platform string) *LeagueEntry {
+	r.mu.Lock()
+	for {
+		if rank, ok := r.entries[puuid]; ok && time.Since(rank.fetchedAt) < leagueRankTTL {
+			r.mu.Unlock()
+			return rank.entry
+		}
+		inFlight, ok := r.pending[puuid]
+		if !ok {
+			break
+		}
+		r.mu.Unlock()
+		<-inFlight
+		r.mu.Lock()
+	}
+
+	done := make(chan struct{})
+	r.pending[puuid] = done
+	start := time.Now()
+	if r.nextLookup.After(start) {
+		start = r.nextLookup
+	}
+	r.nextLookup = start.Add(r.Spacing)
+	r.mu.Unlock()
+
+	time.Sleep(time.Until(start))
+	entries, err := r.Lookup(puuid, platform)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.pending, puuid)
+	close(done)
+	if err != nil {
+		return nil
+	}
+	entry := RankedEntry(entries)
+	r.entries[puuid] = leagueRank{entry: entry, fetchedAt: time.Now()}
+	return entry
+}
+
+// Band returns a player's rank band (see RankBandOf), for MetaFilter.RankOf
+func (r *LeagueRanks) Band(puuid, platform string) string {
+	return RankBandOf(r.Entry(puuid, platform))
+}
+
+// indexOf returns the position of value in values, or -1
+func indexOf(values []string, value string) int {
+	for idx, v := range values {
+		if v == value {
+			return idx
+		}
+	}
+	return -1
+}
@@ -0,0 +1,165 @@
+package riot
+
+import (
+	"fmt"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+)
+
+func TestGetTFTLeagueEntriesByPUUID(t *testing.T) {
+	server := newFakeAPI(t)
+	entries := []LeagueEntry{
+		{PUUID: testPUUID, QueueType: "RANKED_TFT_DOUBLE_UP", Tier: "SILVER", Rank: "I", LeaguePoints: 10},
+		{PUUID: testPUUID, QueueType: RankedQueue, Tier: "GOLD", Rank: "II", LeaguePoints: 45, Wins: 12, Losses: 30},
+	}
+	if err := server.SetLeagueEntries("EUW1", testPUUID, entries); err != nil {
+		t.Fatal(err)
+	}
+
+	got, err := GetTFTLeagueEntriesByPUUID(testPUUID, "EUW1")
+	if err != nil {
+		t.Fatalf("Failed to get league entries: %v", err)
+	}
+	ranked := RankedEntry(got)
+	if ranked == nil || ranked.Tier != "GOLD" || ranked.LeaguePoints != 45 || ranked.Wins != 12 {
+		t.Errorf("Expected the ranked Gold entry, got %+v", ranked)
+	}
+
+	// Entries are per platform, and unranked players have none
+	got, err = GetTFTLeagueEntriesByPUUID(testPUUID, "NA1")
+	if err != nil {
+		t.Fatalf("Failed to get league entries: %v", err)
+	}
+	if len(got) != 0 || RankedEntry(got) != nil {
+		t.Errorf("Expected no NA1 entries, got %+v", got)
+	}
+}
+
+func TestLeagueEntryScore(t *testing.T) {
+	ladder := []LeagueEntry{
+		{Tier: "IRON", Rank: "IV", LeaguePoints: 0},
+		{Tier: "GOLD", Rank: "IV", LeaguePoints: 99},
+		{Tier: "GOLD", Rank: "III", LeaguePoints: 0},
+		{Tier: "DIAMOND", Rank: "I", LeaguePoints: 99},
+		{Tier: "MASTER", LeaguePoints: 0},
+		{Tier: "MASTER", LeaguePoints: 250},
+		{Tier: "CHALLENGER", LeaguePoints: 1000},
+	}
+	for idx := 1; idx < len(ladder); idx++ {
+		if ladder[idx].Score() <= ladder[idx-1].Score() {
+			t.Errorf("Expected %s (%d) above %s (%d)", ladder[idx], ladder[idx].Score(), ladder[idx-1], ladder[idx-1].Score())
+		}
+	}
+}
+
+func TestLeagueEntryString(t *testing.T) {
+	tests := map[string]LeagueEntry{
+		"Gold II 45 LP":   {Tier: "GOLD", Rank: "II", LeaguePoints: 45},
+		"Master 312 LP":   {Tier: "MASTER", Rank: "I", LeaguePoints: 312},
+		"Unranked":        {},
+		"Challenger 0 LP": {Tier: "CHALLENGER", Rank: "I"},
+	}
+	for want, entry := range tests {
+		if got := entry.String(); got != want {
+			t.Errorf("Expected %q, got %q", want, got)
+		}
+	}
+}
+
+func TestRankBandOf(t *testing.T) {
+	tests := []struct {
+		entry    *LeagueEntry
+		expected string
+	}{
+		{&LeagueEntry{Tier: "GOLD", Rank: "II"}, "GOLD"},
+		{&LeagueEntry{Tier: "diamond", Rank: "I"}, "DIAMOND"},
+		{&LeagueEntry{Tier: "GRANDMASTER", Rank: "I"}, RankBandApex},
+		{&LeagueEntry{Tier: "UNKNOWN"}, RankBandUnranked},
+		{nil, RankBandUnranked},
+	}
+	for _, test := range tests {
+		if got := RankBandOf(test.entry); got != test.expected {
+			t.Errorf("Expected %q for %+v, got %q", test.expected, test.entry, got)
+		}
+	}
+}
+
+func TestLeagueRanks(t *testing.T) {
+	lookups := 0
+	ranks := NewLeagueRanks()
+	ranks.Lookup = func(puuid, platform string) ([]LeagueEntry, error) {
+		lookups++
+		switch puuid {
+		case "master":
+			return []LeagueEntry{
+				{QueueType: "RANKED_TFT_DOUBLE_UP", Tier: "GOLD", Rank: "I"},
+				{QueueType: RankedQueue, Tier: "MASTER", Rank: "I", LeaguePoints: 120},
+			}, nil
+		case "unranked":
+			return nil, nil
+		}
+		return nil, fmt.Errorf("API request failed with status 429")
+	}
+
+	if got := ranks.Band("master", "NA1"); got != RankBandApex {
+		t.Errorf("Expected %s, got %s", RankBandApex, got)
+	}
+	if got := ranks.Band("unranked", "NA1"); got != RankBandUnranked {
+		t.Errorf("Expected %s, got %s", RankBandUnranked, got)
+	}
+	ranks.Band("master", "NA1")
+	ranks.Band("unranked", "NA1")
+	if lookups != 2 {
+		t.Errorf("Expected ranks to be remembered after 2 lookups, got %d", lookups)
+	}
+
+	// Failed lookups are retried
+	ranks.Entry("limited", "NA1")
+	ranks.Entry("limited", "NA1")
+	if lookups != 4 {
+		t.Errorf("Expected failed lookups to be retried, got %d lookups", lookups)
+	}
+}
+
+func TestLeagueRanks_LookupInFlight(t *testing.T) {
+	var lookups atomic.Int32
+	release := make(chan struct{})
+	ranks := NewLeagueRanks()
+	ranks.Lookup = func(puuid, platform string) ([]LeagueEntry, error) {
+		lookups.Add(1)
+		if puuid == "slow" {
+			<-release
+		}
+		return []LeagueEntry{{QueueType: RankedQueue, Tier: "GOLD", Rank: "II"}}, nil
+	}
+	ranks.Entry("known", "NA1")
+
+	var wg sync.WaitGroup
+	for i := 0; i < 2; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			ranks.Entry("slow", "NA1")
+		}()
+	}
+
+	// A remembered rank is served while another player's lookup is in flight
+	served := make(chan struct{})
+	go func() {
+		ranks.Entry("known", "NA1")
+		close(served)
+	}()
+	select {
+	case <-served:
+	case <-time.After(time.Second):
+		t.Fatal("Remembered rank waited for a lookup in flight")
+	}
+
+	close(release)
+	wg.Wait()
+	if n := lookups.Load(); n != 2 {
+		t.Errorf("Expected concurrent lookups of a player to share one request, got %d lookups", n)
+	}
+}
@@ -0,0 +1,351 @@
+package riot
+
+import (
+	"fmt"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+)
+
+// contestedCompRate is the comp play rate at which, on average, at least one
+// player per 8-player lobby is on the same comp.
+const contestedCompRate = 1.0 / 8.0
+
+// MetaSnapshot summarizes what the player population is playing for a patch and rank band.
+// It is built purely from stored matches so it can be computed offline from the archive.
+type MetaSnapshot struct {
+	Patch       string                 `json:"patch,omitempty"`    // e.g. "15.17", empty means all patches
+	RankBand    string                 `json:"rankBand,omitempty"` // e.g. "MASTER+", empty means all ranks
+	Matches     int                    `json:"matches"`            // matches contributing to the snapshot
+	SampleSize  int                    `json:"sampleSize"`         // participant lines contributing to the snapshot
+	GeneratedAt time.Time              `json:"generatedAt"`
+	TraitRates  map[string]float64     `json:"traitRates"` // 0-1, share of boards with the trait active
+	UnitRates   map[string]float64     `json:"unitRates"`  // 0-1, share of boards fielding the unit
+	CompRates   map[string]float64     `json:"compRates"`  // 0-1, share of boards on the comp (see CompSignature)
+	TopComps    []CompFrequency        `json:"topComps"`   // comps sorted by play rate
+	Augments    map[string]AugmentStat `json:"augments"`   // lobby-wide pick rate and placement per augment
+}
+
+// CompFrequency captures how often a comp is played and how well it does.
+type CompFrequency struct {
+	Comp         string  `json:"comp"`
+	Frequency    float64 `json:"frequency"`    // 0-1, share of boards on this comp
+	AvgPlacement float64 `json:"avgPlacement"` // 1-8 average placement on this comp
+}
+
+// MetaFilter selects which matches and participants feed a MetaSnapshot.
+type MetaFilter struct {
+	Patch    string // only include matches on this patch (see PatchFromGameVersion); empty for any
+	QueueID  int    // only include this queue; 0 for any
+	RankBand string // only include participants in this band (see RankBandOf); empty for any
+	// RankOf resolves a participant's rank band on the platform the match was played on,
+	// e.g. LeagueRanks.Band. Required when RankBand is set.
+	RankOf func(puuid, platform string) string
+}
+
+var patchPattern = regexp.MustCompile(`(\d+)\.(\d+)`)
+
+// PatchFromGameVersion extracts the "major.minor" patch from a game_version string,
+// e.g. "Linux Version 15.17.707.6040 (Aug 21 2025/14:35:13) [PUBLIC] <Releases/15.17>" -> "15.17".
+func PatchFromGameVersion(version string) string {
+	if idx := strings.Index(version, "<Releases/"); idx != -1 {
+		rest := strings.TrimSuffix(version[idx+len("<Releases/"):], ">")
+		if m := patchPattern.FindString(rest); m != "" {
+			return m
+		}
+	}
+	if idx := strings.Index(version, "Version "); idx != -1 {
+		if m := patchPattern.FindString(version[idx:]); m != "" {
+			return m
+		}
+	}
+	return patchPattern.FindString(version)
+}
+
+// CompSignature identifies a board by its two strongest active traits, ranked by
+// trait style then unit count. The two names are sorted so the signature is stable.
+func CompSignature(p ParticipantDto) string {
+	var active []TraitDto
+	for _, trait := range p.Traits {
+		if trait.TierCurrent > 0 {
+			active = append(active, trait)
+		}
+	}
+	if len(active) == 0 {
+		return ""
+	}
+
+	sort.Slice(active, func(i, j int) bool {
+		if active[i].Style != active[j].Style {
+			return active[i].Style > active[j].Style
+		}
+		if active[i].NumUnits != active[j].NumUnits {
+			return active[i].NumUnits > active[j].NumUnits
+		}
+		return active[i].Name < active[j].Name
+	})
+
+	top := 2
+	if len(active) < top {
+		top = len(active)
+	}
+	names := make([]string, 0, top)
+	for _, trait := range active[:top] {
+		names = append(names, trait.Name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, "+")
+}
+
+// matches reports whether a match passes the patch and queue filters.
+func (f MetaFilter) matches(match *MatchDto) bool {
+	if f.Patch != "" && PatchFromGameVersion(match.Info.GameVersion) != f.Patch {
+		return false
+	}
+	if f.QueueID != 0 && match.Info.QueueID != f.QueueID {
+		return false
+	}
+	return true
+}
+
+// includes reports whether a participant of a match played on platform falls in the filter's rank band.
+func (f MetaFilter) includes(puuid, platform string) bool {
+	if f.RankBand == "" {
+		return true
+	}
+	return f.RankOf != nil && f.RankOf(puuid, platform) == f.RankBand
+}
+
+// MetaTally accumulates the counts behind a MetaSnapshot, so a snapshot can be kept current
+// by adding newly archived matches instead of recounting the whole archive.
+type MetaTally struct {
+	filter MetaFilter
+	latest bool // follow the newest patch added instead of filter.Patch
+
+	matches        int
+	sampleSize     int
+	traitCounts    map[string]int
+	unitCounts     map[string]int
+	compCounts     map[string]int
+	compPlacements map[string]int
+	boards         []ParticipantDto
+}
+
+// NewMetaTally creates an empty tally of the matches and participants that pass filter
+func NewMetaTally(filter MetaFilter) *MetaTally {
+	t := &MetaTally{filter: filter}
+	t.reset(filter.Patch)
+	return t
+}
+
+// NewLatestMetaTally creates an empty tally for the most recent patch added. The filter's
+// patch is replaced by the newest one seen, and the counts start over when a newer patch
+// appears; its queue and rank band still apply.
+func NewLatestMetaTally(filter MetaFilter) *MetaTally {
+	t := NewMetaTally(filter)
+	t.latest = true
+	t.reset("")
+	return t
+}
+
+// reset clears the counts and sets the patch being counted
+func (t *MetaTally) reset(patch string) {
+	t.filter.Patch = patch
+	t.matches = 0
+	t.sampleSize = 0
+	t.traitCounts = make(map[string]int)
+	t.unitCounts = make(map[string]int)
+	t.compCounts = make(map[string]int)
+	t.compPlacements = make(map[string]int)
+	t.boards = nil
+}
+
+// Add counts a match's participants that pass the filter. Rank bands are resolved before
+// anything is counted, checking stop between lookups; when stop closes first nothing is
+// counted and Add returns false, so the match can be added again later.
+func (t *MetaTally) Add(match *MatchDto, stop <-chan struct{}) bool {
+	if match == nil {
+		return true
+	}
+	if t.latest {
+		if patch := PatchFromGameVersion(match.Info.GameVersion); patchAfter(patch, t.filter.Patch) {
+			t.reset(patch)
+		}
+	}
+	if !t.filter.matches(match) {
+		return true
+	}
+
+	platform := extractRegionFromMatchID(match.Metadata.MatchID)
+	var included []ParticipantDto
+	for _, participant := range match.Info.Participants {
+		select {
+		case <-stop:
+			return false
+		default:
+		}
+		if t.filter.includes(participant.PUUID, platform) {
+			included = append(included, participant)
+		}
+	}
+	if len(included) == 0 {
+		return true
+	}
+
+	t.matches++
+	for _, participant := range included {
+		t.sampleSize++
+		t.boards = append(t.boards, participant)
+
+		for _, trait := range participant.Traits {
+			if trait.TierCurrent > 0 {
+				t.traitCounts[trait.Name]++
+			}
+		}
+
+		// Count each unit once per board, even when fielded twice
+		seen := make(map[string]bool)
+		for _, unit := range participant.Units {
+			if !seen[unit.CharacterID] {
+				seen[unit.CharacterID] = true
+				t.unitCounts[unit.CharacterID]++
+			}
+		}
+
+		if comp := CompSignature(participant); comp != "" {
+			t.compCounts[comp]++
+			t.compPlacements[comp] += participant.Placement
+		}
+	}
+	return true
+}
+
+// Snapshot computes trait, unit and comp play rates from the matches added so far
+func (t *MetaTally) Snapshot() *MetaSnapshot {
+	snapshot := &MetaSnapshot{
+		Patch:       t.filter.Patch,
+		RankBand:    t.filter.RankBand,
+		Matches:     t.matches,
+		SampleSize:  t.sampleSize,
+		GeneratedAt: time.Now(),
+		TraitRates:  make(map[string]float64),
+		UnitRates:   make(map[string]float64),
+		CompRates:   make(map[string]float64),
+	}
+	if snapshot.SampleSize == 0 {
+		return snapshot
+	}
+
+	snapshot.Augments = buildAugmentStats(t.boards)
+
+	total := float64(snapshot.SampleSize)
+	for name, count := range t.traitCounts {
+		snapshot.TraitRates[name] = float64(count) / total
+	}
+	for id, count := range t.unitCounts {
+		snapshot.UnitRates[id] = float64(count) / total
+	}
+	for comp, count := range t.compCounts {
+		snapshot.CompRates[comp] = float64(count) / total
+		snapshot.TopComps = append(snapshot.TopComps, CompFrequency{
+			Comp:         comp,
+			Frequency:    float64(count) / total,
+			AvgPlacement: float64(t.compPlacements[comp]) / float64(count),
+		})
+	}
+	sort.Slice(snapshot.TopComps, func(i, j int) bool {
+		if snapshot.TopComps[i].Frequency != snapshot.TopComps[j].Frequency {
+			return snapshot.TopComps[i].Frequency > snapshot.TopComps[j].Frequency
+		}
+		return snapshot.TopComps[i].Comp < snapshot.TopComps[j].Comp
+	})
+
+	return snapshot
+}
+
+// patchAfter reports whether patch a (e.g. "15.18") is newer than b; any patch is newer than none
+func patchAfter(a, b string) bool {
+	if a == "" || b == "" {
+		return b == "" && a != ""
+	}
+	var aMajor, aMinor, bMajor, bMinor int
+	fmt.Sscanf(a, "%d.%d", &aMajor, &aMinor)
+	fmt.Sscanf(b, "%d.%d", &bMajor, &bMinor)
+	if aMajor != bMajor {
+		return aMajor > bMajor
+	}
+	return aMinor > bMinor
+}
+
+// BuildMetaSnapshot computes trait, unit and comp play rates from a set of matches.
+func BuildMetaSnapshot(matches []*MatchDto, filter MetaFilter) *MetaSnapshot {
+	tally := NewMetaTally(filter)
+	for _, match := range matches {
+		tally.Add(match, nil)
+	}
+	return tally.Snapshot()
+}
+
+// BuildMetaSnapshot computes a meta snapshot from every archived match that passes the filter.
+func (a *MatchArchive) BuildMetaSnapshot(filter MetaFilter) (*MetaSnapshot, error) {
+	matches, err := a.Load(filter.matches)
+	if err != nil {
+		return nil, err
+	}
+	return BuildMetaSnapshot(matches, filter), nil
+}
+
+// BuildLatestMetaSnapshot computes a meta snapshot for the most recent patch in the archive.
+// The filter's patch is replaced by the latest one; its queue and rank band still apply.
+func (a *MatchArchive) BuildLatestMetaSnapshot(filter MetaFilter) (*MetaSnapshot, error) {
+	matches, err := a.Load(nil)
+	if err != nil {
+		return nil, err
+	}
+	tally := NewLatestMetaTally(filter)
+	for _, match := range matches {
+		tally.Add(match, nil)
+	}
+	return tally.Snapshot(), nil
+}
+
+// IsContested reports whether a comp is played often enough that it is expected
+// to be contested in a typical lobby.
+func (m *MetaSnapshot) IsContested(comp string) bool {
+	if m == nil || comp == "" {
+		return false
+	}
+	return m.CompRates[comp] >= contestedCompRate
+}
+
+// scoreMetaFollower scores 0-1 how closely a player's comps track the meta.
+// Each game scores its comp's play rate relative to the most played comp, so
+// always playing the top comp scores 1 and off-meta boards score near 0.
+func (pa *ProfileAnalyzer) scoreMetaFollower(playerData []ParticipantDto, meta *MetaSnapshot) float64 {
+	if meta == nil || len(meta.TopComps) == 0 || len(playerData) == 0 {
+		return 0.0
+	}
+
+	topRate := meta.TopComps[0].Frequency
+	total := 0.0
+	for _, game := range playerData {
+		total += meta.CompRates[CompSignature(game)] / topRate
+	}
+	return total / float64(len(playerData))
+}
+
+// scoreContestRate returns the share of a player's games spent on contested comps.
+func (pa *ProfileAnalyzer) scoreContestRate(playerData []ParticipantDto, meta *MetaSnapshot) float64 {
+	if meta == nil || len(playerData) == 0 {
+		return 0.0
+	}
+
+	contested := 0
+	for _, game := range playerData {
+		if meta.IsContested(CompSignature(game)) {
+			contested++
+		}
+	}
+	return float64(contested) / float64(len(playerData))
+}
@@ -0,0 +1,188 @@
+package riot
+
+import (
+	"math"
+	"testing"
+)
+
+// metaTestBoard builds a participant on a two-trait comp
+func metaTestBoard(puuid string, placement int, traitA, traitB string) ParticipantDto {
+	return ParticipantDto{
+		PUUID:     puuid,
+		Placement: placement,
+		Traits: []TraitDto{
+			{Name: traitA, NumUnits: 6, Style: 3, TierCurrent: 3},
+			{Name: traitB, NumUnits: 4, Style: 2, TierCurrent: 2},
+			{Name: "TFT15_Filler", NumUnits: 1, Style: 0, TierCurrent: 0},
+		},
+		Units: []UnitDto{{CharacterID: "TFT15_Jinx"}, {CharacterID: "TFT15_Jinx"}},
+	}
+}
+
+func TestPatchFromGameVersion(t *testing.T) {
+	tests := []struct {
+		version  string
+		expected string
+	}{
+		{"Linux Version 15.17.707.6040 (Aug 21 2025/14:35:13) [PUBLIC] <Releases/15.17>", "15.17"},
+		{"Version 14.24.640.2525 (Dec 05 2024/12:00:00) [PUBLIC]", "14.24"},
+		{"15.3", "15.3"},
+		{"", ""},
+	}
+
+	for _, test := range tests {
+		if got := PatchFromGameVersion(test.version); got != test.expected {
+			t.Errorf("For %q, expected %q, got %q", test.version, test.expected, got)
+		}
+	}
+}
+
+func TestCompSignature(t *testing.T) {
+	a := metaTestBoard("a", 1, "TFT15_Sniper", "TFT15_Star")
+	b := metaTestBoard("b", 1, "TFT15_Star", "TFT15_Sniper")
+	b.Traits[0], b.Traits[1] = b.Traits[1], b.Traits[0]
+
+	if CompSignature(a) != "TFT15_Sniper+TFT15_Star" {
+		t.Errorf("Unexpected signature %q", CompSignature(a))
+	}
+	if CompSignature(a) != CompSignature(b) {
+		t.Errorf("Expected signatures to be order independent: %q vs %q", CompSignature(a), CompSignature(b))
+	}
+	if CompSignature(ParticipantDto{}) != "" {
+		t.Error("Expected empty signature for board without active traits")
+	}
+}
+
+func TestBuildMetaSnapshot(t *testing.T) {
+	matches := []*MatchDto{
+		{Info: InfoDto{GameVersion: "<Releases/15.17>", QueueID: 1100, Participants: []ParticipantDto{
+			metaTestBoard("a", 1, "TFT15_Sniper", "TFT15_Star"),
+			metaTestBoard("b", 2, "TFT15_Sniper", "TFT15_Star"),
+			metaTestBoard("c", 5, "TFT15_Bruiser", "TFT15_Mage"),
+			metaTestBoard("d", 8, "TFT15_Duelist", "TFT15_Mage"),
+		}}},
+		{Info: InfoDto{GameVersion: "<Releases/15.16>", QueueID: 1100, Participants: []ParticipantDto{
+			metaTestBoard("e", 1, "TFT15_Bruiser", "TFT15_Mage"),
+		}}},
+	}
+
+	meta := BuildMetaSnapshot(matches, MetaFilter{Patch: "15.17"})
+	if meta.Matches != 1 || meta.SampleSize != 4 {
+		t.Fatalf("Expected 1 match and 4 boards, got %d and %d", meta.Matches, meta.SampleSize)
+	}
+	if meta.TopComps[0].Comp != "TFT15_Sniper+TFT15_Star" {
+		t.Errorf("Expected Sniper/Star to be the top comp, got %q", meta.TopComps[0].Comp)
+	}
+	if meta.TopComps[0].Frequency != 0.5 || meta.TopComps[0].AvgPlacement != 1.5 {
+		t.Errorf("Unexpected top comp stats: %+v", meta.TopComps[0])
+	}
+	if meta.TraitRates["TFT15_Mage"] != 0.5 {
+		t.Errorf("Expected Mage trait rate 0.5, got %.2f", meta.TraitRates["TFT15_Mage"])
+	}
+	if _, ok := meta.TraitRates["TFT15_Filler"]; ok {
+		t.Error("Inactive traits should not count toward the meta")
+	}
+	if meta.UnitRates["TFT15_Jinx"] != 1.0 {
+		t.Errorf("Expected duplicate units to count once per board, got %.2f", meta.UnitRates["TFT15_Jinx"])
+	}
+
+	// Rank band filtering only keeps resolved participants in the band
+	ranks := map[string]string{"a": "MASTER+", "c": "MASTER+"}
+	banded := BuildMetaSnapshot(matches, MetaFilter{
+		Patch:    "15.17",
+		RankBand: "MASTER+",
+		RankOf:   func(puuid, platform string) string { return ranks[puuid] },
+	})
+	if banded.SampleSize != 2 {
+		t.Errorf("Expected 2 boards in rank band, got %d", banded.SampleSize)
+	}
+}
+
+func TestMatchArchive_BuildLatestMetaSnapshot(t *testing.T) {
+	archive, err := NewMatchArchive(t.TempDir())
+	if err != nil {
+		t.Fatalf("Failed to create archive: %v", err)
+	}
+
+	old := &MatchDto{Metadata: MetadataDto{MatchID: "NA1_1"}, Info: InfoDto{GameDatetime: 1, GameVersion: "<Releases/15.16>", Participants: []ParticipantDto{
+		metaTestBoard("a", 1, "TFT15_Bruiser", "TFT15_Mage"),
+	}}}
+	latest := &MatchDto{Metadata: MetadataDto{MatchID: "NA1_2"}, Info: InfoDto{GameDatetime: 2, GameVersion: "<Releases/15.17>", Participants: []ParticipantDto{
+		metaTestBoard("a", 1, "TFT15_Sniper", "TFT15_Star"),
+	}}}
+	for _, m := range []*MatchDto{old, latest} {
+		if err := archive.Put(m); err != nil {
+			t.Fatalf("Put failed: %v", err)
+		}
+	}
+
+	meta, err := archive.BuildLatestMetaSnapshot(MetaFilter{})
+	if err != nil {
+		t.Fatalf("BuildLatestMetaSnapshot failed: %v", err)
+	}
+	if meta.Patch != "15.17" || meta.SampleSize != 1 {
+		t.Errorf("Expected snapshot of patch 15.17 with 1 board, got %q with %d", meta.Patch, meta.SampleSize)
+	}
+}
+
+func TestLatestMetaTally(t *testing.T) {
+	match := func(id, patch string, boards ...ParticipantDto) *MatchDto {
+		return &MatchDto{Metadata: MetadataDto{MatchID: id}, Info: InfoDto{GameVersion: "<Releases/" + patch + ">", Participants: boards}}
+	}
+	tally := NewLatestMetaTally(MetaFilter{})
+
+	tally.Add(match("NA1_1", "15.9", metaTestBoard("a", 1, "TFT15_Bruiser", "TFT15_Mage")), nil)
+	tally.Add(match("NA1_2", "15.10", metaTestBoard("b", 1, "TFT15_Sniper", "TFT15_Star")), nil)
+	tally.Add(match("NA1_3", "15.9", metaTestBoard("c", 1, "TFT15_Bruiser", "TFT15_Mage")), nil)
+	tally.Add(match("NA1_4", "15.10", metaTestBoard("d", 2, "TFT15_Sniper", "TFT15_Star")), nil)
+
+	meta := tally.Snapshot()
+	if meta.Patch != "15.10" || meta.Matches != 2 || meta.SampleSize != 2 {
+		t.Errorf("Expected 2 matches of patch 15.10, got %d of %q", meta.Matches, meta.Patch)
+	}
+
+	// A stopped add counts nothing, so the match can be added again
+	stop := make(chan struct{})
+	close(stop)
+	if tally.Add(match("NA1_5", "15.10", metaTestBoard("e", 1, "TFT15_Sniper", "TFT15_Star")), stop) {
+		t.Error("Expected a stopped add to report the match wasn't counted")
+	}
+	if meta := tally.Snapshot(); meta.Matches != 2 {
+		t.Errorf("Expected a stopped add to count nothing, got %d matches", meta.Matches)
+	}
+}
+
+func TestScoreMetaFollowerAndContestRate(t *testing.T) {
+	analyzer := NewProfileAnalyzer()
+	meta := &MetaSnapshot{
+		CompRates: map[string]float64{
+			"TFT15_Sniper+TFT15_Star":  0.20,
+			"TFT15_Bruiser+TFT15_Mage": 0.10,
+		},
+		TopComps: []CompFrequency{
+			{Comp: "TFT15_Sniper+TFT15_Star", Frequency: 0.20},
+			{Comp: "TFT15_Bruiser+TFT15_Mage", Frequency: 0.10},
+		},
+	}
+
+	playerData := []ParticipantDto{
+		metaTestBoard("p", 1, "TFT15_Sniper", "TFT15_Star"),
+		metaTestBoard("p", 4, "TFT15_Bruiser", "TFT15_Mage"),
+		metaTestBoard("p", 8, "TFT15_Duelist", "TFT15_Rare"),
+	}
+
+	follower := analyzer.scoreMetaFollower(playerData, meta)
+	expected := (1.0 + 0.5 + 0.0) / 3.0
+	if math.Abs(follower-expected) > 1e-9 {
+		t.Errorf("Expected meta follower %.3f, got %.3f", expected, follower)
+	}
+
+	contest := analyzer.scoreContestRate(playerData, meta)
+	if math.Abs(contest-1.0/3.0) > 1e-9 {
+		t.Errorf("Expected contest rate 0.333, got %.3f", contest)
+	}
+
+	if analyzer.scoreMetaFollower(playerData, nil) != 0 || analyzer.scoreContestRate(playerData, nil) != 0 {
+		t.Error("Expected zero scores without a meta snapshot")
+	}
+}
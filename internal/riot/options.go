@@ -0,0 +1,156 @@
+package riot
+
+import (
+	"fmt"
+	"strings"
+	"time"
+)
+
+const (
+	// matchIDPageSize is how many match IDs are requested per page
+	matchIDPageSize = 20
+	// maxScannedMatchIDs caps how far back we page when filters discard games
+	maxScannedMatchIDs = 200
+	// matchScanFactor is how many IDs we list per requested game when post-filtering
+	matchScanFactor = 3
+)
+
+// AnalysisOptions scopes which games a profile is built from.
+// The zero value analyzes the analyzer's MaxGamesToAnalyze most recent games.
+type AnalysisOptions struct {
+	Count     int       `json:"count"`               // number of games to analyze
+	StartTime time.Time `json:"startTime,omitempty"` // only games played at or after this time
+	EndTime   time.Time `json:"endTime,omitempty"`   // only games played before this time
+	Patch     string    `json:"patch,omitempty"`     // e.g. "15.17" (see PatchFromGameVersion)
+	QueueID   int       `json:"queueId,omitempty"`   // e.g. 1100 for ranked
+	SetNumber int       `json:"setNumber,omitempty"` // e.g. 15
+}
+
+// normalizeOptions fills in defaults from the analyzer settings
+func (pa *ProfileAnalyzer) normalizeOptions(opts AnalysisOptions) AnalysisOptions {
+	if opts.Count <= 0 {
+		opts.Count = pa.MaxGamesToAnalyze
+	}
+	if opts.Count <= 0 {
+		opts.Count = 20
+	}
+	return opts
+}
+
+// cacheKey scopes cache entries for a player to these options so
+// differently-windowed profiles don't collide.
+func (o AnalysisOptions) cacheKey(puuid string) string {
+	var start, end int64
+	if !o.StartTime.IsZero() {
+		start = o.StartTime.Unix()
+	}
+	if !o.EndTime.IsZero() {
+		end = o.EndTime.Unix()
+	}
+	return fmt.Sprintf("%s|n=%d|from=%d|to=%d|patch=%s|queue=%d|set=%d",
+		puuid, o.Count, start, end, o.Patch, o.QueueID, o.SetNumber)
+}
+
+// hasMatchFilters reports whether games must be inspected to know if they are in scope.
+// Time bounds are applied by the API, so they don't count.
+func (o AnalysisOptions) hasMatchFilters() bool {
+	return o.Patch != "" || o.QueueID != 0 || o.SetNumber != 0
+}
+
+// scanLimit is the number of match IDs to list so that, after filtering, Count games remain
+func (o AnalysisOptions) scanLimit() int {
+	if !o.hasMatchFilters() {
+		return o.Count
+	}
+	limit := o.Count * matchScanFactor
+	if limit > maxScannedMatchIDs {
+		limit = maxScannedMatchIDs
+	}
+	if limit < o.Count {
+		limit = o.Count
+	}
+	return limit
+}
+
+// epochRange converts the time bounds into the API's epoch-second parameters
+func (o AnalysisOptions) epochRange() (startTime, endTime *int64) {
+	if !o.StartTime.IsZero() {
+		start := o.StartTime.Unix()
+		startTime = &start
+	}
+	if !o.EndTime.IsZero() {
+		end := o.EndTime.Unix()
+		endTime = &end
+	}
+	return startTime, endTime
+}
+
+// includes reports whether a match falls within the options' window
+func (o AnalysisOptions) includes(match *MatchDto) bool {
+	if match == nil {
+		return false
+	}
+	played := time.UnixMilli(match.Info.GameDatetime)
+	if !o.StartTime.IsZero() && match.Info.GameDatetime != 0 && played.Before(o.StartTime) {
+		return false
+	}
+	if !o.EndTime.IsZero() && match.Info.GameDatetime != 0 && !played.Before(o.EndTime) {
+		return false
+	}
+	if o.Patch != "" && PatchFromGameVersion(match.Info.GameVersion) != o.Patch {
+		return false
+	}
+	if o.QueueID != 0 && match.Info.QueueID != o.QueueID {
+		return false
+	}
+	if o.SetNumber != 0 && match.Info.TftSetNumber != o.SetNumber {
+		return false
+	}
+	return true
+}
+
+// listMatchIDs finds the region holding the player's match history and pages
+// through it until enough IDs are collected for the options.
+func (pa *ProfileAnalyzer) listMatchIDs(puuid string, opts AnalysisOptions) ([]string, string, error) {
+	startTime, endTime := opts.epochRange()
+
+	// Try regions in order until we find match history
+	regions := []string{"NA1", "EUW1", "KR", "BR1", "LAS", "LAN", "EUNE", "OC1", "JP1", "TR1", "RU", "PH2", "SG2", "TH2", "TW2", "VN2"}
+	for _, region := range regions {
+		ids, err := pa.fetchMatchIDs(puuid, region, opts.scanLimit(), startTime, endTime)
+		if err != nil && strings.Contains(err.Error(), "status 429") {
+			// Every other region shares the rate limit
+			return nil, "", err
+		}
+		if err == nil && len(ids) > 0 {
+			return ids, region, nil
+		}
+	}
+	return nil, "", fmt.Errorf("no match history found for %s in any region", puuid)
+}
+
+// fetchMatchIDs requests pages of match IDs until limit IDs are collected or
+// the history runs out. An error on the first page is returned; errors on
+// later pages end pagination early and return what has been collected so far.
+func (pa *ProfileAnalyzer) fetchMatchIDs(puuid, region string, limit int, startTime, endTime *int64) ([]string, error) {
+	var ids []string
+	for len(ids) < limit {
+		count := matchIDPageSize
+		if remaining := limit - len(ids); remaining < count {
+			count = remaining
+		}
+
+		page, err := pa.dataSource().ListMatchIDs(puuid, region, len(ids), count, startTime, endTime)
+		if err != nil {
+			if len(ids) == 0 {
+				return nil, err
+			}
+			break
+		}
+		ids = append(ids, page...)
+		if len(page) < count {
+			break
+		}
+	}
+	return ids, nil
+}
@@ -0,0 +1,78 @@
+package riot
+
+import (
+	"testing"
+	"time"
+)
+
+func TestAnalysisOptions_CacheKeyScopesProfiles(t *testing.T) {
+	analyzer := NewProfileAnalyzer()
+
+	defaults := analyzer.normalizeOptions(AnalysisOptions{})
+	if defaults.Count != analyzer.MaxGamesToAnalyze {
+		t.Errorf("Expected default count %d, got %d", analyzer.MaxGamesToAnalyze, defaults.Count)
+	}
+
+	keys := map[string]bool{
+		defaults.cacheKey("p"): true,
+		analyzer.normalizeOptions(AnalysisOptions{Count: 50}).cacheKey("p"):                    true,
+		analyzer.normalizeOptions(AnalysisOptions{Patch: "15.17"}).cacheKey("p"):               true,
+		analyzer.normalizeOptions(AnalysisOptions{QueueID: 1100}).cacheKey("p"):                true,
+		analyzer.normalizeOptions(AnalysisOptions{SetNumber: 15}).cacheKey("p"):                true,
+		analyzer.normalizeOptions(AnalysisOptions{StartTime: time.Unix(100, 0)}).cacheKey("p"): true,
+	}
+	if len(keys) != 6 {
+		t.Errorf("Expected 6 distinct cache keys, got %d", len(keys))
+	}
+
+	if defaults.cacheKey("p") != analyzer.normalizeOptions(AnalysisOptions{}).cacheKey("p") {
+		t.Error("Expected identical options to share a cache key")
+	}
+}
+
+func TestAnalysisOptions_Includes(t *testing.T) {
+	match := &MatchDto{Info: InfoDto{
+		GameDatetime: time.Date(2025, 8, 20, 12, 0, 0, 0, time.UTC).UnixMilli(),
+		GameVersion:  "<Releases/15.17>",
+		QueueID:      1100,
+		TftSetNumber: 15,
+	}}
+
+	tests := []struct {
+		name     string
+		opts     AnalysisOptions
+		expected bool
+	}{
+		{"no filters", AnalysisOptions{}, true},
+		{"matching patch", AnalysisOptions{Patch: "15.17"}, true},
+		{"other patch", AnalysisOptions{Patch: "15.16"}, false},
+		{"other queue", AnalysisOptions{QueueID: 1090}, false},
+		{"other set", AnalysisOptions{SetNumber: 14}, false},
+		{"inside range", AnalysisOptions{StartTime: time.Date(2025, 8, 20, 0, 0, 0, 0, time.UTC), EndTime: time.Date(2025, 8, 21, 0, 0, 0, 0, time.UTC)}, true},
+		{"before start", AnalysisOptions{StartTime: time.Date(2025, 8, 21, 0, 0, 0, 0, time.UTC)}, false},
+		{"after end", AnalysisOptions{EndTime: time.Date(2025, 8, 20, 0, 0, 0, 0, time.UTC)}, false},
+	}
+
+	for _, test := range tests {
+		if got := test.opts.includes(match); got != test.expected {
+			t.Errorf("%s: expected %v, got %v", test.name, test.expected, got)
+		}
+	}
+}
+
+func TestAnalysisOptions_ScanLimit(t *testing.T) {
+	if limit := (AnalysisOptions{Count: 30}).scanLimit(); limit != 30 {
+		t.Errorf("Expected unfiltered scan limit 30, got %d", limit)
+	}
+	if limit := (AnalysisOptions{Count: 30, Patch: "15.17"}).scanLimit(); limit != 90 {
+		t.Errorf("Expected filtered scan limit 90, got %d", limit)
+	}
+	if limit := (AnalysisOptions{Count: 100, QueueID: 1100}).scanLimit(); limit != maxScannedMatchIDs {
+		t.Errorf("Expected scan limit capped at %d, got %d", maxScannedMatchIDs, limit)
+	}
+
+	start, end := (AnalysisOptions{StartTime: time.Unix(100, 0)}).epochRange()
+	if start == nil || *start != 100 || end != nil {
+		t.Errorf("Unexpected epoch range %v %v", start, end)
+	}
+}
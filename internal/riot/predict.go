@@ -0,0 +1,173 @@
+package riot
+
+import (
+	"math"
+)
+
+// meanPlacement is the expected placement of an average player in an 8-player lobby
+const meanPlacement = 4.5
+
+// PlacementModel predicts lobby placements with a Plackett-Luce model. Each player
+// gets a rating from their placement history; a player's strength is exp(rating),
+// and finishing order is drawn first place to last, each remaining player taking
+// the next spot with probability proportional to their strength.
+type PlacementModel struct {
+	Scale      float64 // rating per place better than average; higher makes predictions more confident
+	PriorGames float64 // pseudo-games at the mean placement that shrink ratings from short histories
+	// RankOf optionally adds a rating adjustment from a player's ranked standing on a
+	// platform, e.g. RankRating.
+	RankOf func(puuid, platform string) float64
+}
+
+// PlacementPrediction is one player's predicted finish in a lobby
+type PlacementPrediction struct {
+	PUUID              string    `json:"puuid"`
+	Rating             float64   `json:"rating"`
+	Distribution       []float64 `json:"distribution"`       // probability of finishing 1st..Nth
+	ExpectedPlacement  float64   `json:"expectedPlacement"`  // mean of the distribution
+	TopFourProbability float64   `json:"topFourProbability"` // probability of finishing 1st-4th
+}
+
+// NewPlacementModel creates a model with default parameters
+func NewPlacementModel() *PlacementModel {
+	return &PlacementModel{
+		Scale:      0.5,
+		PriorGames: 5,
+	}
+}
+
+// DefaultRankWeight is the rating per tier above or below Gold I used by RankRating in the bot
+const DefaultRankWeight = 0.1
+
+// rankBaselineScore is the ladder score (see LeagueEntry.Score) rated as average, Gold I 0 LP
+const rankBaselineScore = 1500
+
+// RankRating returns a RankOf that rates players by their ranked standing, worth perTier
+// for every tier (400 LP) they are above or below Gold I. Unranked players get no adjustment.
+func RankRating(ranks *LeagueRanks, perTier float64) func(puuid, platform string) float64 {
+	return func(puuid, platform string) float64 {
+		entry := ranks.Entry(puuid, platform)
+		if entry == nil {
+			return 0.0
+		}
+		return perTier * float64(entry.Score()-rankBaselineScore) / 400
+	}
+}
+
+// Rating converts an average placement over a number of games into a rating.
+// Averages are shrunk toward the mean so a few lucky games don't dominate.
+func (m *PlacementModel) Rating(avgPlacement float64, games int) float64 {
+	if games <= 0 || avgPlacement <= 0 {
+		return 0.0
+	}
+	n := float64(games)
+	shrunk := (avgPlacement*n + meanPlacement*m.PriorGames) / (n + m.PriorGames)
+	return m.Scale * (meanPlacement - shrunk)
+}
+
+// ratingForProfile rates a player on a platform from their profile, preferring time-weighted form
+func (m *PlacementModel) ratingForProfile(puuid, platform string, p *PlayerProfile) float64 {
+	rating := 0.0
+	if p != nil {
+		placement := p.Performance.WeightedPlacement
+		if placement == 0 {
+			placement = p.PlayStyle.AveragePlacement
+		}
+		rating = m.Rating(placement, p.SoloGames)
+	}
+	if m.RankOf != nil {
+		rating += m.RankOf(puuid, platform)
+	}
+	return rating
+}
+
+// PredictLobby predicts placements for each participant, in participant order.
+// Players without a profile are rated as average.
+func (m *PlacementModel) PredictLobby(gameInfo *CurrentGameInfo, profiles []*PlayerProfile) []PlacementPrediction {
+	byPUUID := make(map[string]*PlayerProfile, len(profiles))
+	for _, p := range profiles {
+		if p != nil {
+			byPUUID[p.PUUID] = p
+		}
+	}
+
+	ratings := make([]float64, len(gameInfo.Participants))
+	for i, participant := range gameInfo.Participants {
+		ratings[i] = m.ratingForProfile(participant.PUUID, gameInfo.PlatformID, byPUUID[participant.PUUID])
+	}
+
+	distributions := PlacementDistribution(ratings)
+	predictions := make([]PlacementPrediction, len(gameInfo.Participants))
+	for i, participant := range gameInfo.Participants {
+		predictions[i] = newPlacementPrediction(participant.PUUID, ratings[i], distributions[i])
+	}
+	return predictions
+}
+
+// newPlacementPrediction summarizes a placement distribution
+func newPlacementPrediction(puuid string, rating float64, distribution []float64) PlacementPrediction {
+	prediction := PlacementPrediction{
+		PUUID:        puuid,
+		Rating:       rating,
+		Distribution: distribution,
+	}
+	for k, p := range distribution {
+		prediction.ExpectedPlacement += float64(k+1) * p
+		if k < 4 {
+			prediction.TopFourProbability += p
+		}
+	}
+	return prediction
+}
+
+// PlacementDistribution returns, for each rating, the Plackett-Luce probability of
+// finishing in each place. It is exact, enumerating which players took the places
+// above, so it is intended for lobby-sized inputs.
+func PlacementDistribution(ratings []float64) [][]float64 {
+	n := len(ratings)
+	distributions := make([][]float64, n)
+	for i := range distributions {
+		distributions[i] = make([]float64, n)
+	}
+	if n == 0 {
+		return distributions
+	}
+
+	strengths := make([]float64, n)
+	for i, rating := range ratings {
+		strengths[i] = math.Exp(rating)
+	}
+
+	// placed[mask] is the probability that exactly the players in mask took the top places
+	placed := make([]float64, 1<<n)
+	placed[0] = 1
+	for mask := 0; mask < len(placed); mask++ {
+		if placed[mask] == 0 {
+			continue
+		}
+
+		remaining := 0.0
+		place := 0
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) == 0 {
+				remaining += strengths[i]
+			} else {
+				place++
+			}
+		}
+		if remaining == 0 {
+			continue
+		}
+
+		for i := 0; i < n; i++ {
+			if mask&(1<<i) != 0 {
+				continue
+			}
+			p := placed[mask] * strengths[i] / remaining
+			distributions[i][place] += p
+			placed[mask|1<<i] += p
+		}
+	}
+
+	return distributions
+}
@@ -0,0 +1,194 @@
+package riot
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestPlacementDistribution_EqualRatingsAreUniform(t *testing.T) {
+	distributions := PlacementDistribution(make([]float64, 8))
+	for i, distribution := range distributions {
+		for k, p := range distribution {
+			if math.Abs(p-1.0/8.0) > 1e-9 {
+				t.Fatalf("Player %d place %d: expected 0.125, got %.4f", i, k+1, p)
+			}
+		}
+	}
+}
+
+func TestPlacementDistribution_RowsAndColumnsSumToOne(t *testing.T) {
+	ratings := []float64{1.2, 0.5, 0, 0, -0.3, -0.8, 0.1, 2.0}
+	distributions := PlacementDistribution(ratings)
+
+	for i, distribution := range distributions {
+		total := 0.0
+		for _, p := range distribution {
+			total += p
+		}
+		if math.Abs(total-1) > 1e-9 {
+			t.Errorf("Player %d: distribution sums to %.6f", i, total)
+		}
+	}
+	for k := range ratings {
+		total := 0.0
+		for i := range distributions {
+			total += distributions[i][k]
+		}
+		if math.Abs(total-1) > 1e-9 {
+			t.Errorf("Place %d: probabilities sum to %.6f", k+1, total)
+		}
+	}
+
+	// Two players: first place is the strength share
+	pair := PlacementDistribution([]float64{math.Log(3), 0})
+	if math.Abs(pair[0][0]-0.75) > 1e-9 || math.Abs(pair[1][1]-0.75) > 1e-9 {
+		t.Errorf("Expected 75%% first place for 3x strength, got %+v", pair)
+	}
+}
+
+func TestPlacementModel_Rating(t *testing.T) {
+	model := NewPlacementModel()
+
+	if model.Rating(4.5, 20) != 0 {
+		t.Error("Expected an average player to have zero rating")
+	}
+	if model.Rating(0, 0) != 0 {
+		t.Error("Expected zero rating without games")
+	}
+	// Same average, more games, more confidence
+	if model.Rating(2.0, 5) >= model.Rating(2.0, 50) {
+		t.Error("Expected a longer history to shrink less toward average")
+	}
+	if model.Rating(6.0, 10) >= 0 {
+		t.Error("Expected a below-average player to have a negative rating")
+	}
+}
+
+func TestPlacementModel_PredictLobby(t *testing.T) {
+	model := NewPlacementModel()
+	model.RankOf = func(puuid, platform string) float64 {
+		if puuid == "ranked" {
+			return 0.5
+		}
+		return 0
+	}
+
+	game := &CurrentGameInfo{Participants: []CurrentGameParticipant{{PUUID: "strong"}, {PUUID: "weak"}, {PUUID: "unknown"}, {PUUID: "ranked"}}}
+	profiles := []*PlayerProfile{
+		{PUUID: "weak", AnalyzedGames: 20, SoloGames: 20, PlayStyle: PlayStyleProfile{AveragePlacement: 6.5}},
+		{PUUID: "strong", AnalyzedGames: 20, SoloGames: 20, PlayStyle: PlayStyleProfile{AveragePlacement: 5.0}, Performance: PerformanceProfile{WeightedPlacement: 2.5}},
+	}
+
+	predictions := model.PredictLobby(game, profiles)
+	if len(predictions) != 4 || predictions[0].PUUID != "strong" {
+		t.Fatalf("Expected predictions in participant order, got %+v", predictions)
+	}
+	if predictions[0].ExpectedPlacement >= predictions[1].ExpectedPlacement {
+		t.Errorf("Expected strong player to place better: %.2f vs %.2f", predictions[0].ExpectedPlacement, predictions[1].ExpectedPlacement)
+	}
+	if predictions[2].Rating != 0 || predictions[3].Rating != 0.5 {
+		t.Errorf("Expected unknown player at 0 and ranked bonus 0.5, got %.2f and %.2f", predictions[2].Rating, predictions[3].Rating)
+	}
+
+	total := 0.0
+	for _, prediction := range predictions {
+		total += prediction.ExpectedPlacement
+	}
+	if math.Abs(total-10) > 1e-9 {
+		t.Errorf("Expected placements in a 4-player lobby to sum to 10, got %.4f", total)
+	}
+}
+
+func TestPlacementModel_Backtest(t *testing.T) {
+	// The same 8 players finish in the same order every game
+	var matches []*MatchDto
+	for h := 0; h < 20; h++ {
+		match := &MatchDto{
+			Metadata: MetadataDto{MatchID: fmt.Sprintf("NA1_%d", h)},
+			Info: InfoDto{
+				GameDatetime: time.Date(2025, 8, 1, h, 0, 0, 0, time.UTC).UnixMilli(),
+				QueueID:      1100,
+			},
+		}
+		for p := 1; p <= 8; p++ {
+			match.Info.Participants = append(match.Info.Participants, ParticipantDto{PUUID: fmt.Sprintf("p%d", p), Placement: p})
+		}
+		matches = append(matches, match)
+	}
+
+	flat := &PlacementModel{Scale: 0, PriorGames: 5}
+	informed := NewPlacementModel()
+
+	flatReport := flat.Backtest(matches, 8)
+	report := informed.Backtest(matches, 8)
+	if report.Matches != 15 || report.Predictions != 15*8 {
+		t.Fatalf("Expected the first 5 matches to only build profiles, got %d matches and %d predictions", report.Matches, report.Predictions)
+	}
+	if math.Abs(flatReport.LogLoss-math.Log(8)) > 1e-9 {
+		t.Errorf("Expected uniform log loss %.3f, got %.3f", math.Log(8), flatReport.LogLoss)
+	}
+	if report.LogLoss >= flatReport.LogLoss || report.MeanAbsoluteError >= flatReport.MeanAbsoluteError {
+		t.Errorf("Expected history to beat a uniform model: %+v vs %+v", report, flatReport)
+	}
+
+	// The sixth match is predicted from profiles of the five before it, like a live lobby
+	ranked := NewPlacementModel()
+	ranked.RankOf = func(puuid, platform string) float64 {
+		if platform != "NA1" {
+			t.Errorf("Expected the platform from the match ID, got %q", platform)
+		}
+		return 0
+	}
+	sixth := ranked.Backtest(matches[:6], 8)
+	analyzer := NewProfileAnalyzer()
+	ratings := make([]float64, 8)
+	for p := range ratings {
+		puuid := fmt.Sprintf("p%d", p+1)
+		profile := analyzer.profileFromGames(puuid, extractPlayerGames(puuid, matches[:5]), AnalysisOptions{})
+		ratings[p] = informed.ratingForProfile(puuid, "NA1", profile)
+	}
+	expected := 0.0
+	for p, distribution := range PlacementDistribution(ratings) {
+		expected -= math.Log(distribution[p]) / 8
+	}
+	if sixth.Matches != 1 || math.Abs(sixth.LogLoss-expected) > 1e-9 {
+		t.Errorf("Expected 1 match with log loss %.4f from profile ratings, got %d with %.4f", expected, sixth.Matches, sixth.LogLoss)
+	}
+
+	counted := 0
+	for _, bin := range report.Calibration {
+		counted += bin.Count
+		if bin.AvgPredicted < bin.Lower || bin.AvgPredicted > bin.Upper {
+			t.Errorf("Bin %.1f-%.1f has average prediction %.3f outside its range", bin.Lower, bin.Upper, bin.AvgPredicted)
+		}
+	}
+	if counted != report.Predictions {
+		t.Errorf("Expected every prediction in a calibration bin, got %d of %d", counted, report.Predictions)
+	}
+}
+
+func TestRankRating(t *testing.T) {
+	ranks := NewLeagueRanks()
+	ranks.Lookup = func(puuid, platform string) ([]LeagueEntry, error) {
+		switch puuid {
+		case "gold":
+			return []LeagueEntry{{QueueType: RankedQueue, Tier: "GOLD", Rank: "I"}}, nil
+		case "platinum":
+			return []LeagueEntry{{QueueType: RankedQueue, Tier: "PLATINUM", Rank: "I"}}, nil
+		}
+		return nil, nil
+	}
+
+	rating := RankRating(ranks, 0.1)
+	if got := rating("gold", "NA1"); got != 0 {
+		t.Errorf("Expected Gold I to be rated average, got %.2f", got)
+	}
+	if got := rating("platinum", "NA1"); math.Abs(got-0.1) > 1e-9 {
+		t.Errorf("Expected one tier above Gold I to add 0.1, got %.2f", got)
+	}
+	if got := rating("unranked", "NA1"); got != 0 {
+		t.Errorf("Expected no adjustment for unranked players, got %.2f", got)
+	}
+}
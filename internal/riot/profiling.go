@@ -2,10 +2,17 @@ package riot
 
 import (
 	"fmt"
+	"math"
 	"sort"
+	"strings"
+	"sync"
 	"time"
 )
 
+// recentFormHalfLife is how long it takes a game's weight to halve when computing
+// the time-weighted average placement.
+const recentFormHalfLife = 72 * time.Hour
+
 // PlayerProfile represents a player's analyzed gameplay patterns
 type PlayerProfile struct {
 	PUUID          string                `json:"puuid"`
@@ -13,11 +20,16 @@ type PlayerProfile struct {
 	TagLine        string                `json:"tagLine,omitempty"`
 	ProfileIconID  int64                 `json:"profileIconId,omitempty"`
 	AnalyzedGames  int                   `json:"analyzedGames"`
+	SoloGames      int                   `json:"soloGames"` // games with their own placement; placement stats are empty without any
 	LastUpdated    time.Time             `json:"lastUpdated"`
 	PlayStyle      PlayStyleProfile      `json:"playStyle"`
 	CompPreference CompPreferenceProfile `json:"compPreference"`
 	ItemPreference ItemPreferenceProfile `json:"itemPreference"`
 	Performance    PerformanceProfile    `json:"performance"`
+	Augments       AugmentProfile        `json:"augments"`
+	DoubleUp       DoubleUpProfile       `json:"doubleUp"`
+	Options        AnalysisOptions       `json:"options"`  // the window of games this profile was built from
+	MatchIDs       []string              `json:"matchIds"` // matches this profile was built from, oldest first
 }
 
 // PlayStyleProfile captures how a player typically plays
@@ -35,6 +47,7 @@ type PlayStyleProfile struct {
 type CompPreferenceProfile struct {
 	FavoriteTraits  []TraitFrequency `json:"favoriteTraits"`
 	FavoriteUnits   []UnitFrequency  `json:"favoriteUnits"`
+	FavoriteComps   []CompFrequency  `json:"favoriteComps"`   // comps by share of games, see CompSignature
 	CompFlexibility float64          `json:"compFlexibility"` // 0-1, how often they pivot
 	TraitDiversity  float64          `json:"traitDiversity"`  // 0-1, variety of traits played
 	MetaFollower    float64          `json:"metaFollower"`    // 0-1, how closely they follow meta
@@ -52,12 +65,18 @@ type ItemPreferenceProfile struct {
 
 // PerformanceProfile tracks performance metrics
 type PerformanceProfile struct {
-	RecentForm        []int   `json:"recentForm"`        // last 10 game placements
+	RecentForm        []int   `json:"recentForm"`        // last 10 game placements, oldest first
+	WeightedPlacement float64 `json:"weightedPlacement"` // time-weighted average placement, recent games count more
 	ConsistencyScore  float64 `json:"consistencyScore"`  // 0-1, placement consistency
 	ClimbingTrend     string  `json:"climbingTrend"`     // "climbing", "stable", "declining"
 	HighRollGames     int     `json:"highRollGames"`     // games with 1st/2nd place
 	LowRollGames      int     `json:"lowRollGames"`      // games with 7th/8th place
 	AverageGameLength float64 `json:"averageGameLength"` // seconds, indicates early vs late game
+	Sessions          int     `json:"sessions"`          // play sessions the analyzed games span
+	SessionTrend      float64 `json:"sessionTrend"`      // placements per game in the latest session; positive is worsening
+	BotFourStreak     int     `json:"botFourStreak"`     // consecutive 5th-8th finishes ending with the latest game
+	LongestBotStreak  int     `json:"longestBotStreak"`  // most consecutive 5th-8th finishes
+	TiltIndicator     float64 `json:"tiltIndicator"`     // 0-1, tilt in the latest session
 }
 
 // Supporting frequency types
@@ -92,6 +111,17 @@ type ProfileAnalyzer struct {
 	MaxGamesToAnalyze int // default 20
 	MinGamesRequired  int // default 5
 	Cache             *Cache
+	Archive           *MatchArchive   // optional, matches are read from and written to disk
+	Meta              *MetaSnapshot   // optional, enables meta-follower and contest-rate scoring
+	Model             *PlacementModel // optional, predicts lobby placements; defaults to NewPlacementModel()
+	Source            MatchSource     // optional, where match data comes from; defaults to LiveSource
+
+	// MaxLobbyAnalyses caps how many uncached players a lobby analysis profiles from
+	// scratch; the rest are left unanalyzed. Zero means no cap.
+	MaxLobbyAnalyses int
+	// LobbySpacing, when set, analyzes uncached lobby players one at a time with at least
+	// this long between them, to stay under the API rate limit
+	LobbySpacing time.Duration
 }
 
 // NewProfileAnalyzer creates a new analyzer with default settings
@@ -103,115 +133,232 @@ func NewProfileAnalyzer() *ProfileAnalyzer {
 	}
 }
 
-// AnalyzePlayer creates a comprehensive profile for a player
+// AnalyzePlayer creates a comprehensive profile for a player from their most recent games
 func (pa *ProfileAnalyzer) AnalyzePlayer(puuid string) (*PlayerProfile, error) {
+	return pa.AnalyzePlayerWithOptions(puuid, AnalysisOptions{})
+}
+
+// AnalyzePlayerWithOptions creates a profile from the games selected by opts
+func (pa *ProfileAnalyzer) AnalyzePlayerWithOptions(puuid string, opts AnalysisOptions) (*PlayerProfile, error) {
+	opts = pa.normalizeOptions(opts)
+	cacheKey := opts.cacheKey(puuid)
+
 	// Return cached profile if available
 	if pa.Cache != nil {
-		if cached, ok := pa.Cache.GetProfile(puuid); ok {
+		if cached, ok := pa.Cache.GetProfile(cacheKey); ok {
 			return cached, nil
 		}
 	}
 
-	// Try multiple regions to get match IDs (since we don't know player's region)
-	var matchIDs []string
-	var ok bool
-	if pa.Cache != nil {
-		if cachedIDs, hit := pa.Cache.GetMatchIDs(puuid); hit {
-			matchIDs = cachedIDs
-			ok = true
+	// Fold new games into the stored state when we have one, otherwise build it from scratch
+	var state *profileState
+	if prev, ok := pa.Cache.getProfileState(cacheKey); ok {
+		state = pa.refreshProfileState(puuid, prev, opts)
+	} else {
+		built, err := pa.buildProfileState(puuid, opts)
+		if err != nil {
+			return nil, err
 		}
+		state = built
 	}
-	if !ok {
-		// Try regions in order until we find match history
-		regions := []string{"NA1", "EUW1", "KR", "BR1", "LAS", "LAN", "EUNE", "OC1", "JP1", "TR1", "RU", "PH2", "SG2", "TH2", "TW2", "VN2"}
-		for _, region := range regions {
-			ids, err := GetTFTMatchIDsByPUUIDWithRegion(puuid, region, 0, pa.MaxGamesToAnalyze, nil, nil)
-			if err == nil && len(ids) > 0 {
-				matchIDs = ids
-				break
-			}
-		}
-		if len(matchIDs) == 0 {
-			return nil, fmt.Errorf("no match history found for %s in any region", puuid)
+	pa.Cache.setProfileState(cacheKey, state)
+
+	if len(state.games) < pa.MinGamesRequired {
+		return nil, fmt.Errorf("insufficient valid matches for analysis: %d", len(state.games))
+	}
+
+	profile := pa.profileFromGames(puuid, state.games, opts)
+
+	// Cache the computed profile
+	if pa.Cache != nil {
+		pa.Cache.SetProfile(cacheKey, profile)
+	}
+
+	return profile, nil
+}
+
+// CachedProfile returns the profile for the games selected by opts if it's cached, without fetching anything
+func (pa *ProfileAnalyzer) CachedProfile(puuid string, opts AnalysisOptions) (*PlayerProfile, bool) {
+	return pa.Cache.GetProfile(pa.normalizeOptions(opts).cacheKey(puuid))
+}
+
+// profileFromGames computes a profile summary from a player's games, oldest first
+func (pa *ProfileAnalyzer) profileFromGames(puuid string, games []playerGame, opts AnalysisOptions) *PlayerProfile {
+	profile := &PlayerProfile{
+		PUUID:         puuid,
+		AnalyzedGames: len(games),
+		LastUpdated:   time.Now(),
+		Options:       opts,
+		MatchIDs:      make([]string, len(games)),
+	}
+
+	// Riot IDs can change, so take them from the newest game that has one
+	for i := len(games) - 1; i >= 0; i-- {
+		if games[i].Participant.RiotIDGameName != "" {
+			profile.GameName = games[i].Participant.RiotIDGameName
+			profile.TagLine = games[i].Participant.RiotIDTagline
+			break
 		}
-		if pa.Cache != nil {
-			pa.Cache.SetMatchIDs(puuid, matchIDs)
+	}
+
+	// Double Up placements are shared by a pair, so placement-based
+	// analysis only uses solo games; boards and items use every game.
+	playerData := make([]ParticipantDto, len(games))
+	var soloGames []playerGame
+	var soloData []ParticipantDto
+	for i, game := range games {
+		playerData[i] = game.Participant
+		profile.MatchIDs[i] = game.MatchID
+		if !game.DoubleUp {
+			soloGames = append(soloGames, game)
+			soloData = append(soloData, game.Participant)
 		}
 	}
 
-	if len(matchIDs) < pa.MinGamesRequired {
-		return nil, fmt.Errorf("insufficient games for analysis: %d (minimum %d)", len(matchIDs), pa.MinGamesRequired)
+	// Analyze different aspects
+	profile.SoloGames = len(soloGames)
+	profile.PlayStyle = pa.analyzePlayStyle(soloData)
+	profile.CompPreference = pa.analyzeCompPreference(playerData)
+	profile.ItemPreference = pa.analyzeItemPreference(playerData)
+	profile.Performance = pa.analyzePerformance(soloGames)
+	profile.Augments = pa.analyzeAugments(soloData, pa.Meta)
+	profile.DoubleUp = pa.analyzeDoubleUp(games)
+	if pa.Meta != nil {
+		profile.CompPreference.MetaFollower = pa.scoreMetaFollower(playerData, pa.Meta)
+		profile.PlayStyle.ContestRate = pa.scoreContestRate(playerData, pa.Meta)
 	}
 
-	// Get detailed match data (use cache when possible)
-	var matches []*MatchDto
-	for _, matchID := range matchIDs {
-		var match *MatchDto
-		if pa.Cache != nil {
-			if m, hit := pa.Cache.GetMatch(matchID); hit {
-				match = m
-			}
+	return profile
+}
+
+// resolveAccount looks up a player's Riot ID by PUUID from the analyzer's source, using the cache when possible
+func (pa *ProfileAnalyzer) resolveAccount(puuid string) (*Account, error) {
+	if account, ok := pa.Cache.GetAccount(puuid); ok {
+		return account, nil
+	}
+	account, err := pa.dataSource().GetAccount(puuid)
+	if err != nil {
+		return nil, err
+	}
+	pa.Cache.SetAccount(account)
+	return account, nil
+}
+
+// dataSource returns where the analyzer reads match data from
+func (pa *ProfileAnalyzer) dataSource() MatchSource {
+	if pa.Source == nil {
+		return LiveSource{}
+	}
+	return pa.Source
+}
+
+// GetActiveGame returns the player's game in progress from the analyzer's source
+func (pa *ProfileAnalyzer) GetActiveGame(puuid, region string) (*CurrentGameInfo, error) {
+	return pa.dataSource().GetActiveGame(puuid, region)
+}
+
+// getMatch returns match data from the cache, the archive or the API, in that order
+func (pa *ProfileAnalyzer) getMatch(matchID string) (*MatchDto, error) {
+	if pa.Cache != nil {
+		if m, hit := pa.Cache.GetMatch(matchID); hit {
+			return m, nil
 		}
-		if match == nil {
-			m, err := GetTFTMatchByID(matchID)
-			if err != nil {
-				continue // skip failed matches
-			}
-			match = m
+	}
+	if pa.Archive != nil {
+		if m, err := pa.Archive.Get(matchID); err == nil {
 			if pa.Cache != nil {
-				pa.Cache.SetMatch(matchID, match)
+				pa.Cache.SetMatch(matchID, m)
 			}
+			return m, nil
 		}
-		matches = append(matches, match)
 	}
 
-	if len(matches) < pa.MinGamesRequired {
-		return nil, fmt.Errorf("insufficient valid matches for analysis: %d", len(matches))
+	match, err := pa.dataSource().GetMatch(matchID)
+	if err != nil {
+		return nil, err
 	}
-
-	// Create profile
-	profile := &PlayerProfile{
-		PUUID:         puuid,
-		AnalyzedGames: len(matches),
-		LastUpdated:   time.Now(),
+	if pa.Cache != nil {
+		pa.Cache.SetMatch(matchID, match)
 	}
+	if pa.Archive != nil {
+		if err := pa.Archive.Put(match); err != nil {
+			fmt.Printf("Error archiving match %s: %v\n", matchID, err)
+		}
+	}
+	return match, nil
+}
+
+// playerGame pairs a player's participant data with the timing of the match it came from
+type playerGame struct {
+	MatchID      string
+	Participant  ParticipantDto
+	GameDatetime int64    // epoch milliseconds
+	GameLength   float64  // seconds
+	DoubleUp     bool     // placement is shared with Partner
+	Partner      teammate // Double Up partner, zero if none
+}
+
+// teammate is what a profile needs of a Double Up partner's line. It is copied out of
+// the match so cached games don't keep every participant of the match alive.
+type teammate struct {
+	PUUID     string
+	Placement int
+	GameName  string
+	TagLine   string
+}
 
-	// Extract player-specific data from matches
-	var playerData []ParticipantDto
+// extractPlayerGames pulls the given player's line out of each match and returns
+// them in chronological order (oldest first). Riot returns match IDs newest-first,
+// so callers must not rely on the input order.
+func extractPlayerGames(puuid string, matches []*MatchDto) []playerGame {
+	var games []playerGame
 	for _, match := range matches {
+		if match == nil {
+			continue
+		}
 		for _, participant := range match.Info.Participants {
 			if participant.PUUID == puuid {
-				playerData = append(playerData, participant)
+				game := playerGame{
+					MatchID:      match.Metadata.MatchID,
+					Participant:  participant,
+					GameDatetime: match.Info.GameDatetime,
+					GameLength:   match.Info.GameLength,
+					DoubleUp:     IsDoubleUp(match),
+				}
+				if partner, ok := FindPartner(match, puuid); ok {
+					game.Partner = teammate{
+						PUUID:     partner.PUUID,
+						Placement: partner.Placement,
+						GameName:  partner.RiotIDGameName,
+						TagLine:   partner.RiotIDTagline,
+					}
+				}
+				games = append(games, game)
 				break
 			}
 		}
 	}
 
-	// Analyze different aspects
-	profile.PlayStyle = pa.analyzePlayStyle(playerData)
-	profile.CompPreference = pa.analyzeCompPreference(playerData)
-	profile.ItemPreference = pa.analyzeItemPreference(playerData)
-	profile.Performance = pa.analyzePerformance(playerData)
-
-	// Cache the computed profile
-	if pa.Cache != nil {
-		pa.Cache.SetProfile(puuid, profile)
-	}
-
-	return profile, nil
+	sort.SliceStable(games, func(i, j int) bool {
+		return games[i].GameDatetime < games[j].GameDatetime
+	})
+	return games
 }
 
 // AnalyzeLobby creates profiles for all players in an active game
 type LobbyProfile struct {
-	GameID          int64            `json:"gameId"`
-	Profiles        []*PlayerProfile `json:"profiles"`
-	ContestedTraits []TraitFrequency `json:"contestedTraits"`
-	AvgPlacement    float64          `json:"avgPlacement"`
-	TopFourRate     float64          `json:"topFourRate"`
+	GameID          int64                 `json:"gameId"`
+	Profiles        []*PlayerProfile      `json:"profiles"`
+	ContestedTraits []TraitFrequency      `json:"contestedTraits"`
+	AvgPlacement    float64               `json:"avgPlacement"`
+	TopFourRate     float64               `json:"topFourRate"`
+	Teams           []LobbyTeam           `json:"teams,omitempty"` // Double Up teams, if a Double Up lobby
+	Predictions     []PlacementPrediction `json:"predictions"`     // expected placements, in participant order
 }
 
 // AnalyzeLobbyAggregated profiles all players in the active game in parallel
-// and returns aggregated lobby insights alongside individual profiles.
+// and returns aggregated lobby insights alongside individual profiles. Players
+// whose analysis fails show as unknown, unless it failed on the rate limit.
 func (pa *ProfileAnalyzer) AnalyzeLobbyAggregated(gameInfo *CurrentGameInfo) (*LobbyProfile, error) {
 	n := len(gameInfo.Participants)
 	if n == 0 {
@@ -221,24 +368,52 @@ func (pa *ProfileAnalyzer) AnalyzeLobbyAggregated(gameInfo *CurrentGameInfo) (*L
 		}, nil
 	}
 
-	// Limit concurrency to avoid rate limits; choose the smaller of n and 4
+	// Limit concurrency to avoid rate limits; choose the smaller of n and 4, or
+	// one at a time when fresh analyses are spaced
 	maxConcurrent := 4
+	if pa.LobbySpacing > 0 {
+		maxConcurrent = 1
+	}
 	if n < maxConcurrent {
 		maxConcurrent = n
 	}
 	sem := make(chan struct{}, maxConcurrent)
 	results := make(chan *PlayerProfile, n)
 
+	var mu sync.Mutex
+	var rateLimited error
+	fresh := 0
+
 	// Launch analysis goroutines
 	for _, participant := range gameInfo.Participants {
 		puuid := participant.PUUID
 		icon := participant.ProfileIconID
 
 		sem <- struct{}{}
-		go func(puuid string, icon int64) {
+
+		// Cached profiles are free; uncached players past the cap go unanalyzed
+		_, cached := pa.CachedProfile(puuid, AnalysisOptions{})
+		analyze := cached || pa.MaxLobbyAnalyses == 0 || fresh < pa.MaxLobbyAnalyses
+		if analyze && !cached {
+			if fresh > 0 && pa.LobbySpacing > 0 {
+				time.Sleep(pa.LobbySpacing)
+			}
+			fresh++
+		}
+
+		go func(puuid string, icon int64, analyze bool) {
 			defer func() { <-sem }()
-			profile, err := pa.AnalyzePlayer(puuid)
-			if err != nil {
+			var profile *PlayerProfile
+			if analyze {
+				var err error
+				profile, err = pa.AnalyzePlayer(puuid)
+				if err != nil && strings.Contains(err.Error(), "status 429") {
+					mu.Lock()
+					rateLimited = err
+					mu.Unlock()
+				}
+			}
+			if profile == nil {
 				// Fallback to minimal profile on error
 				profile = &PlayerProfile{
 					PUUID:         puuid,
@@ -247,8 +422,17 @@ func (pa *ProfileAnalyzer) AnalyzeLobbyAggregated(gameInfo *CurrentGameInfo) (*L
 					LastUpdated:   time.Now(),
 				}
 			}
+			if profile.GameName == "" {
+				if account, err := pa.resolveAccount(puuid); err == nil {
+					// Copy so a cached profile isn't mutated
+					named := *profile
+					named.GameName = account.GameName
+					named.TagLine = account.TagLine
+					profile = &named
+				}
+			}
 			results <- profile
-		}(puuid, icon)
+		}(puuid, icon, analyze)
 	}
 
 	// Collect results
@@ -257,6 +441,11 @@ func (pa *ProfileAnalyzer) AnalyzeLobbyAggregated(gameInfo *CurrentGameInfo) (*L
 		profiles = append(profiles, <-results)
 	}
 
+	// A rate limited lobby would be reported as mostly unknown players, so fail instead
+	if rateLimited != nil {
+		return nil, fmt.Errorf("error analyzing lobby: %w", rateLimited)
+	}
+
 	// Aggregate lobby-level insights
 	var sumAvgPlacement float64
 	var sumTopFourRate float64
@@ -265,7 +454,7 @@ func (pa *ProfileAnalyzer) AnalyzeLobbyAggregated(gameInfo *CurrentGameInfo) (*L
 	traitCounts := make(map[string]int)
 
 	for _, p := range profiles {
-		if p.AnalyzedGames > 0 {
+		if p.SoloGames > 0 {
 			sumAvgPlacement += p.PlayStyle.AveragePlacement
 			sumTopFourRate += p.PlayStyle.TopFourRate
 			playerCount++
@@ -299,13 +488,24 @@ func (pa *ProfileAnalyzer) AnalyzeLobbyAggregated(gameInfo *CurrentGameInfo) (*L
 		return contested[i].Frequency > contested[j].Frequency
 	})
 
-	return &LobbyProfile{
+	lobby := &LobbyProfile{
 		GameID:          gameInfo.GameID,
 		Profiles:        profiles,
 		ContestedTraits: contested,
 		AvgPlacement:    avgPlacement,
 		TopFourRate:     topFourRate,
-	}, nil
+	}
+	if gameInfo.GameQueueConfigID == DoubleUpQueueID {
+		lobby.Teams = groupLobbyTeams(gameInfo, profiles)
+	}
+
+	model := pa.Model
+	if model == nil {
+		model = NewPlacementModel()
+	}
+	lobby.Predictions = model.PredictLobby(gameInfo, profiles)
+
+	return lobby, nil
 }
 
 // AnalyzeLobby preserves the original signature but now performs parallel analysis
@@ -348,8 +548,15 @@ func (pa *ProfileAnalyzer) analyzePlayStyle(playerData []ParticipantDto) PlaySty
 func (pa *ProfileAnalyzer) analyzeCompPreference(playerData []ParticipantDto) CompPreferenceProfile {
 	traitMap := make(map[string]int)
 	unitMap := make(map[string]int)
+	compMap := make(map[string]int)
+	compPlacements := make(map[string]int)
 
 	for _, game := range playerData {
+		if comp := CompSignature(game); comp != "" {
+			compMap[comp]++
+			compPlacements[comp] += game.Placement
+		}
+
 		// Count trait usage
 		for _, trait := range game.Traits {
 			if trait.TierCurrent > 0 {
@@ -382,6 +589,15 @@ func (pa *ProfileAnalyzer) analyzeCompPreference(playerData []ParticipantDto) Co
 		})
 	}
 
+	var favoriteComps []CompFrequency
+	for comp, count := range compMap {
+		favoriteComps = append(favoriteComps, CompFrequency{
+			Comp:         comp,
+			Frequency:    float64(count) / float64(len(playerData)),
+			AvgPlacement: float64(compPlacements[comp]) / float64(count),
+		})
+	}
+
 	// Sort by frequency
 	sort.Slice(favoriteTraits, func(i, j int) bool {
 		return favoriteTraits[i].Frequency > favoriteTraits[j].Frequency
@@ -389,10 +605,17 @@ func (pa *ProfileAnalyzer) analyzeCompPreference(playerData []ParticipantDto) Co
 	sort.Slice(favoriteUnits, func(i, j int) bool {
 		return favoriteUnits[i].Frequency > favoriteUnits[j].Frequency
 	})
+	sort.Slice(favoriteComps, func(i, j int) bool {
+		if favoriteComps[i].Frequency != favoriteComps[j].Frequency {
+			return favoriteComps[i].Frequency > favoriteComps[j].Frequency
+		}
+		return favoriteComps[i].Comp < favoriteComps[j].Comp
+	})
 
 	return CompPreferenceProfile{
 		FavoriteTraits: favoriteTraits,
 		FavoriteUnits:  favoriteUnits,
+		FavoriteComps:  favoriteComps,
 	}
 }
 
@@ -431,41 +654,90 @@ func (pa *ProfileAnalyzer) analyzeItemPreference(playerData []ParticipantDto) It
 	}
 }
 
-func (pa *ProfileAnalyzer) analyzePerformance(playerData []ParticipantDto) PerformanceProfile {
+// analyzePerformance expects games in chronological order (oldest first), as
+// returned by extractPlayerGames.
+func (pa *ProfileAnalyzer) analyzePerformance(games []playerGame) PerformanceProfile {
+	if len(games) == 0 {
+		return PerformanceProfile{}
+	}
+
 	var recentForm []int
 	highRolls := 0
 	lowRolls := 0
 	totalGameTime := 0.0
 
-	for _, game := range playerData {
-		recentForm = append(recentForm, game.Placement)
-		if game.Placement <= 2 {
+	for _, game := range games {
+		placement := game.Participant.Placement
+		recentForm = append(recentForm, placement)
+		if placement <= 2 {
 			highRolls++
 		}
-		if game.Placement >= 7 {
+		if placement >= 7 {
 			lowRolls++
 		}
-		totalGameTime += game.TimeEliminated
+		totalGameTime += game.Participant.TimeEliminated
 	}
 
-	// Keep only last 10 games for recent form
+	// Keep only the 10 most recent games for recent form
 	if len(recentForm) > 10 {
 		recentForm = recentForm[len(recentForm)-10:]
 	}
 
-	avgGameLength := totalGameTime / float64(len(playerData))
+	avgGameLength := totalGameTime / float64(len(games))
 	consistencyScore := pa.calculateConsistencyScore(recentForm)
 
+	placements := make([]int, len(games))
+	for i, game := range games {
+		placements[i] = game.Participant.Placement
+	}
+	sessions := segmentSessions(games, sessionGap)
+	latest := sessions[len(sessions)-1]
+
 	return PerformanceProfile{
 		RecentForm:        recentForm,
+		WeightedPlacement: pa.calculateWeightedPlacement(games, recentFormHalfLife),
 		ConsistencyScore:  consistencyScore,
 		HighRollGames:     highRolls,
 		LowRollGames:      lowRolls,
 		AverageGameLength: avgGameLength,
 		ClimbingTrend:     pa.determineClimbingTrend(recentForm),
+		Sessions:          len(sessions),
+		SessionTrend:      latest.PlacementTrend,
+		BotFourStreak:     currentBotFourStreak(placements),
+		LongestBotStreak:  longestBotFourStreak(placements),
+		TiltIndicator:     latest.TiltIndicator,
 	}
 }
 
+// calculateWeightedPlacement returns an exponentially time-weighted average placement.
+// Each game's weight halves for every halfLife it is older than the most recent game.
+func (pa *ProfileAnalyzer) calculateWeightedPlacement(games []playerGame, halfLife time.Duration) float64 {
+	if len(games) == 0 {
+		return 0.0
+	}
+
+	newest := games[0].GameDatetime
+	for _, game := range games {
+		if game.GameDatetime > newest {
+			newest = game.GameDatetime
+		}
+	}
+
+	totalWeight := 0.0
+	weighted := 0.0
+	for _, game := range games {
+		weight := 1.0
+		if halfLife > 0 {
+			age := time.Duration(newest-game.GameDatetime) * time.Millisecond
+			weight = math.Pow(0.5, age.Hours()/halfLife.Hours())
+		}
+		weighted += weight * float64(game.Participant.Placement)
+		totalWeight += weight
+	}
+
+	return weighted / totalWeight
+}
+
 // Helper methods for analysis
 func (pa *ProfileAnalyzer) determineEconomyStyle(playerData []ParticipantDto) string {
 	if len(playerData) == 0 {
@@ -545,6 +817,7 @@ func (pa *ProfileAnalyzer) calculateConsistencyScore(placements []int) float64 {
 	return 1.0 / (1.0 + stdDev/4.0) // normalize roughly
 }
 
+// determineClimbingTrend expects recentForm in chronological order (oldest first)
 func (pa *ProfileAnalyzer) determineClimbingTrend(recentForm []int) string {
 	if len(recentForm) < 5 {
 		return "unknown"
@@ -576,23 +849,6 @@ func (pa *ProfileAnalyzer) determineClimbingTrend(recentForm []int) string {
 	return "stable"
 }
 
-// LoadSampleActiveGame loads the sample active game data for testing
-func LoadSampleActiveGame() (*CurrentGameInfo, error) {
-	// This would load from samples/active_game_sample.json
-	// For now, we'll return nil to indicate this should be implemented
-	// when we need to test without a live game
-	return nil, fmt.Errorf("sample loading not yet implemented - use live game data")
-}
-
-// AnalyzeLobbyFromSample analyzes a lobby using sample data for testing
-func (pa *ProfileAnalyzer) AnalyzeLobbyFromSample() ([]*PlayerProfile, error) {
-	sampleGame, err := LoadSampleActiveGame()
-	if err != nil {
-		return nil, fmt.Errorf("failed to load sample game: %w", err)
-	}
-	return pa.AnalyzeLobby(sampleGame)
-}
-
 // GetSpectatorInfo returns information about spectating a game
 // The encryption key from CurrentGameInfo.Observers.EncryptionKey is used to:
 // - Decrypt live spectator data streams from Riot's servers
@@ -608,10 +864,3 @@ func GetSpectatorInfo(gameInfo *CurrentGameInfo) map[string]interface{} {
 		"spectatorUrl":     fmt.Sprintf("spectator %s %s %d %s", gameInfo.PlatformID, gameInfo.Observers.EncryptionKey, gameInfo.GameID, gameInfo.PlatformID),
 	}
 }
-
-// LoadSampleActiveGameFromFile loads sample active game data from JSON file
-func LoadSampleActiveGameFromFile(filename string) (*CurrentGameInfo, error) {
-	// This would read and unmarshal the JSON file
-	// Implementation would go here when needed for testing
-	return nil, fmt.Errorf("sample file loading not yet implemented")
-}
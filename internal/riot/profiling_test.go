@@ -1,10 +1,9 @@
 package riot
 
 import (
-	"os"
+	"math"
 	"testing"
-
-	"github.com/hunterjsb/tft/internal/dotenv"
+	"time"
 )
 
 func TestNewProfileAnalyzer(t *testing.T) {
@@ -17,22 +16,22 @@ func TestNewProfileAnalyzer(t *testing.T) {
 	}
 }
 
-func TestAnalyzePlayer_Success(t *testing.T) {
-	// Try to load environment variables from .env file (ignore errors for CI/CD)
-	_ = dotenv.LoadDefault()
-	_ = dotenv.Load("../../.env")
-
-	if os.Getenv("RIOT_API_KEY") == "" {
-		t.Skip("RIOT_API_KEY not set")
+// sampleAnalyzer returns an analyzer over the sample fixtures and a sample player's account
+func sampleAnalyzer(t *testing.T) (*ProfileAnalyzer, *Account) {
+	t.Helper()
+	analyzer, err := NewSampleProfileAnalyzer()
+	if err != nil {
+		t.Fatalf("Failed to load sample analyzer: %v", err)
 	}
-
-	analyzer := NewProfileAnalyzer()
-
-	// Get a test account
-	account, err := GetAccountByRiotId("mubs", "NA1")
+	game, err := LoadSampleActiveGame()
 	if err != nil {
-		t.Fatalf("Failed to get test account: %v", err)
+		t.Fatalf("Failed to load sample game: %v", err)
 	}
+	return analyzer, &Account{PUUID: game.Participants[0].PUUID}
+}
+
+func TestAnalyzePlayer_Success(t *testing.T) {
+	analyzer, account := sampleAnalyzer(t)
 
 	profile, err := analyzer.AnalyzePlayer(account.PUUID)
 	if err != nil {
@@ -83,44 +82,69 @@ func TestAnalyzePlayer_Success(t *testing.T) {
 }
 
 func TestAnalyzePlayer_InsufficientGames(t *testing.T) {
-	// Try to load environment variables from .env file (ignore errors for CI/CD)
-	_ = dotenv.LoadDefault()
-	_ = dotenv.Load("../../.env")
-
-	if os.Getenv("RIOT_API_KEY") == "" {
-		t.Skip("RIOT_API_KEY not set")
-	}
-
-	analyzer := NewProfileAnalyzer()
+	analyzer, account := sampleAnalyzer(t)
 	analyzer.MinGamesRequired = 100 // Set unreasonably high requirement
 
-	account, err := GetAccountByRiotId("mubs", "NA1")
-	if err != nil {
-		t.Fatalf("Failed to get test account: %v", err)
-	}
-
-	_, err = analyzer.AnalyzePlayer(account.PUUID)
+	_, err := analyzer.AnalyzePlayer(account.PUUID)
 	if err == nil {
 		t.Error("Expected error for insufficient games")
 	}
 }
 
 func TestAnalyzePlayer_InvalidPUUID(t *testing.T) {
-	// Try to load environment variables from .env file (ignore errors for CI/CD)
-	_ = dotenv.LoadDefault()
-	_ = dotenv.Load("../../.env")
-
-	if os.Getenv("RIOT_API_KEY") == "" {
-		t.Skip("RIOT_API_KEY not set")
-	}
-
-	analyzer := NewProfileAnalyzer()
+	analyzer, _ := sampleAnalyzer(t)
 	_, err := analyzer.AnalyzePlayer("invalid-puuid-12345")
 	if err == nil {
 		t.Error("Expected error for invalid PUUID")
 	}
 }
 
+func TestCachedProfile(t *testing.T) {
+	analyzer, account := sampleAnalyzer(t)
+	opts := AnalysisOptions{Count: 10}
+
+	if _, ok := analyzer.CachedProfile(account.PUUID, opts); ok {
+		t.Fatal("Expected no cached profile before analyzing")
+	}
+	profile, err := analyzer.AnalyzePlayerWithOptions(account.PUUID, opts)
+	if err != nil {
+		t.Fatalf("Failed to analyze player: %v", err)
+	}
+	if cached, ok := analyzer.CachedProfile(account.PUUID, opts); !ok || cached != profile {
+		t.Error("Expected the analyzed profile to be cached")
+	}
+	if _, ok := analyzer.CachedProfile(account.PUUID, AnalysisOptions{Count: 20}); ok {
+		t.Error("Expected profiles over other games to be cached separately")
+	}
+}
+
+func TestAnalyzeLobbyAggregated_MaxLobbyAnalyses(t *testing.T) {
+	analyzer, account := sampleAnalyzer(t)
+	game, err := LoadSampleActiveGame()
+	if err != nil {
+		t.Fatalf("Failed to load sample game: %v", err)
+	}
+	if _, err := analyzer.AnalyzePlayer(account.PUUID); err != nil {
+		t.Fatalf("Failed to analyze player: %v", err)
+	}
+
+	// The cached player doesn't count toward the cap
+	analyzer.MaxLobbyAnalyses = 2
+	lobby, err := analyzer.AnalyzeLobbyAggregated(game)
+	if err != nil {
+		t.Fatalf("Failed to analyze lobby: %v", err)
+	}
+	analyzed := 0
+	for _, profile := range lobby.Profiles {
+		if profile.AnalyzedGames > 0 {
+			analyzed++
+		}
+	}
+	if len(lobby.Profiles) != len(game.Participants) || analyzed != 3 {
+		t.Errorf("Expected %d profiles with 3 analyzed, got %d with %d", len(game.Participants), len(lobby.Profiles), analyzed)
+	}
+}
+
 func TestCalculateConsistencyScore(t *testing.T) {
 	analyzer := NewProfileAnalyzer()
 
@@ -326,6 +350,99 @@ func TestAnalyzeItemPreference(t *testing.T) {
 	}
 }
 
+func TestExtractPlayerGames_ChronologicalOrder(t *testing.T) {
+	// Riot returns match IDs newest-first; build matches in that order
+	matches := []*MatchDto{
+		{Info: InfoDto{GameDatetime: 3000, Participants: []ParticipantDto{{PUUID: "test", Placement: 1}, {PUUID: "other", Placement: 2}}}},
+		{Info: InfoDto{GameDatetime: 2000, Participants: []ParticipantDto{{PUUID: "other", Placement: 1}, {PUUID: "test", Placement: 5}}}},
+		{Info: InfoDto{GameDatetime: 1000, Participants: []ParticipantDto{{PUUID: "test", Placement: 8}}}},
+		{Info: InfoDto{GameDatetime: 1500, Participants: []ParticipantDto{{PUUID: "other", Placement: 3}}}},
+	}
+
+	games := extractPlayerGames("test", matches)
+	if len(games) != 3 {
+		t.Fatalf("Expected 3 games for player, got %d", len(games))
+	}
+
+	expected := []int{8, 5, 1}
+	for i, game := range games {
+		if game.Participant.Placement != expected[i] {
+			t.Errorf("Game %d: expected placement %d, got %d", i, expected[i], game.Participant.Placement)
+		}
+		if i > 0 && games[i-1].GameDatetime > game.GameDatetime {
+			t.Errorf("Games not in chronological order at index %d", i)
+		}
+	}
+}
+
+func TestAnalyzePerformance_RecentFormKeepsNewestGames(t *testing.T) {
+	analyzer := NewProfileAnalyzer()
+
+	// 12 games, oldest placed 8th, getting steadily better
+	var games []playerGame
+	placements := []int{8, 8, 7, 7, 6, 6, 5, 4, 3, 2, 2, 1}
+	for i, placement := range placements {
+		games = append(games, playerGame{
+			Participant:  ParticipantDto{Placement: placement},
+			GameDatetime: int64(i+1) * int64(time.Hour/time.Millisecond),
+		})
+	}
+
+	perf := analyzer.analyzePerformance(games)
+
+	if len(perf.RecentForm) != 10 {
+		t.Fatalf("Expected 10 games of recent form, got %d", len(perf.RecentForm))
+	}
+	if perf.RecentForm[len(perf.RecentForm)-1] != 1 {
+		t.Errorf("Expected most recent game last in recent form, got %v", perf.RecentForm)
+	}
+	if perf.RecentForm[0] != 7 {
+		t.Errorf("Expected two oldest games to be dropped, got %v", perf.RecentForm)
+	}
+	if perf.ClimbingTrend != "climbing" {
+		t.Errorf("Expected 'climbing' trend for improving placements, got '%s'", perf.ClimbingTrend)
+	}
+}
+
+func TestCalculateWeightedPlacement(t *testing.T) {
+	analyzer := NewProfileAnalyzer()
+	day := int64(24 * time.Hour / time.Millisecond)
+
+	// A bad game long ago and a good game now: weighted average should favor the recent game
+	games := []playerGame{
+		{Participant: ParticipantDto{Placement: 8}, GameDatetime: 0},
+		{Participant: ParticipantDto{Placement: 1}, GameDatetime: 30 * day},
+	}
+	weighted := analyzer.calculateWeightedPlacement(games, recentFormHalfLife)
+	if weighted >= 1.5 {
+		t.Errorf("Expected weighted placement close to 1, got %.2f", weighted)
+	}
+
+	// One half-life apart: weights 0.5 and 1
+	games = []playerGame{
+		{Participant: ParticipantDto{Placement: 7}, GameDatetime: 0},
+		{Participant: ParticipantDto{Placement: 1}, GameDatetime: 3 * day},
+	}
+	weighted = analyzer.calculateWeightedPlacement(games, 72*time.Hour)
+	expected := (0.5*7 + 1.0*1) / 1.5
+	if math.Abs(weighted-expected) > 1e-9 {
+		t.Errorf("Expected weighted placement %.3f, got %.3f", expected, weighted)
+	}
+
+	// Same timestamps degrade to a plain average
+	games = []playerGame{
+		{Participant: ParticipantDto{Placement: 2}},
+		{Participant: ParticipantDto{Placement: 6}},
+	}
+	if weighted = analyzer.calculateWeightedPlacement(games, recentFormHalfLife); weighted != 4.0 {
+		t.Errorf("Expected plain average 4.00 for equal timestamps, got %.2f", weighted)
+	}
+
+	if weighted = analyzer.calculateWeightedPlacement(nil, recentFormHalfLife); weighted != 0.0 {
+		t.Errorf("Expected 0 for no games, got %.2f", weighted)
+	}
+}
+
 // Helper function for logging
 func getTopThreeTraits(traits []TraitFrequency) []string {
 	var topThree []string
@@ -339,26 +456,47 @@ func getTopThreeTraits(traits []TraitFrequency) []string {
 }
 
 func BenchmarkAnalyzePlayer(b *testing.B) {
-	// Try to load environment variables from .env file (ignore errors for CI/CD)
-	_ = dotenv.LoadDefault()
-	_ = dotenv.Load("../../.env")
-
-	if os.Getenv("RIOT_API_KEY") == "" {
-		b.Skip("RIOT_API_KEY not set")
-	}
-
-	account, err := GetAccountByRiotId("mubs", "NA1")
-	if err != nil {
-		b.Fatalf("Failed to get test account: %v", err)
-	}
-
+	newFakeAPI(b)
 	analyzer := NewProfileAnalyzer()
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, err := analyzer.AnalyzePlayer(account.PUUID)
+		analyzer.Cache = NewDefaultCache()
+		_, err := analyzer.AnalyzePlayer(testPUUID)
 		if err != nil {
 			b.Fatalf("Benchmark failed: %v", err)
 		}
 	}
 }
+
+func TestProfileFromGames_RiotIDFromNewestGame(t *testing.T) {
+	analyzer := NewProfileAnalyzer()
+	matches := []*MatchDto{stateTestMatch(2, 3, 1100), stateTestMatch(1, 4, 1100), stateTestMatch(3, 5, 1100)}
+	matches[0].Info.Participants[0].RiotIDGameName = "NewName"
+	matches[0].Info.Participants[0].RiotIDTagline = "NA1"
+	matches[1].Info.Participants[0].RiotIDGameName = "OldName"
+
+	profile := analyzer.profileFromGames("test", extractPlayerGames("test", matches), AnalysisOptions{})
+	if profile.GameName != "NewName" || profile.TagLine != "NA1" {
+		t.Errorf("Expected NewName#NA1 from the newest named game, got %s#%s", profile.GameName, profile.TagLine)
+	}
+}
+
+func TestCache_Account(t *testing.T) {
+	cache := NewDefaultCache()
+	cache.SetAccount(&Account{PUUID: "test", GameName: "Player", TagLine: "NA1"})
+
+	account, ok := cache.GetAccount("test")
+	if !ok || account.GameName != "Player" {
+		t.Fatalf("Expected cached account, got %+v", account)
+	}
+	if _, ok := cache.GetAccount("missing"); ok {
+		t.Error("Expected miss for unknown PUUID")
+	}
+
+	var nilCache *Cache
+	nilCache.SetAccount(account)
+	if _, ok := nilCache.GetAccount("test"); ok {
+		t.Error("Expected nil cache to miss")
+	}
+}
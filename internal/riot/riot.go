@@ -6,6 +6,8 @@ import (
 	"io"
 	"net/http"
 	"os"
+	"strings"
+	"sync/atomic"
 	"time"
 )
 
@@ -13,12 +15,46 @@ func GetAPIKey() string {
 	return os.Getenv("RIOT_API_KEY")
 }
 
+// httpClient sends every API request
+var httpClient atomic.Pointer[http.Client]
+
 func init() {
-	http.DefaultClient.Timeout = time.Second * 10
+	httpClient.Store(&http.Client{Timeout: time.Second * 10})
+}
+
+// SetTransport sends API requests through transport, e.g. a riottest cassette
+// recorder, until the returned function is called
+func SetTransport(transport http.RoundTripper) (restore func()) {
+	previous := httpClient.Load()
+	httpClient.Store(&http.Client{Timeout: previous.Timeout, Transport: transport})
+	return func() {
+		httpClient.Store(previous)
+	}
+}
+
+// baseURLOverride, when set, redirects every API request to a single server
+var baseURLOverride atomic.Value
+
+// SetBaseURL sends all API requests to baseURL, e.g. a riottest fake server, until the
+// returned function is called. The routing host's first label ("na1", "americas") is kept
+// as the first path segment so the server can still tell platforms apart.
+func SetBaseURL(baseURL string) (restore func()) {
+	previous, _ := baseURLOverride.Load().(string)
+	baseURLOverride.Store(strings.TrimSuffix(baseURL, "/"))
+	return func() {
+		baseURLOverride.Store(previous)
+	}
 }
 
 // buildURL constructs a Riot API URL with the given base URL and endpoint
 func buildURL(baseURL, endpoint string) string {
+	if override, _ := baseURLOverride.Load().(string); override != "" {
+		host := strings.TrimPrefix(baseURL, "https://")
+		if dot := strings.Index(host, "."); dot >= 0 {
+			host = host[:dot]
+		}
+		baseURL = override + "/" + host
+	}
 	return fmt.Sprintf("%s%s?api_key=%s", baseURL, endpoint, GetAPIKey())
 }
 
@@ -46,7 +82,7 @@ func buildRegionalRoutingURL(region, endpoint string) string {
 
 // makeAPIRequest is a generic function that handles HTTP boilerplate for Riot API requests
 func makeAPIRequest(url string, result interface{}) error {
-	resp, err := http.Get(url)
+	resp, err := httpClient.Load().Get(url)
 	if err != nil {
 		return err
 	}
@@ -77,3 +113,15 @@ func GetAccountByRiotId(gameName, tagLine string) (*Account, error) {
 
 	return &account, nil
 }
+
+func GetAccountByPUUID(puuid string) (*Account, error) {
+	endpoint := fmt.Sprintf("/riot/account/v1/accounts/by-puuid/%s", puuid)
+	url := buildAmericasURL(endpoint)
+
+	var account Account
+	if err := makeAPIRequest(url, &account); err != nil {
+		return nil, err
+	}
+
+	return &account, nil
+}
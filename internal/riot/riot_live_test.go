@@ -0,0 +1,156 @@
+package riot
+
+import (
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/hunterjsb/tft/internal/riot/riottest"
+)
+
+// Live tests talk to the real Riot API through a cassette named after the test.
+// By default they replay testdata/cassettes and fail when nothing is recorded;
+// RIOT_CASSETTE_MODE=record re-records them and passthrough skips the cassette,
+// both of which need RIOT_API_KEY.
+
+// liveKey routes the client through tb's cassette
+func liveKey(tb testing.TB) {
+	tb.Helper()
+
+	mode, err := riottest.ModeFromEnv()
+	if err != nil {
+		tb.Fatal(err)
+	}
+
+	path := filepath.Join("testdata", "cassettes", strings.ReplaceAll(tb.Name(), "/", "_")+".json")
+	if mode == riottest.ModeReplay {
+		if _, err := os.Stat(path); errors.Is(err, os.ErrNotExist) {
+			tb.Fatalf("No cassette at %s; record it with %s=record", path, riottest.CassetteModeEnv)
+		}
+	} else if os.Getenv("RIOT_API_KEY") == "" {
+		tb.Skip("RIOT_API_KEY not set")
+	}
+
+	recorder, err := riottest.NewRecorder(path, mode)
+	if err != nil {
+		tb.Fatalf("Failed to open cassette: %v", err)
+	}
+	restore := SetTransport(recorder)
+	tb.Cleanup(func() {
+		restore()
+		if err := recorder.Save(); err != nil {
+			tb.Errorf("Failed to save cassette: %v", err)
+		}
+	})
+}
+
+func TestLive_GetAccountByRiotId(t *testing.T) {
+	liveKey(t)
+
+	account, err := GetAccountByRiotId("mubs", "NA1")
+	if err != nil {
+		t.Fatalf("Failed to get account: %v", err)
+	}
+	if account.PUUID == "" {
+		t.Error("Account PUUID should not be empty")
+	}
+	if account.GameName != "mubs" || account.TagLine != "NA1" {
+		t.Errorf("Expected mubs#NA1, got %s#%s", account.GameName, account.TagLine)
+	}
+
+	if _, err := GetAccountByRiotId("ThisPlayerDoesNotExist123456", "NA1"); err == nil {
+		t.Error("Expected error for non-existent account")
+	}
+}
+
+func TestLive_GetSummonerByRiotId(t *testing.T) {
+	liveKey(t)
+
+	summoner, account, err := GetSummonerByRiotId("mubs", "NA1")
+	if err != nil {
+		t.Fatalf("Failed to get summoner by Riot ID: %v", err)
+	}
+	if summoner.PUUID != account.PUUID {
+		t.Errorf("Summoner PUUID should match account PUUID")
+	}
+	if summoner.SummonerLevel <= 0 {
+		t.Error("Summoner level should be positive")
+	}
+
+	if _, err := GetSummonerByPUUID("invalid-puuid-12345"); err == nil {
+		t.Error("Expected error for invalid PUUID")
+	}
+}
+
+func TestLive_GetTFTMatches(t *testing.T) {
+	liveKey(t)
+
+	account, err := GetAccountByRiotId("mubs", "NA1")
+	if err != nil {
+		t.Fatalf("Failed to get account for test: %v", err)
+	}
+
+	matchIDs, err := GetTFTMatchIDsByPUUID(account.PUUID, 0, 5, nil, nil)
+	if err != nil {
+		t.Fatalf("Failed to get match IDs: %v", err)
+	}
+	if len(matchIDs) > 5 {
+		t.Errorf("Should return at most 5 matches, got %d", len(matchIDs))
+	}
+	if len(matchIDs) == 0 {
+		t.Skip("No TFT matches found for test account")
+	}
+
+	match, err := GetTFTMatchByID(matchIDs[0])
+	if err != nil {
+		t.Fatalf("Failed to get TFT match: %v", err)
+	}
+	if match.Metadata.MatchID != matchIDs[0] {
+		t.Errorf("Expected match ID %s, got %s", matchIDs[0], match.Metadata.MatchID)
+	}
+	if match.Info.GameLength <= 0 || match.Info.TftSetNumber <= 0 {
+		t.Error("Game length and set number should be positive")
+	}
+}
+
+func TestLive_GetActiveTFTGameByPUUID(t *testing.T) {
+	liveKey(t)
+
+	account, err := GetAccountByRiotId("mubs", "NA1")
+	if err != nil {
+		t.Fatalf("Failed to get account for test: %v", err)
+	}
+
+	info, err := GetActiveTFTGameByPUUIDWithRegion(account.PUUID, "NA1")
+	if err != nil {
+		// 404 indicates the player is not currently in an active game; treat as non-fatal/skip
+		if strings.Contains(err.Error(), "status 404") {
+			t.Skip("Player is not currently in an active TFT game (404)")
+		}
+		t.Fatalf("Failed to get active TFT game: %v", err)
+	}
+	if info.GameID == 0 || len(info.Participants) == 0 {
+		t.Error("Expected a game with participants")
+	}
+}
+
+func BenchmarkLive_AnalyzePlayer(b *testing.B) {
+	liveKey(b)
+
+	account, err := GetAccountByRiotId("mubs", "NA1")
+	if err != nil {
+		b.Fatalf("Failed to get test account: %v", err)
+	}
+
+	analyzer := NewProfileAnalyzer()
+
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		_, err := analyzer.AnalyzePlayer(account.PUUID)
+		if err != nil {
+			b.Fatalf("Benchmark failed: %v", err)
+		}
+	}
+}
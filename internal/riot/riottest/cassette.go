@@ -0,0 +1,226 @@
+package riottest
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+)
+
+// CassetteModeEnv selects the Mode returned by ModeFromEnv
+const CassetteModeEnv = "RIOT_CASSETTE_MODE"
+
+// redacted replaces API keys in recorded URLs and bodies
+const redacted = "REDACTED"
+
+// Mode controls whether a Recorder replays, records or bypasses its cassette
+type Mode string
+
+const (
+	// ModeReplay serves responses from the cassette and never touches the network
+	ModeReplay Mode = "replay"
+	// ModeRecord sends requests to the real API and saves the responses
+	ModeRecord Mode = "record"
+	// ModePassthrough sends requests to the real API without a cassette
+	ModePassthrough Mode = "passthrough"
+)
+
+// ModeFromEnv reads the cassette mode from RIOT_CASSETTE_MODE, defaulting to replay
+func ModeFromEnv() (Mode, error) {
+	switch mode := Mode(strings.ToLower(strings.TrimSpace(os.Getenv(CassetteModeEnv)))); mode {
+	case "":
+		return ModeReplay, nil
+	case ModeReplay, ModeRecord, ModePassthrough:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("%s must be replay, record or passthrough, got %q", CassetteModeEnv, mode)
+	}
+}
+
+// Interaction is one recorded request and its response
+type Interaction struct {
+	Method      string          `json:"method"`
+	URL         string          `json:"url"`
+	Status      int             `json:"status"`
+	ContentType string          `json:"content_type,omitempty"`
+	Body        json.RawMessage `json:"body,omitempty"` // JSON bodies, stored inline
+	Text        string          `json:"text,omitempty"` // any other body
+}
+
+// Cassette is the on-disk list of interactions
+type Cassette struct {
+	Interactions []Interaction `json:"interactions"`
+}
+
+// Recorder is an http.RoundTripper that records responses to a cassette file
+// or replays them from it, depending on its Mode
+type Recorder struct {
+	Mode Mode
+
+	path string
+	next http.RoundTripper
+
+	mu       sync.Mutex
+	cassette Cassette
+	played   map[string]int // request key -> interactions replayed so far
+}
+
+// NewRecorder creates a recorder for the cassette at path. Replay mode loads the
+// cassette and fails if it does not exist; record mode starts a new one.
+func NewRecorder(path string, mode Mode) (*Recorder, error) {
+	r := &Recorder{
+		Mode:   mode,
+		path:   path,
+		next:   http.DefaultTransport,
+		played: make(map[string]int),
+	}
+
+	switch mode {
+	case ModeReplay:
+		data, err := os.ReadFile(path)
+		if err != nil {
+			return nil, err
+		}
+		if err := json.Unmarshal(data, &r.cassette); err != nil {
+			return nil, fmt.Errorf("%s: %w", path, err)
+		}
+	case ModeRecord, ModePassthrough:
+	default:
+		return nil, fmt.Errorf("unknown cassette mode %q", mode)
+	}
+	return r, nil
+}
+
+// RoundTrip implements http.RoundTripper
+func (r *Recorder) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch r.Mode {
+	case ModeReplay:
+		return r.replay(req)
+	case ModeRecord:
+		return r.record(req)
+	default:
+		return r.next.RoundTrip(req)
+	}
+}
+
+// replay serves the next recorded interaction for the request, repeating the
+// last one once they run out
+func (r *Recorder) replay(req *http.Request) (*http.Response, error) {
+	key := req.Method + " " + scrubURL(req.URL)
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	var matches []Interaction
+	for _, interaction := range r.cassette.Interactions {
+		if interaction.Method+" "+interaction.URL == key {
+			matches = append(matches, interaction)
+		}
+	}
+	if len(matches) == 0 {
+		return nil, fmt.Errorf("cassette %s has no recorded response for %s", r.path, key)
+	}
+
+	idx := r.played[key]
+	if idx >= len(matches) {
+		idx = len(matches) - 1
+	}
+	r.played[key]++
+	return interactionResponse(req, matches[idx]), nil
+}
+
+// record sends the request to the real API and keeps a scrubbed copy of the response
+func (r *Recorder) record(req *http.Request) (*http.Response, error) {
+	resp, err := r.next.RoundTrip(req)
+	if err != nil {
+		return nil, err
+	}
+	body, err := io.ReadAll(resp.Body)
+	_ = resp.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+
+	interaction := Interaction{
+		Method:      req.Method,
+		URL:         scrubURL(req.URL),
+		Status:      resp.StatusCode,
+		ContentType: resp.Header.Get("Content-Type"),
+	}
+	if apiKey := req.URL.Query().Get("api_key"); apiKey != "" {
+		body = bytes.ReplaceAll(body, []byte(apiKey), []byte(redacted))
+	}
+	if json.Valid(body) {
+		interaction.Body = body
+	} else {
+		interaction.Text = string(body)
+	}
+
+	r.mu.Lock()
+	r.cassette.Interactions = append(r.cassette.Interactions, interaction)
+	r.mu.Unlock()
+
+	resp.Body = io.NopCloser(bytes.NewReader(body))
+	return resp, nil
+}
+
+// Save writes recorded interactions to the cassette file; it does nothing outside record mode
+func (r *Recorder) Save() error {
+	if r.Mode != ModeRecord {
+		return nil
+	}
+
+	r.mu.Lock()
+	data, err := json.MarshalIndent(r.cassette, "", "  ")
+	r.mu.Unlock()
+	if err != nil {
+		return err
+	}
+
+	if err := os.MkdirAll(filepath.Dir(r.path), 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(r.path, append(data, '\n'), 0o644)
+}
+
+// scrubURL returns u as a string with the api_key query value redacted
+func scrubURL(u *url.URL) string {
+	scrubbed := *u
+	query := scrubbed.Query()
+	if query.Has("api_key") {
+		query.Set("api_key", redacted)
+		scrubbed.RawQuery = query.Encode()
+	}
+	return scrubbed.String()
+}
+
+// interactionResponse rebuilds an http.Response from a recorded interaction
+func interactionResponse(req *http.Request, interaction Interaction) *http.Response {
+	// JSON bodies are indented on disk; serve them compact as the API does
+	var compact bytes.Buffer
+	body := []byte(interaction.Text)
+	if len(interaction.Body) > 0 && json.Compact(&compact, interaction.Body) == nil {
+		body = compact.Bytes()
+	}
+	header := make(http.Header)
+	if interaction.ContentType != "" {
+		header.Set("Content-Type", interaction.ContentType)
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", interaction.Status, http.StatusText(interaction.Status)),
+		StatusCode:    interaction.Status,
+		Proto:         "HTTP/1.1",
+		ProtoMajor:    1,
+		ProtoMinor:    1,
+		Header:        header,
+		Body:          io.NopCloser(bytes.NewReader(body)),
+		ContentLength: int64(len(body)),
+		Request:       req,
+	}
+}
@@ -0,0 +1,100 @@
+package riottest
+
+import (
+	"io"
+	"net/http"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+func TestRecorder_RecordAndReplay(t *testing.T) {
+	server := NewServer()
+	server.AddAccount("p1", "Player", "NA1")
+	url := server.URL + "/americas/riot/account/v1/accounts/by-puuid/p1?api_key=secret-key"
+	missing := server.URL + "/americas/riot/account/v1/accounts/by-puuid/p2?api_key=secret-key"
+
+	path := filepath.Join(t.TempDir(), "cassettes", "account.json")
+	recorder, err := NewRecorder(path, ModeRecord)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	client := &http.Client{Transport: recorder}
+	recorded := fetch(t, client, url)
+	if code := fetchStatus(t, client, missing); code != http.StatusNotFound {
+		t.Fatalf("Expected 404 for unknown account, got %d", code)
+	}
+	if err := recorder.Save(); err != nil {
+		t.Fatalf("Unexpected error saving: %v", err)
+	}
+	server.Close()
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if strings.Contains(string(data), "secret-key") {
+		t.Error("Expected the API key to be scrubbed from the cassette")
+	}
+
+	// Replay works with any key, and without the server
+	replayer, err := NewRecorder(path, ModeReplay)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	client = &http.Client{Transport: replayer}
+	if replayed := fetch(t, client, strings.Replace(url, "secret-key", "other-key", 1)); replayed != recorded {
+		t.Errorf("Expected replayed body %s, got %s", recorded, replayed)
+	}
+	if code := fetchStatus(t, client, missing); code != http.StatusNotFound {
+		t.Errorf("Expected replayed 404, got %d", code)
+	}
+	if _, err := client.Get(server.URL + "/americas/unrecorded?api_key=k"); err == nil {
+		t.Error("Expected error for an unrecorded request")
+	}
+
+	if _, err := NewRecorder(filepath.Join(t.TempDir(), "missing.json"), ModeReplay); err == nil {
+		t.Error("Expected error replaying a missing cassette")
+	}
+}
+
+func TestModeFromEnv(t *testing.T) {
+	for value, want := range map[string]Mode{"": ModeReplay, "record": ModeRecord, " Passthrough ": ModePassthrough} {
+		t.Setenv(CassetteModeEnv, value)
+		if mode, err := ModeFromEnv(); err != nil || mode != want {
+			t.Errorf("%q: expected %s, got %s (%v)", value, want, mode, err)
+		}
+	}
+
+	t.Setenv(CassetteModeEnv, "rewind")
+	if _, err := ModeFromEnv(); err == nil {
+		t.Error("Expected error for unknown mode")
+	}
+}
+
+func fetch(t *testing.T, client *http.Client, url string) string {
+	t.Helper()
+	resp, err := client.Get(url)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	body, err := io.ReadAll(resp.Body)
+	if err != nil || resp.StatusCode != http.StatusOK {
+		t.Fatalf("Unexpected response %d: %v", resp.StatusCode, err)
+	}
+	return string(body)
+}
+
+func fetchStatus(t *testing.T, client *http.Client, url string) int {
+	t.Helper()
+	resp, err := client.Get(url)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	_ = resp.Body.Close()
+	return resp.StatusCode
+}
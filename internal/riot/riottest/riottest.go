@@ -0,0 +1,571 @@
+// Package riottest provides a fake Riot API server for hermetic tests.
+//
+// The server speaks the account, summoner, TFT match, spectator and TFT league
+// endpoints from in-memory fixtures. Point the riot client at it with
+// riot.SetBaseURL(server.URL); every request then arrives as
+// /{host}/{endpoint}, where host is the routing label the client would have
+// called ("americas", "na1", ...).
+package riottest
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+)
+
+// platformRouting maps platform IDs to the regional cluster that serves their matches
+var platformRouting = map[string]string{
+	"NA1": "americas", "BR1": "americas", "LA1": "americas", "LA2": "americas",
+	"EUW1": "europe", "EUN1": "europe", "TR1": "europe", "RU": "europe", "ME1": "europe",
+	"KR": "asia", "JP1": "asia",
+	"OC1": "sea", "PH2": "sea", "SG2": "sea", "TH2": "sea", "TW2": "sea", "VN2": "sea",
+}
+
+// accountClusters are the routing hosts that serve the account API
+var accountClusters = map[string]bool{"americas": true, "asia": true, "europe": true}
+
+// apexTiers are the tiers served by the league list endpoints
+var apexTiers = map[string]bool{"challenger": true, "grandmaster": true, "master": true}
+
+// Fault makes matching requests fail with Status instead of being served
+type Fault struct {
+	// Path matches requests whose endpoint (without the routing prefix) contains it; empty matches every request
+	Path   string
+	Status int
+	// Times is how many requests fail before the fault clears; zero fails every matching request
+	Times int
+}
+
+type account struct {
+	PUUID    string `json:"puuid"`
+	GameName string `json:"gameName"`
+	TagLine  string `json:"tagLine"`
+}
+
+type summoner struct {
+	ID            string `json:"id"`
+	AccountID     string `json:"accountId"`
+	PUUID         string `json:"puuid"`
+	ProfileIconID int    `json:"profileIconId"`
+	RevisionDate  int64  `json:"revisionDate"`
+	SummonerLevel int    `json:"summonerLevel"`
+}
+
+type match struct {
+	id           string
+	routing      string
+	datetime     int64
+	participants map[string]bool
+	raw          json.RawMessage
+}
+
+// Server is a fake Riot API backed by fixtures
+type Server struct {
+	URL string
+
+	server *httptest.Server
+
+	mu          sync.Mutex
+	accounts    map[string]account                    // puuid -> account
+	summoners   map[string]map[string]summoner        // platform -> puuid -> summoner
+	matches     map[string]*match                     // match ID -> match
+	activeGames map[string]map[string]json.RawMessage // platform -> puuid -> game
+	entries     map[string]map[string]json.RawMessage // platform -> puuid -> league entries
+	leagues     map[string]map[string]json.RawMessage // platform -> tier -> league list
+	faults      []*Fault
+	latency     time.Duration
+	requests    []string
+}
+
+// NewServer starts an empty fake server; call Close when done
+func NewServer() *Server {
+	s := &Server{
+		accounts:    make(map[string]account),
+		summoners:   make(map[string]map[string]summoner),
+		matches:     make(map[string]*match),
+		activeGames: make(map[string]map[string]json.RawMessage),
+		entries:     make(map[string]map[string]json.RawMessage),
+		leagues:     make(map[string]map[string]json.RawMessage),
+	}
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("GET /{host}/riot/account/v1/accounts/by-riot-id/{gameName}/{tagLine}", s.handleAccountByRiotID)
+	mux.HandleFunc("GET /{host}/riot/account/v1/accounts/by-puuid/{puuid}", s.handleAccountByPUUID)
+	mux.HandleFunc("GET /{host}/lol/summoner/v4/summoners/by-puuid/{puuid}", s.handleSummoner)
+	mux.HandleFunc("GET /{host}/tft/summoner/v1/summoners/by-puuid/{puuid}", s.handleSummoner)
+	mux.HandleFunc("GET /{host}/tft/match/v1/matches/by-puuid/{puuid}/ids", s.handleMatchIDs)
+	mux.HandleFunc("GET /{host}/tft/match/v1/matches/{matchID}", s.handleMatch)
+	mux.HandleFunc("GET /{host}/lol/spectator/tft/v5/active-games/by-puuid/{puuid}", s.handleActiveGame)
+	mux.HandleFunc("GET /{host}/tft/league/v1/by-puuid/{puuid}", s.handleLeagueEntries)
+	mux.HandleFunc("GET /{host}/tft/league/v1/{tier}", s.handleLeague)
+
+	s.server = httptest.NewServer(s.middleware(mux))
+	s.URL = s.server.URL
+	return s
+}
+
+// Close shuts the server down
+func (s *Server) Close() {
+	s.server.Close()
+}
+
+// LoadDir loads fixtures from a directory laid out like the riot samples:
+// matches/*.json for match payloads and top-level *.json files for active games
+func (s *Server) LoadDir(dir string) error {
+	return s.LoadFS(os.DirFS(dir))
+}
+
+// LoadFS loads fixtures from fsys using the LoadDir layout
+func (s *Server) LoadFS(fsys fs.FS) error {
+	matchFiles, err := fs.Glob(fsys, "matches/*.json")
+	if err != nil {
+		return err
+	}
+	for _, name := range matchFiles {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		if err := s.AddMatch(data); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+
+	gameFiles, err := fs.Glob(fsys, "*.json")
+	if err != nil {
+		return err
+	}
+	for _, name := range gameFiles {
+		data, err := fs.ReadFile(fsys, name)
+		if err != nil {
+			return err
+		}
+		if err := s.AddActiveGame(data); err != nil {
+			return fmt.Errorf("%s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// AddMatch adds a match payload. Participants' Riot IDs become accounts, and
+// every participant gets a summoner on the match's platform.
+func (s *Server) AddMatch(data []byte) error {
+	var payload struct {
+		Metadata struct {
+			MatchID string `json:"match_id"`
+		} `json:"metadata"`
+		Info struct {
+			GameDatetime int64 `json:"game_datetime"`
+			Participants []struct {
+				PUUID          string `json:"puuid"`
+				RiotIDGameName string `json:"riotIdGameName"`
+				RiotIDTagline  string `json:"riotIdTagline"`
+			} `json:"participants"`
+		} `json:"info"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+
+	platform, _, ok := strings.Cut(payload.Metadata.MatchID, "_")
+	routing := platformRouting[platform]
+	if !ok || routing == "" {
+		return fmt.Errorf("match ID %q has no known platform", payload.Metadata.MatchID)
+	}
+
+	m := &match{
+		id:           payload.Metadata.MatchID,
+		routing:      routing,
+		datetime:     payload.Info.GameDatetime,
+		participants: make(map[string]bool, len(payload.Info.Participants)),
+		raw:          append(json.RawMessage(nil), data...),
+	}
+	for _, p := range payload.Info.Participants {
+		m.participants[p.PUUID] = true
+		if p.RiotIDGameName != "" {
+			s.AddAccount(p.PUUID, p.RiotIDGameName, p.RiotIDTagline)
+		}
+		s.addDefaultSummoner(platform, p.PUUID)
+	}
+
+	s.mu.Lock()
+	s.matches[m.id] = m
+	s.mu.Unlock()
+	return nil
+}
+
+// AddActiveGame adds a spectator payload, served to each participant on the game's platform
+func (s *Server) AddActiveGame(data []byte) error {
+	var payload struct {
+		PlatformID   string `json:"platformId"`
+		Participants []struct {
+			PUUID         string `json:"puuid"`
+			ProfileIconID int    `json:"profileIconId"`
+		} `json:"participants"`
+	}
+	if err := json.Unmarshal(data, &payload); err != nil {
+		return err
+	}
+	if payload.PlatformID == "" {
+		return fmt.Errorf("active game has no platformId")
+	}
+
+	platform := strings.ToUpper(payload.PlatformID)
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if s.activeGames[platform] == nil {
+		s.activeGames[platform] = make(map[string]json.RawMessage)
+	}
+	for _, p := range payload.Participants {
+		s.activeGames[platform][p.PUUID] = append(json.RawMessage(nil), data...)
+		if sum, ok := s.summoners[platform][p.PUUID]; ok && p.ProfileIconID != 0 {
+			sum.ProfileIconID = p.ProfileIconID
+			s.summoners[platform][p.PUUID] = sum
+		}
+	}
+	return nil
+}
+
+// AddAccount adds or replaces the account for puuid
+func (s *Server) AddAccount(puuid, gameName, tagLine string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.accounts[puuid] = account{PUUID: puuid, GameName: gameName, TagLine: tagLine}
+}
+
+// AddSummoner adds or replaces the summoner for puuid on platform (e.g. "NA1")
+func (s *Server) AddSummoner(platform, puuid string, level, profileIconID int) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.putSummoner(strings.ToUpper(platform), summoner{
+		ID:            "summoner-" + puuid,
+		AccountID:     "account-" + puuid,
+		PUUID:         puuid,
+		ProfileIconID: profileIconID,
+		RevisionDate:  time.Now().UnixMilli(),
+		SummonerLevel: level,
+	})
+}
+
+// addDefaultSummoner adds a summoner for puuid unless one already exists
+func (s *Server) addDefaultSummoner(platform, puuid string) {
+	s.mu.Lock()
+	_, ok := s.summoners[platform][puuid]
+	s.mu.Unlock()
+	if !ok {
+		s.AddSummoner(platform, puuid, 100, 29)
+	}
+}
+
+func (s *Server) putSummoner(platform string, sum summoner) {
+	if s.summoners[platform] == nil {
+		s.summoners[platform] = make(map[string]summoner)
+	}
+	s.summoners[platform][sum.PUUID] = sum
+}
+
+// SetLeagueEntries sets the TFT league entries returned for puuid on platform;
+// entries is marshalled as JSON and should be a list
+func (s *Server) SetLeagueEntries(platform, puuid string, entries any) error {
+	data, err := json.Marshal(entries)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	platform = strings.ToUpper(platform)
+	if s.entries[platform] == nil {
+		s.entries[platform] = make(map[string]json.RawMessage)
+	}
+	s.entries[platform][puuid] = data
+	return nil
+}
+
+// SetLeague sets the league list returned for an apex tier ("challenger", "grandmaster" or "master") on platform
+func (s *Server) SetLeague(platform, tier string, league any) error {
+	tier = strings.ToLower(tier)
+	if !apexTiers[tier] {
+		return fmt.Errorf("unknown apex tier %q", tier)
+	}
+	data, err := json.Marshal(league)
+	if err != nil {
+		return err
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	platform = strings.ToUpper(platform)
+	if s.leagues[platform] == nil {
+		s.leagues[platform] = make(map[string]json.RawMessage)
+	}
+	s.leagues[platform][tier] = data
+	return nil
+}
+
+// Inject adds a fault; faults are checked in the order they were added
+func (s *Server) Inject(fault Fault) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = append(s.faults, &fault)
+}
+
+// ClearFaults removes every injected fault
+func (s *Server) ClearFaults() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.faults = nil
+}
+
+// SetLatency delays every response by d
+func (s *Server) SetLatency(d time.Duration) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.latency = d
+}
+
+// Requests returns the paths requested so far, in order, without query strings
+func (s *Server) Requests() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return append([]string(nil), s.requests...)
+}
+
+// middleware records requests and applies latency, API key checks and injected faults
+func (s *Server) middleware(next http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		s.mu.Lock()
+		s.requests = append(s.requests, r.URL.Path)
+		latency := s.latency
+		status := s.takeFault(r.URL.Path)
+		s.mu.Unlock()
+
+		if latency > 0 {
+			select {
+			case <-time.After(latency):
+			case <-r.Context().Done():
+				return
+			}
+		}
+
+		switch {
+		case r.URL.Query().Get("api_key") == "":
+			writeStatus(w, http.StatusUnauthorized, "Unauthorized")
+		case status != 0:
+			if status == http.StatusTooManyRequests {
+				w.Header().Set("Retry-After", "1")
+			}
+			writeStatus(w, status, http.StatusText(status))
+		default:
+			next.ServeHTTP(w, r)
+		}
+	})
+}
+
+// takeFault returns the status of the first fault matching path, consuming one of its uses.
+// The caller must hold s.mu.
+func (s *Server) takeFault(requestPath string) int {
+	endpoint := requestPath
+	if idx := strings.Index(strings.TrimPrefix(requestPath, "/"), "/"); idx >= 0 {
+		endpoint = requestPath[idx+1:]
+	}
+	for i, fault := range s.faults {
+		if !strings.Contains(endpoint, fault.Path) {
+			continue
+		}
+		if fault.Times > 0 {
+			fault.Times--
+			if fault.Times == 0 {
+				s.faults = append(s.faults[:i], s.faults[i+1:]...)
+			}
+		}
+		return fault.Status
+	}
+	return 0
+}
+
+func (s *Server) handleAccountByRiotID(w http.ResponseWriter, r *http.Request) {
+	if !accountClusters[r.PathValue("host")] {
+		writeStatus(w, http.StatusNotFound, "Unknown routing value")
+		return
+	}
+	gameName, tagLine := r.PathValue("gameName"), r.PathValue("tagLine")
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for _, acc := range s.accounts {
+		if strings.EqualFold(acc.GameName, gameName) && strings.EqualFold(acc.TagLine, tagLine) {
+			writeJSON(w, acc)
+			return
+		}
+	}
+	writeStatus(w, http.StatusNotFound, "Data not found - No results found for player with riot id "+gameName+"#"+tagLine)
+}
+
+func (s *Server) handleAccountByPUUID(w http.ResponseWriter, r *http.Request) {
+	if !accountClusters[r.PathValue("host")] {
+		writeStatus(w, http.StatusNotFound, "Unknown routing value")
+		return
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if acc, ok := s.accounts[r.PathValue("puuid")]; ok {
+		writeJSON(w, acc)
+		return
+	}
+	writeStatus(w, http.StatusNotFound, "Data not found - No account found")
+}
+
+func (s *Server) handleSummoner(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if sum, ok := s.summoners[platformOf(r)][r.PathValue("puuid")]; ok {
+		writeJSON(w, sum)
+		return
+	}
+	writeStatus(w, http.StatusNotFound, "Data not found - summoner not found")
+}
+
+func (s *Server) handleMatchIDs(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	start, count := 0, 20
+	var startTime, endTime int64
+	for name, target := range map[string]*int{"start": &start, "count": &count} {
+		if v := query.Get(name); v != "" {
+			n, err := strconv.Atoi(v)
+			if err != nil || n < 0 {
+				writeStatus(w, http.StatusBadRequest, "Bad request - invalid "+name)
+				return
+			}
+			*target = n
+		}
+	}
+	for name, target := range map[string]*int64{"startTime": &startTime, "endTime": &endTime} {
+		if v := query.Get(name); v != "" {
+			n, err := strconv.ParseInt(v, 10, 64)
+			if err != nil {
+				writeStatus(w, http.StatusBadRequest, "Bad request - invalid "+name)
+				return
+			}
+			*target = n
+		}
+	}
+	if count > 200 {
+		writeStatus(w, http.StatusBadRequest, "Bad request - count must be at most 200")
+		return
+	}
+
+	host, puuid := r.PathValue("host"), r.PathValue("puuid")
+	s.mu.Lock()
+	var found []*match
+	for _, m := range s.matches {
+		if m.routing != host || !m.participants[puuid] {
+			continue
+		}
+		seconds := m.datetime / 1000
+		if (startTime > 0 && seconds < startTime) || (endTime > 0 && seconds > endTime) {
+			continue
+		}
+		found = append(found, m)
+	}
+	s.mu.Unlock()
+
+	sort.Slice(found, func(i, j int) bool {
+		if found[i].datetime != found[j].datetime {
+			return found[i].datetime > found[j].datetime
+		}
+		return found[i].id > found[j].id
+	})
+
+	ids := []string{}
+	for i := start; i < len(found) && len(ids) < count; i++ {
+		ids = append(ids, found[i].id)
+	}
+	writeJSON(w, ids)
+}
+
+func (s *Server) handleMatch(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	m, ok := s.matches[r.PathValue("matchID")]
+	s.mu.Unlock()
+	if !ok || m.routing != r.PathValue("host") {
+		writeStatus(w, http.StatusNotFound, "Data not found - match file not found")
+		return
+	}
+	writeRaw(w, m.raw)
+}
+
+func (s *Server) handleActiveGame(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	game, ok := s.activeGames[platformOf(r)][r.PathValue("puuid")]
+	s.mu.Unlock()
+	if !ok {
+		writeStatus(w, http.StatusNotFound, "Data not found - spectator game info isn't found")
+		return
+	}
+	writeRaw(w, game)
+}
+
+func (s *Server) handleLeagueEntries(w http.ResponseWriter, r *http.Request) {
+	s.mu.Lock()
+	entries, ok := s.entries[platformOf(r)][r.PathValue("puuid")]
+	s.mu.Unlock()
+	if !ok {
+		// Unranked players have no entries rather than a 404
+		writeJSON(w, []any{})
+		return
+	}
+	writeRaw(w, entries)
+}
+
+func (s *Server) handleLeague(w http.ResponseWriter, r *http.Request) {
+	tier := r.PathValue("tier")
+	if !apexTiers[tier] {
+		writeStatus(w, http.StatusNotFound, "Not found")
+		return
+	}
+
+	s.mu.Lock()
+	league, ok := s.leagues[platformOf(r)][tier]
+	s.mu.Unlock()
+	if !ok {
+		writeJSON(w, map[string]any{"tier": strings.ToUpper(tier), "entries": []any{}})
+		return
+	}
+	writeRaw(w, league)
+}
+
+// platformOf returns the platform ID a platform-routed request was sent to, e.g. "NA1"
+func platformOf(r *http.Request) string {
+	return strings.ToUpper(r.PathValue("host"))
+}
+
+func writeJSON(w http.ResponseWriter, v any) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		writeStatus(w, http.StatusInternalServerError, err.Error())
+		return
+	}
+	writeRaw(w, data)
+}
+
+func writeRaw(w http.ResponseWriter, data []byte) {
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	_, _ = w.Write(data)
+}
+
+// writeStatus writes an error in the Riot API's status envelope
+func writeStatus(w http.ResponseWriter, code int, message string) {
+	w.Header().Set("Content-Type", "application/json;charset=utf-8")
+	w.WriteHeader(code)
+	_ = json.NewEncoder(w).Encode(map[string]any{
+		"status": map[string]any{"message": message, "status_code": code},
+	})
+}
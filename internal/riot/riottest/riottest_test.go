@@ -0,0 +1,83 @@
+package riottest
+
+import (
+	"encoding/json"
+	"net/http"
+	"testing"
+)
+
+func getJSON(t *testing.T, url string, v any) int {
+	t.Helper()
+	resp, err := http.Get(url)
+	if err != nil {
+		t.Fatalf("Request failed: %v", err)
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+	if v != nil && resp.StatusCode == http.StatusOK {
+		if err := json.NewDecoder(resp.Body).Decode(v); err != nil {
+			t.Fatalf("Failed to decode response: %v", err)
+		}
+	}
+	return resp.StatusCode
+}
+
+func TestServer_League(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+
+	entries := []map[string]any{{"puuid": "p1", "tier": "DIAMOND", "rank": "II", "leaguePoints": 42}}
+	if err := server.SetLeagueEntries("na1", "p1", entries); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := server.SetLeague("NA1", "Challenger", map[string]any{"tier": "CHALLENGER", "entries": []any{}}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := server.SetLeague("NA1", "iron", nil); err == nil {
+		t.Error("Expected error for a non-apex tier")
+	}
+
+	var got []map[string]any
+	if code := getJSON(t, server.URL+"/na1/tft/league/v1/by-puuid/p1?api_key=k", &got); code != http.StatusOK || len(got) != 1 || got[0]["tier"] != "DIAMOND" {
+		t.Errorf("Expected diamond entry, got %d %v", code, got)
+	}
+	if code := getJSON(t, server.URL+"/na1/tft/league/v1/by-puuid/unranked?api_key=k", &got); code != http.StatusOK || len(got) != 0 {
+		t.Errorf("Expected no entries for an unranked player, got %d %v", code, got)
+	}
+
+	var league map[string]any
+	if code := getJSON(t, server.URL+"/na1/tft/league/v1/challenger?api_key=k", &league); code != http.StatusOK || league["tier"] != "CHALLENGER" {
+		t.Errorf("Expected challenger league, got %d %v", code, league)
+	}
+	if code := getJSON(t, server.URL+"/euw1/tft/league/v1/grandmaster?api_key=k", &league); code != http.StatusOK || league["tier"] != "GRANDMASTER" {
+		t.Errorf("Expected empty grandmaster league, got %d %v", code, league)
+	}
+}
+
+func TestServer_FaultsAndRequests(t *testing.T) {
+	server := NewServer()
+	defer server.Close()
+	server.AddAccount("p1", "Player", "NA1")
+
+	server.Inject(Fault{Path: "/by-puuid/", Status: http.StatusInternalServerError, Times: 2})
+	url := server.URL + "/americas/riot/account/v1/accounts/by-puuid/p1?api_key=k"
+	for i, want := range []int{http.StatusInternalServerError, http.StatusInternalServerError, http.StatusOK} {
+		if code := getJSON(t, url, nil); code != want {
+			t.Errorf("Request %d: expected %d, got %d", i, want, code)
+		}
+	}
+
+	// A fault on one routing label's path must not match the prefix itself
+	server.Inject(Fault{Path: "americas", Status: http.StatusTeapot})
+	if code := getJSON(t, url, nil); code != http.StatusOK {
+		t.Errorf("Expected fault paths to ignore the routing prefix, got %d", code)
+	}
+
+	if code := getJSON(t, server.URL+"/sea/riot/account/v1/accounts/by-puuid/p1?api_key=k", nil); code != http.StatusNotFound {
+		t.Errorf("Expected 404 for the SEA cluster, got %d", code)
+	}
+	if requests := server.Requests(); len(requests) != 5 || requests[0] != "/americas/riot/account/v1/accounts/by-puuid/p1" {
+		t.Errorf("Unexpected request log %v", requests)
+	}
+}
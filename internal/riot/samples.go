@@ -0,0 +1,74 @@
+package riot
+
+import (
+	"embed"
+	"encoding/json"
+	"fmt"
+	"os"
+)
+
+// sampleFS holds fixture data so lobby analysis can run without an API key.
+// It follows the LoadFixtureSource layout.
+//
+//go:embed samples
+var sampleFS embed.FS
+
+const sampleActiveGameFile = "samples/active_game_sample.json"
+
+// LoadSampleActiveGame loads the sample active game data for testing
+func LoadSampleActiveGame() (*CurrentGameInfo, error) {
+	data, err := sampleFS.ReadFile(sampleActiveGameFile)
+	if err != nil {
+		return nil, err
+	}
+	return decodeActiveGame(data)
+}
+
+// LoadSampleActiveGameFromFile loads sample active game data from JSON file
+func LoadSampleActiveGameFromFile(filename string) (*CurrentGameInfo, error) {
+	data, err := os.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return decodeActiveGame(data)
+}
+
+// decodeActiveGame decodes a spectator-v5 active game response
+func decodeActiveGame(data []byte) (*CurrentGameInfo, error) {
+	var game CurrentGameInfo
+	if err := json.Unmarshal(data, &game); err != nil {
+		return nil, fmt.Errorf("error decoding sample active game: %w", err)
+	}
+	return &game, nil
+}
+
+// NewSampleProfileAnalyzer creates an analyzer that reads only from the sample
+// lobby and its matches, with its own cache so fixture profiles never mix with live ones.
+func NewSampleProfileAnalyzer() (*ProfileAnalyzer, error) {
+	source, err := SampleSource()
+	if err != nil {
+		return nil, err
+	}
+	pa := NewProfileAnalyzer()
+	pa.Source = source
+	return pa, nil
+}
+
+// AnalyzeLobbyFromSample analyzes the sample lobby against the sample matches.
+// It needs no API key; the analyzer's settings, meta and model are kept.
+func (pa *ProfileAnalyzer) AnalyzeLobbyFromSample() ([]*PlayerProfile, error) {
+	sampleGame, err := LoadSampleActiveGame()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sample game: %w", err)
+	}
+	source, err := SampleSource()
+	if err != nil {
+		return nil, fmt.Errorf("failed to load sample matches: %w", err)
+	}
+
+	sample := *pa
+	sample.Cache = NewDefaultCache()
+	sample.Archive = nil
+	sample.Source = source
+	return sample.AnalyzeLobby(sampleGame)
+}
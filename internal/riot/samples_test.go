@@ -0,0 +1,53 @@
+package riot
+
+import (
+	"testing"
+)
+
+func TestLoadSampleActiveGame(t *testing.T) {
+	game, err := LoadSampleActiveGame()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if game.GameID != 5359015295 || len(game.Participants) != 8 {
+		t.Errorf("Unexpected sample game %d with %d participants", game.GameID, len(game.Participants))
+	}
+
+	fromFile, err := LoadSampleActiveGameFromFile("samples/active_game_sample.json")
+	if err != nil {
+		t.Fatalf("Unexpected error loading from file: %v", err)
+	}
+	if fromFile.GameID != game.GameID {
+		t.Errorf("Expected file and embedded samples to match, got %d", fromFile.GameID)
+	}
+	if _, err := LoadSampleActiveGameFromFile("samples/missing.json"); err == nil {
+		t.Error("Expected error for missing file")
+	}
+}
+
+func TestAnalyzeLobbyFromSample(t *testing.T) {
+	analyzer := NewProfileAnalyzer()
+	profiles, err := analyzer.AnalyzeLobbyFromSample()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(profiles) != 8 {
+		t.Fatalf("Expected 8 profiles, got %d", len(profiles))
+	}
+	for _, profile := range profiles {
+		if profile.AnalyzedGames < analyzer.MinGamesRequired || profile.GameName == "" {
+			t.Errorf("Expected a named profile from sample matches, got %s with %d games", profile.PUUID, profile.AnalyzedGames)
+		}
+	}
+	if _, ok := analyzer.Cache.GetProfile(AnalysisOptions{Count: 20}.cacheKey(profiles[0].PUUID)); ok {
+		t.Error("Expected sample profiles to stay out of the analyzer's cache")
+	}
+
+	sample, err := NewSampleProfileAnalyzer()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if _, err := sample.AnalyzePlayer(profiles[0].PUUID); err != nil {
+		t.Errorf("Expected sample analyzer to profile a sample player: %v", err)
+	}
+}
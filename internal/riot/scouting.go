@@ -0,0 +1,138 @@
+package riot
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+)
+
+const (
+	// maxScoutedComps is how many of the player's comps a scouting report covers
+	maxScoutedComps = 3
+	// traitOverlapWeight discounts opponents who share a comp's traits but not the exact comp
+	traitOverlapWeight = 0.5
+)
+
+// ScoutingReport relates a lobby's opponents to the requesting player's preferred comps
+type ScoutingReport struct {
+	PUUID          string         `json:"puuid"`
+	Comps          []CompScouting `json:"comps"`          // the player's top comps, most played first
+	LeastContested string         `json:"leastContested"` // comp with the lowest contest score
+}
+
+// CompScouting lists who in the lobby overlaps with one of the player's comps
+type CompScouting struct {
+	Comp         string       `json:"comp"`         // see CompSignature
+	Frequency    float64      `json:"frequency"`    // 0-1, share of the player's games on this comp
+	AvgPlacement float64      `json:"avgPlacement"` // the player's average placement on this comp
+	ContestScore float64      `json:"contestScore"` // sum of opponent threats; roughly the expected strong opponents on this line
+	MetaRate     float64      `json:"metaRate"`     // 0-1, population play rate, when a meta snapshot is available
+	Opponents    []CompThreat `json:"opponents"`    // overlapping opponents, most threatening first
+}
+
+// CompThreat describes one opponent's overlap with a comp
+type CompThreat struct {
+	PUUID        string  `json:"puuid"`
+	GameName     string  `json:"gameName,omitempty"`
+	Overlap      float64 `json:"overlap"`      // 0-1, exact comp rate, or discounted shared-trait rate
+	AvgPlacement float64 `json:"avgPlacement"` // opponent's placement on the comp, or overall if they never played it
+	Threat       float64 `json:"threat"`       // 0-1, overlap scaled by how well they place
+}
+
+// BuildScoutingReport scouts a lobby for the player with the given PUUID.
+// Opponents overlap a comp fully when they play the same CompSignature, and
+// partially when they play its traits in other boards.
+func BuildScoutingReport(puuid string, profiles []*PlayerProfile, meta *MetaSnapshot) (*ScoutingReport, error) {
+	var me *PlayerProfile
+	for _, p := range profiles {
+		if p != nil && p.PUUID == puuid {
+			me = p
+			break
+		}
+	}
+	if me == nil || len(me.CompPreference.FavoriteComps) == 0 {
+		return nil, fmt.Errorf("no comp history for player")
+	}
+
+	report := &ScoutingReport{PUUID: puuid}
+	for i, comp := range me.CompPreference.FavoriteComps {
+		if i >= maxScoutedComps {
+			break
+		}
+		scouting := CompScouting{
+			Comp:         comp.Comp,
+			Frequency:    comp.Frequency,
+			AvgPlacement: comp.AvgPlacement,
+		}
+		if meta != nil {
+			scouting.MetaRate = meta.CompRates[comp.Comp]
+		}
+
+		for _, opponent := range profiles {
+			if opponent == nil || opponent.PUUID == puuid || opponent.AnalyzedGames == 0 {
+				continue
+			}
+			threat := scoutOpponent(comp.Comp, opponent)
+			if threat.Overlap == 0 {
+				continue
+			}
+			scouting.ContestScore += threat.Threat
+			scouting.Opponents = append(scouting.Opponents, threat)
+		}
+		sort.SliceStable(scouting.Opponents, func(i, j int) bool {
+			return scouting.Opponents[i].Threat > scouting.Opponents[j].Threat
+		})
+
+		report.Comps = append(report.Comps, scouting)
+	}
+
+	least := 0
+	for i, scouting := range report.Comps {
+		if scouting.ContestScore < report.Comps[least].ContestScore {
+			least = i
+		}
+	}
+	report.LeastContested = report.Comps[least].Comp
+
+	return report, nil
+}
+
+// scoutOpponent measures how much an opponent overlaps a comp and how well they place on it
+func scoutOpponent(comp string, opponent *PlayerProfile) CompThreat {
+	threat := CompThreat{
+		PUUID:    opponent.PUUID,
+		GameName: opponent.GameName,
+	}
+	if opponent.SoloGames > 0 {
+		threat.AvgPlacement = opponent.PlayStyle.AveragePlacement
+	}
+
+	for _, played := range opponent.CompPreference.FavoriteComps {
+		if played.Comp == comp {
+			threat.Overlap = played.Frequency
+			threat.AvgPlacement = played.AvgPlacement
+			break
+		}
+	}
+
+	if threat.Overlap == 0 {
+		traits := strings.Split(comp, "+")
+		shared := 0.0
+		for _, trait := range opponent.CompPreference.FavoriteTraits {
+			for _, name := range traits {
+				if trait.Name == name {
+					shared += trait.Frequency
+				}
+			}
+		}
+		threat.Overlap = traitOverlapWeight * shared / float64(len(traits))
+	}
+
+	// 1st place scores full strength, 8th none; unknown placements score as average
+	strength := (8 - meanPlacement) / 7
+	if threat.AvgPlacement > 0 {
+		strength = (8 - threat.AvgPlacement) / 7
+	}
+	threat.Threat = threat.Overlap * strength
+	return threat
+}
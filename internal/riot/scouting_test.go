@@ -0,0 +1,101 @@
+package riot
+
+import (
+	"math"
+	"testing"
+)
+
+func TestBuildScoutingReport(t *testing.T) {
+	profiles := []*PlayerProfile{
+		{
+			PUUID:         "me",
+			AnalyzedGames: 10,
+			SoloGames:     10,
+			CompPreference: CompPreferenceProfile{FavoriteComps: []CompFrequency{
+				{Comp: "Duelist+Sniper", Frequency: 0.5, AvgPlacement: 3.0},
+				{Comp: "Bastion+Mentor", Frequency: 0.3, AvgPlacement: 4.0},
+			}},
+		},
+		{
+			PUUID:         "rival",
+			AnalyzedGames: 10,
+			SoloGames:     10,
+			PlayStyle:     PlayStyleProfile{AveragePlacement: 4.0},
+			CompPreference: CompPreferenceProfile{FavoriteComps: []CompFrequency{
+				{Comp: "Duelist+Sniper", Frequency: 0.6, AvgPlacement: 1.0},
+			}},
+		},
+		{
+			PUUID:         "dabbler",
+			AnalyzedGames: 10,
+			SoloGames:     10,
+			PlayStyle:     PlayStyleProfile{AveragePlacement: 8.0},
+			CompPreference: CompPreferenceProfile{FavoriteTraits: []TraitFrequency{
+				{Name: "Bastion", Frequency: 0.8},
+			}},
+		},
+		{PUUID: "nodata"},
+	}
+
+	report, err := BuildScoutingReport("me", profiles, &MetaSnapshot{CompRates: map[string]float64{"Duelist+Sniper": 0.2}})
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(report.Comps) != 2 {
+		t.Fatalf("Expected 2 scouted comps, got %d", len(report.Comps))
+	}
+
+	duelist := report.Comps[0]
+	if len(duelist.Opponents) != 1 || duelist.Opponents[0].PUUID != "rival" {
+		t.Fatalf("Expected rival on Duelist+Sniper, got %+v", duelist.Opponents)
+	}
+	if duelist.Opponents[0].Threat != 0.6 || duelist.MetaRate != 0.2 {
+		t.Errorf("Expected a 1st-place rival at 60%% to threaten 0.6 with meta rate 0.2, got %+v", duelist)
+	}
+
+	// Sharing one of two traits counts half, then the trait discount halves it again
+	bastion := report.Comps[1]
+	if len(bastion.Opponents) != 1 || math.Abs(bastion.Opponents[0].Overlap-0.2) > 1e-9 {
+		t.Errorf("Expected dabbler to overlap 20%% on Bastion+Mentor, got %+v", bastion.Opponents)
+	}
+	if bastion.ContestScore != 0 {
+		t.Errorf("Expected an 8th-place opponent to pose no threat, got %.2f", bastion.ContestScore)
+	}
+	if report.LeastContested != "Bastion+Mentor" {
+		t.Errorf("Expected Bastion+Mentor least contested, got %s", report.LeastContested)
+	}
+
+	if _, err := BuildScoutingReport("nodata", profiles, nil); err == nil {
+		t.Error("Expected error without comp history")
+	}
+}
+
+func TestAnalyzeCompPreference_FavoriteComps(t *testing.T) {
+	analyzer := NewProfileAnalyzer()
+	playerData := []ParticipantDto{
+		metaTestBoard("me", 2, "Duelist", "Sniper"),
+		metaTestBoard("me", 4, "Duelist", "Sniper"),
+		metaTestBoard("me", 6, "Bastion", "Mentor"),
+	}
+
+	comps := analyzer.analyzeCompPreference(playerData).FavoriteComps
+	if len(comps) != 2 || comps[0].Comp != "Duelist+Sniper" {
+		t.Fatalf("Expected Duelist+Sniper as the top comp, got %+v", comps)
+	}
+	if comps[0].AvgPlacement != 3.0 || math.Abs(comps[0].Frequency-2.0/3.0) > 1e-9 {
+		t.Errorf("Unexpected comp stats %+v", comps[0])
+	}
+}
+
+func TestScoutOpponent_NoSoloGames(t *testing.T) {
+	// A Double Up only player has no placement of their own, so they threaten at average strength
+	opponent := &PlayerProfile{
+		PUUID:          "duo",
+		AnalyzedGames:  10,
+		CompPreference: CompPreferenceProfile{FavoriteTraits: []TraitFrequency{{Name: "Bastion", Frequency: 0.8}}},
+	}
+	threat := scoutOpponent("Bastion+Mentor", opponent)
+	if threat.AvgPlacement != 0 || math.Abs(threat.Threat-0.1) > 1e-9 {
+		t.Errorf("Expected an unknown placement and a 0.1 threat, got %+v", threat)
+	}
+}
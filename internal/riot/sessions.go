@@ -0,0 +1,220 @@
+package riot
+
+import (
+	"fmt"
+	"time"
+)
+
+const (
+	// sessionGap is the idle time between games that starts a new session
+	sessionGap = 45 * time.Minute
+	// tiltStreakLength is the bottom-4 streak at which the streak alone signals full tilt
+	tiltStreakLength = 3
+	// sessionLookback is how far back /session looks for the player's latest session
+	sessionLookback = 24 * time.Hour
+	// maxSessionGames caps how many games are fetched for a session summary
+	maxSessionGames = 30
+)
+
+// Session is a run of games queued back to back
+type Session struct {
+	Start                time.Time `json:"start"`
+	End                  time.Time `json:"end"`
+	MatchIDs             []string  `json:"matchIds"`   // oldest first
+	Placements           []int     `json:"placements"` // oldest first
+	AvgPlacement         float64   `json:"avgPlacement"`
+	TopFourRate          float64   `json:"topFourRate"`
+	PlacementTrend       float64   `json:"placementTrend"`       // placements per game; positive means finishing lower as the session goes on
+	LongestBotFourStreak int       `json:"longestBotFourStreak"` // most consecutive 5th-8th finishes
+	CurrentBotFourStreak int       `json:"currentBotFourStreak"` // consecutive 5th-8th finishes ending with the last game
+	TiltIndicator        float64   `json:"tiltIndicator"`        // 0-1, see calculateTiltIndicator
+}
+
+// Games returns how many games were played in the session
+func (s Session) Games() int {
+	return len(s.Placements)
+}
+
+// Duration returns the time from the first game's start to the last game's end
+func (s Session) Duration() time.Duration {
+	return s.End.Sub(s.Start)
+}
+
+// segmentSessions splits games (oldest first) into sessions wherever the idle
+// time between one game ending and the next starting exceeds gap.
+// GameDatetime is treated as the start of the game.
+func segmentSessions(games []playerGame, gap time.Duration) []Session {
+	var sessions []Session
+	var current []playerGame
+
+	flush := func() {
+		if len(current) > 0 {
+			sessions = append(sessions, buildSession(current))
+			current = nil
+		}
+	}
+
+	for _, game := range games {
+		if len(current) > 0 {
+			last := current[len(current)-1]
+			idle := time.Duration(game.GameDatetime-last.GameDatetime)*time.Millisecond -
+				time.Duration(last.GameLength*float64(time.Second))
+			if idle > gap {
+				flush()
+			}
+		}
+		current = append(current, game)
+	}
+	flush()
+
+	return sessions
+}
+
+// buildSession summarizes a run of consecutive games, oldest first
+func buildSession(games []playerGame) Session {
+	first := games[0]
+	last := games[len(games)-1]
+	session := Session{
+		Start: time.UnixMilli(first.GameDatetime),
+		End:   time.UnixMilli(last.GameDatetime).Add(time.Duration(last.GameLength * float64(time.Second))),
+	}
+
+	total := 0
+	topFours := 0
+	for _, game := range games {
+		placement := game.Participant.Placement
+		session.MatchIDs = append(session.MatchIDs, game.MatchID)
+		session.Placements = append(session.Placements, placement)
+		total += placement
+		if placement <= 4 {
+			topFours++
+		}
+	}
+
+	session.AvgPlacement = float64(total) / float64(len(games))
+	session.TopFourRate = float64(topFours) / float64(len(games))
+	session.PlacementTrend = placementSlope(session.Placements)
+	session.LongestBotFourStreak = longestBotFourStreak(session.Placements)
+	session.CurrentBotFourStreak = currentBotFourStreak(session.Placements)
+	session.TiltIndicator = calculateTiltIndicator(session.Placements)
+	return session
+}
+
+// placementSlope returns the least-squares slope of placement over game number
+func placementSlope(placements []int) float64 {
+	n := float64(len(placements))
+	if n < 2 {
+		return 0.0
+	}
+
+	var sumX, sumY, sumXY, sumXX float64
+	for i, placement := range placements {
+		x := float64(i)
+		y := float64(placement)
+		sumX += x
+		sumY += y
+		sumXY += x * y
+		sumXX += x * x
+	}
+
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return 0.0
+	}
+	return (n*sumXY - sumX*sumY) / denominator
+}
+
+// longestBotFourStreak returns the most consecutive 5th-8th place finishes
+func longestBotFourStreak(placements []int) int {
+	longest := 0
+	current := 0
+	for _, placement := range placements {
+		if placement > 4 {
+			current++
+			if current > longest {
+				longest = current
+			}
+		} else {
+			current = 0
+		}
+	}
+	return longest
+}
+
+// currentBotFourStreak returns how many of the latest games in a row finished 5th-8th
+func currentBotFourStreak(placements []int) int {
+	streak := 0
+	for i := len(placements) - 1; i >= 0 && placements[i] > 4; i-- {
+		streak++
+	}
+	return streak
+}
+
+// calculateTiltIndicator scores 0-1 how tilted a player looks in a session.
+// It blends the current bottom-4 streak with how much worse the second half
+// of the session went compared to the first.
+func calculateTiltIndicator(placements []int) float64 {
+	if len(placements) == 0 {
+		return 0.0
+	}
+
+	streak := float64(currentBotFourStreak(placements)) / tiltStreakLength
+	if streak > 1 {
+		streak = 1
+	}
+
+	decline := 0.0
+	if len(placements) >= 4 {
+		mid := len(placements) / 2
+		decline = (averageOf(placements[mid:]) - averageOf(placements[:mid])) / 4
+		if decline < 0 {
+			decline = 0
+		} else if decline > 1 {
+			decline = 1
+		}
+	}
+
+	return 0.6*streak + 0.4*decline
+}
+
+// averageOf returns the mean of a slice of placements
+func averageOf(placements []int) float64 {
+	if len(placements) == 0 {
+		return 0.0
+	}
+	total := 0
+	for _, placement := range placements {
+		total += placement
+	}
+	return float64(total) / float64(len(placements))
+}
+
+// AnalyzeLatestSession summarizes the player's most recent session from the last day of solo games
+func (pa *ProfileAnalyzer) AnalyzeLatestSession(puuid string) (*Session, error) {
+	opts := AnalysisOptions{
+		Count:     maxSessionGames,
+		StartTime: time.Now().Add(-sessionLookback),
+	}
+	matchIDs, _, err := pa.listMatchIDs(puuid, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*MatchDto
+	for _, matchID := range matchIDs {
+		match, err := pa.getMatch(matchID)
+		if err != nil {
+			continue // skip failed matches
+		}
+		if !IsDoubleUp(match) {
+			matches = append(matches, match)
+		}
+	}
+
+	sessions := segmentSessions(extractPlayerGames(puuid, matches), sessionGap)
+	if len(sessions) == 0 {
+		return nil, fmt.Errorf("no solo games played in the last %d hours", int(sessionLookback.Hours()))
+	}
+	latest := sessions[len(sessions)-1]
+	return &latest, nil
+}
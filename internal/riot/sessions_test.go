@@ -0,0 +1,101 @@
+package riot
+
+import (
+	"fmt"
+	"math"
+	"testing"
+	"time"
+)
+
+// sessionTestGames builds back-to-back 30 minute games starting at the given minutes past midnight
+func sessionTestGames(starts []int, placements []int) []playerGame {
+	var games []playerGame
+	for i, start := range starts {
+		games = append(games, playerGame{
+			MatchID:      fmt.Sprintf("NA1_%d", i+1),
+			Participant:  ParticipantDto{PUUID: "test", Placement: placements[i]},
+			GameDatetime: time.Date(2025, 8, 1, 0, start, 0, 0, time.UTC).UnixMilli(),
+			GameLength:   30 * 60,
+		})
+	}
+	return games
+}
+
+func TestSegmentSessions(t *testing.T) {
+	// Two games back to back, a 3 hour break, then three more
+	games := sessionTestGames([]int{0, 35, 245, 280, 320}, []int{1, 2, 3, 6, 7})
+
+	sessions := segmentSessions(games, sessionGap)
+	if len(sessions) != 2 {
+		t.Fatalf("Expected 2 sessions, got %d", len(sessions))
+	}
+	if sessions[0].Games() != 2 || sessions[1].Games() != 3 {
+		t.Errorf("Expected 2 and 3 games, got %d and %d", sessions[0].Games(), sessions[1].Games())
+	}
+	if fmt.Sprint(sessions[1].MatchIDs) != "[NA1_3 NA1_4 NA1_5]" {
+		t.Errorf("Unexpected second session matches %v", sessions[1].MatchIDs)
+	}
+	if sessions[0].Duration() != 65*time.Minute {
+		t.Errorf("Expected first session to last 65m, got %s", sessions[0].Duration())
+	}
+	if sessions[1].PlacementTrend != 2.0 {
+		t.Errorf("Expected worsening trend of 2.0 per game, got %.2f", sessions[1].PlacementTrend)
+	}
+	if len(segmentSessions(nil, sessionGap)) != 0 {
+		t.Error("Expected no sessions without games")
+	}
+}
+
+func TestBotFourStreaks(t *testing.T) {
+	placements := []int{5, 6, 7, 2, 8, 5}
+	if got := longestBotFourStreak(placements); got != 3 {
+		t.Errorf("Expected longest streak 3, got %d", got)
+	}
+	if got := currentBotFourStreak(placements); got != 2 {
+		t.Errorf("Expected current streak 2, got %d", got)
+	}
+	if got := currentBotFourStreak([]int{8, 1}); got != 0 {
+		t.Errorf("Expected no current streak after a top 4, got %d", got)
+	}
+}
+
+func TestCalculateTiltIndicator(t *testing.T) {
+	if tilt := calculateTiltIndicator([]int{3, 2, 1, 1}); tilt != 0 {
+		t.Errorf("Expected no tilt for a strong session, got %.2f", tilt)
+	}
+
+	// Three straight bot 4s max the streak; the second half averages 5 places worse, capped at 1
+	tilt := calculateTiltIndicator([]int{1, 2, 3, 6, 7, 8})
+	if tilt != 1.0 {
+		t.Errorf("Expected full tilt, got %.2f", tilt)
+	}
+
+	// One bot 4 after an even start: a third of the streak, a quarter of the decline
+	tilt = calculateTiltIndicator([]int{4, 4, 4, 8})
+	expected := 0.6*(1.0/3.0) + 0.4*(2.0/4.0)
+	if math.Abs(tilt-expected) > 1e-9 {
+		t.Errorf("Expected tilt %.2f, got %.2f", expected, tilt)
+	}
+	if calculateTiltIndicator(nil) != 0 {
+		t.Error("Expected no tilt without games")
+	}
+}
+
+func TestAnalyzePerformance_Sessions(t *testing.T) {
+	analyzer := NewProfileAnalyzer()
+	games := sessionTestGames([]int{0, 35, 245, 280, 320}, []int{1, 2, 3, 6, 7})
+
+	performance := analyzer.analyzePerformance(games)
+	if performance.Sessions != 2 {
+		t.Errorf("Expected 2 sessions, got %d", performance.Sessions)
+	}
+	if performance.BotFourStreak != 2 || performance.LongestBotStreak != 2 {
+		t.Errorf("Expected current and longest streak 2, got %d and %d", performance.BotFourStreak, performance.LongestBotStreak)
+	}
+	if performance.SessionTrend != 2.0 {
+		t.Errorf("Expected latest session trend 2.0, got %.2f", performance.SessionTrend)
+	}
+	if performance.TiltIndicator <= 0 {
+		t.Error("Expected tilt from the latest session's bot 4 streak")
+	}
+}
@@ -0,0 +1,366 @@
+package riot
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/fs"
+	"os"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// MatchSource supplies match history, match data, active games and Riot IDs to the analyzer.
+// Sources report missing data with the same "status 404" errors as the live API
+// so callers can treat every source alike.
+type MatchSource interface {
+	// ListMatchIDs pages a player's match IDs on a platform, newest first
+	ListMatchIDs(puuid, region string, start, count int, startTime, endTime *int64) ([]string, error)
+	// GetMatch returns a single match
+	GetMatch(matchID string) (*MatchDto, error)
+	// GetActiveGame returns the player's game in progress; an empty region searches every platform
+	GetActiveGame(puuid, region string) (*CurrentGameInfo, error)
+	// GetAccount returns a player's Riot ID
+	GetAccount(puuid string) (*Account, error)
+}
+
+// errNotFound reports missing data the way the API does
+func errNotFound(format string, args ...interface{}) error {
+	return fmt.Errorf("API request failed with status 404: "+format, args...)
+}
+
+// LiveSource reads from the Riot API
+type LiveSource struct{}
+
+func (LiveSource) ListMatchIDs(puuid, region string, start, count int, startTime, endTime *int64) ([]string, error) {
+	return GetTFTMatchIDsByPUUIDWithRegion(puuid, region, start, count, startTime, endTime)
+}
+
+func (LiveSource) GetMatch(matchID string) (*MatchDto, error) {
+	return GetTFTMatchByID(matchID)
+}
+
+func (LiveSource) GetActiveGame(puuid, region string) (*CurrentGameInfo, error) {
+	return GetActiveTFTGameByPUUIDWithRegionOrDefault(puuid, region)
+}
+
+func (LiveSource) GetAccount(puuid string) (*Account, error) {
+	return GetAccountByPUUID(puuid)
+}
+
+// FixtureSource serves a fixed set of matches and active games
+type FixtureSource struct {
+	matches map[string]*MatchDto
+	games   []*CurrentGameInfo
+}
+
+// NewFixtureSource creates a source over the given matches and active games
+func NewFixtureSource(matches []*MatchDto, games ...*CurrentGameInfo) *FixtureSource {
+	source := &FixtureSource{matches: make(map[string]*MatchDto, len(matches)), games: games}
+	for _, match := range matches {
+		if match != nil {
+			source.matches[match.Metadata.MatchID] = match
+		}
+	}
+	return source
+}
+
+// LoadFixtureSource loads a fixture directory: matches/<matchID>.json holds match-v1
+// responses and any other top-level .json file holds a spectator-v5 active game.
+func LoadFixtureSource(dir string) (*FixtureSource, error) {
+	return loadFixtures(os.DirFS(dir))
+}
+
+// SampleSource returns a fixture source over the embedded sample lobby and its matches
+func SampleSource() (*FixtureSource, error) {
+	samples, err := fs.Sub(sampleFS, "samples")
+	if err != nil {
+		return nil, err
+	}
+	return loadFixtures(samples)
+}
+
+// loadFixtures reads a fixture directory laid out as described by LoadFixtureSource
+func loadFixtures(fsys fs.FS) (*FixtureSource, error) {
+	var matches []*MatchDto
+	entries, err := fs.ReadDir(fsys, "matches")
+	if err != nil && !os.IsNotExist(err) {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		var match MatchDto
+		if err := readFixture(fsys, path.Join("matches", entry.Name()), &match); err != nil {
+			return nil, err
+		}
+		matches = append(matches, &match)
+	}
+
+	var games []*CurrentGameInfo
+	entries, err = fs.ReadDir(fsys, ".")
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), ".json") {
+			continue
+		}
+		var game CurrentGameInfo
+		if err := readFixture(fsys, entry.Name(), &game); err != nil {
+			return nil, err
+		}
+		games = append(games, &game)
+	}
+
+	return NewFixtureSource(matches, games...), nil
+}
+
+// readFixture decodes a JSON fixture file
+func readFixture(fsys fs.FS, name string, result interface{}) error {
+	data, err := fs.ReadFile(fsys, name)
+	if err != nil {
+		return err
+	}
+	if err := json.Unmarshal(data, result); err != nil {
+		return fmt.Errorf("error decoding fixture %s: %w", name, err)
+	}
+	return nil
+}
+
+func (f *FixtureSource) ListMatchIDs(puuid, region string, start, count int, startTime, endTime *int64) ([]string, error) {
+	matches := make([]*MatchDto, 0, len(f.matches))
+	for _, match := range f.matches {
+		matches = append(matches, match)
+	}
+	return pageMatchIDs(matches, puuid, region, start, count, startTime, endTime), nil
+}
+
+func (f *FixtureSource) GetMatch(matchID string) (*MatchDto, error) {
+	match, ok := f.matches[matchID]
+	if !ok {
+		return nil, errNotFound("match %s not in fixtures", matchID)
+	}
+	return match, nil
+}
+
+func (f *FixtureSource) GetActiveGame(puuid, region string) (*CurrentGameInfo, error) {
+	for _, game := range f.games {
+		if region != "" && !strings.EqualFold(game.PlatformID, region) {
+			continue
+		}
+		for _, participant := range game.Participants {
+			if participant.PUUID == puuid {
+				return game, nil
+			}
+		}
+	}
+	return nil, errNotFound("no active game for %s in fixtures", puuid)
+}
+
+func (f *FixtureSource) GetAccount(puuid string) (*Account, error) {
+	matches := make([]*MatchDto, 0, len(f.matches))
+	for _, match := range f.matches {
+		matches = append(matches, match)
+	}
+	if account := accountFromMatches(puuid, matches); account != nil {
+		return account, nil
+	}
+	return nil, errNotFound("no Riot ID for %s in fixtures", puuid)
+}
+
+// ArchiveSource serves matches from a MatchArchive. It has no active games.
+// Listing reads only the player's matches, found with the archive's player index.
+type ArchiveSource struct {
+	Archive *MatchArchive
+}
+
+// NewArchiveSource creates a source over an archive
+func NewArchiveSource(archive *MatchArchive) *ArchiveSource {
+	return &ArchiveSource{Archive: archive}
+}
+
+func (a *ArchiveSource) ListMatchIDs(puuid, region string, start, count int, startTime, endTime *int64) ([]string, error) {
+	ids, err := a.Archive.MatchIDsFor(puuid)
+	if err != nil {
+		return nil, err
+	}
+	var matches []*MatchDto
+	for _, id := range ids {
+		if match, err := a.Archive.Get(id); err == nil {
+			matches = append(matches, match)
+		}
+	}
+	return pageMatchIDs(matches, puuid, region, start, count, startTime, endTime), nil
+}
+
+func (a *ArchiveSource) GetMatch(matchID string) (*MatchDto, error) {
+	if !a.Archive.Has(matchID) {
+		return nil, errNotFound("match %s not archived", matchID)
+	}
+	return a.Archive.Get(matchID)
+}
+
+func (a *ArchiveSource) GetActiveGame(puuid, region string) (*CurrentGameInfo, error) {
+	return nil, errNotFound("the archive has no active games")
+}
+
+func (a *ArchiveSource) GetAccount(puuid string) (*Account, error) {
+	ids, err := a.Archive.MatchIDsFor(puuid)
+	if err != nil {
+		return nil, err
+	}
+	var matches []*MatchDto
+	for _, id := range ids {
+		if match, err := a.Archive.Get(id); err == nil {
+			matches = append(matches, match)
+		}
+	}
+	if account := accountFromMatches(puuid, matches); account != nil {
+		return account, nil
+	}
+	return nil, errNotFound("no Riot ID for %s in the archive", puuid)
+}
+
+// accountFromMatches returns a player's Riot ID from the newest match that has one, or nil
+func accountFromMatches(puuid string, matches []*MatchDto) *Account {
+	var account *Account
+	var newest int64
+	for _, match := range matches {
+		for _, participant := range match.Info.Participants {
+			if participant.PUUID != puuid || participant.RiotIDGameName == "" {
+				continue
+			}
+			if account == nil || match.Info.GameDatetime > newest {
+				account = &Account{PUUID: puuid, GameName: participant.RiotIDGameName, TagLine: participant.RiotIDTagline}
+				newest = match.Info.GameDatetime
+			}
+		}
+	}
+	return account
+}
+
+// pageMatchIDs mimics the API's match history paging over a set of matches. Like the API,
+// history covers every platform sharing the region's routing, so LAS lists LA2_ matches.
+func pageMatchIDs(matches []*MatchDto, puuid, region string, start, count int, startTime, endTime *int64) []string {
+	routing := GetRegionalRoutingURL(strings.ToUpper(region))
+	var played []*MatchDto
+	for _, match := range matches {
+		platform := strings.ToUpper(extractRegionFromMatchID(match.Metadata.MatchID))
+		if GetRegionalRoutingURL(platform) != routing || !hasParticipant(match, puuid) {
+			continue
+		}
+		seconds := match.Info.GameDatetime / 1000
+		if startTime != nil && seconds < *startTime {
+			continue
+		}
+		if endTime != nil && seconds > *endTime {
+			continue
+		}
+		played = append(played, match)
+	}
+	sort.Slice(played, func(i, j int) bool {
+		return played[i].Info.GameDatetime > played[j].Info.GameDatetime
+	})
+
+	ids := []string{}
+	for i := start; i < len(played) && len(ids) < count; i++ {
+		ids = append(ids, played[i].Metadata.MatchID)
+	}
+	return ids
+}
+
+// LayeredSource combines sources. Matches and active games come from the first
+// source that has them; match history is merged across sources.
+type LayeredSource struct {
+	Sources []MatchSource
+}
+
+// NewLayeredSource layers sources in priority order, e.g. archive before live
+func NewLayeredSource(sources ...MatchSource) *LayeredSource {
+	return &LayeredSource{Sources: sources}
+}
+
+func (l *LayeredSource) ListMatchIDs(puuid, region string, start, count int, startTime, endTime *int64) ([]string, error) {
+	// Each source pages independently, so take enough from each to cover the merged page
+	seen := make(map[string]bool)
+	var merged []string
+	var firstErr error
+	succeeded := false
+	for _, source := range l.Sources {
+		ids, err := source.ListMatchIDs(puuid, region, 0, start+count, startTime, endTime)
+		if err != nil {
+			if firstErr == nil {
+				firstErr = err
+			}
+			continue
+		}
+		succeeded = true
+		for _, id := range ids {
+			if !seen[id] {
+				seen[id] = true
+				merged = append(merged, id)
+			}
+		}
+	}
+	if !succeeded {
+		if firstErr == nil {
+			firstErr = errNotFound("no sources configured")
+		}
+		return nil, firstErr
+	}
+
+	// Match IDs on a platform increase over time, so sort newest first by number
+	sort.SliceStable(merged, func(i, j int) bool {
+		return matchIDNumber(merged[i]) > matchIDNumber(merged[j])
+	})
+	if start >= len(merged) {
+		return []string{}, nil
+	}
+	end := start + count
+	if end > len(merged) {
+		end = len(merged)
+	}
+	return merged[start:end], nil
+}
+
+func (l *LayeredSource) GetMatch(matchID string) (*MatchDto, error) {
+	err := errNotFound("no sources configured")
+	for _, source := range l.Sources {
+		var match *MatchDto
+		if match, err = source.GetMatch(matchID); err == nil {
+			return match, nil
+		}
+	}
+	return nil, err
+}
+
+func (l *LayeredSource) GetActiveGame(puuid, region string) (*CurrentGameInfo, error) {
+	err := errNotFound("no sources configured")
+	for _, source := range l.Sources {
+		var game *CurrentGameInfo
+		if game, err = source.GetActiveGame(puuid, region); err == nil {
+			return game, nil
+		}
+	}
+	return nil, err
+}
+
+func (l *LayeredSource) GetAccount(puuid string) (*Account, error) {
+	err := errNotFound("no sources configured")
+	for _, source := range l.Sources {
+		var account *Account
+		if account, err = source.GetAccount(puuid); err == nil {
+			return account, nil
+		}
+	}
+	return nil, err
+}
+
+// matchIDNumber returns the numeric part of a match ID, e.g. "NA1_5359015295" -> 5359015295
+func matchIDNumber(matchID string) int64 {
+	n, _ := strconv.ParseInt(matchID[strings.LastIndex(matchID, "_")+1:], 10, 64)
+	return n
+}
@@ -0,0 +1,138 @@
+package riot
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+)
+
+func TestFixtureSource_PagesNewestFirst(t *testing.T) {
+	source := NewFixtureSource([]*MatchDto{stateTestMatch(1, 1, 1100), stateTestMatch(3, 3, 1100), stateTestMatch(2, 2, 1100)})
+
+	ids, err := source.ListMatchIDs("test", "NA1", 1, 5, nil, nil)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if len(ids) != 2 || ids[0] != "NA1_2" || ids[1] != "NA1_1" {
+		t.Errorf("Expected [NA1_2 NA1_1] after skipping the newest, got %v", ids)
+	}
+	if ids, _ := source.ListMatchIDs("test", "EUW1", 0, 5, nil, nil); len(ids) != 0 {
+		t.Errorf("Expected no matches in another region, got %v", ids)
+	}
+	if _, err := source.GetMatch("NA1_9"); err == nil {
+		t.Error("Expected error for unknown match")
+	}
+
+	// History covers every platform with the same routing, whatever the region alias
+	las := stateTestMatch(4, 4, 1100)
+	las.Metadata.MatchID = "LA2_4"
+	eune := stateTestMatch(5, 5, 1100)
+	eune.Metadata.MatchID = "EUN1_5"
+	routed := NewFixtureSource([]*MatchDto{las, eune, stateTestMatch(1, 1, 1100)})
+	for region, want := range map[string]string{"LAS": "[LA2_4 NA1_1]", "NA1": "[LA2_4 NA1_1]", "EUNE": "[EUN1_5]", "KR": "[]"} {
+		if ids, _ := routed.ListMatchIDs("test", region, 0, 5, nil, nil); fmt.Sprint(ids) != want {
+			t.Errorf("For %s, expected %s, got %v", region, want, ids)
+		}
+	}
+}
+
+func TestLoadFixtureSource(t *testing.T) {
+	source, err := LoadFixtureSource("samples")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	game, err := LoadSampleActiveGame()
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	puuid := game.Participants[0].PUUID
+
+	active, err := source.GetActiveGame(puuid, "")
+	if err != nil || active.GameID != game.GameID {
+		t.Fatalf("Expected the sample active game, got %v (%v)", active, err)
+	}
+	if _, err := source.GetActiveGame(puuid, "EUW1"); err == nil || !strings.Contains(err.Error(), "status 404") {
+		t.Errorf("Expected a 404 in another region, got %v", err)
+	}
+
+	ids, err := source.ListMatchIDs(puuid, "NA1", 0, 20, nil, nil)
+	if err != nil || len(ids) != 8 {
+		t.Fatalf("Expected 8 sample matches, got %d (%v)", len(ids), err)
+	}
+	if _, err := source.GetMatch(ids[0]); err != nil {
+		t.Errorf("Expected listed match to load: %v", err)
+	}
+}
+
+func TestArchiveSource(t *testing.T) {
+	archive, err := NewMatchArchive(t.TempDir())
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	for h := 1; h <= 3; h++ {
+		if err := archive.Put(stateTestMatch(h, h, 1100)); err != nil {
+			t.Fatalf("Unexpected error: %v", err)
+		}
+	}
+	source := NewArchiveSource(archive)
+
+	ids, err := source.ListMatchIDs("test", "NA1", 0, 2, nil, nil)
+	if err != nil || fmt.Sprint(ids) != "[NA1_3 NA1_2]" {
+		t.Errorf("Expected [NA1_3 NA1_2], got %v (%v)", ids, err)
+	}
+	if _, err := source.GetMatch("NA1_9"); err == nil || !strings.Contains(err.Error(), "status 404") {
+		t.Errorf("Expected a 404 for a missing match, got %v", err)
+	}
+	if _, err := source.GetActiveGame("test", ""); err == nil {
+		t.Error("Expected the archive to have no active games")
+	}
+
+	// Riot IDs come from the player's newest archived match that has one
+	named := stateTestMatch(4, 1, 1100)
+	named.Info.Participants[0].RiotIDGameName = "Tester"
+	named.Info.Participants[0].RiotIDTagline = "NA1"
+	if err := archive.Put(named); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	account, err := source.GetAccount("test")
+	if err != nil || account.GameName != "Tester" || account.TagLine != "NA1" {
+		t.Errorf("Expected Tester#NA1, got %+v (%v)", account, err)
+	}
+	if _, err := source.GetAccount("nobody"); err == nil || !strings.Contains(err.Error(), "status 404") {
+		t.Errorf("Expected a 404 for an unknown player, got %v", err)
+	}
+}
+
+func TestLayeredSource(t *testing.T) {
+	older := NewFixtureSource([]*MatchDto{stateTestMatch(1, 1, 1100), stateTestMatch(2, 2, 1100)})
+	newer := NewFixtureSource([]*MatchDto{stateTestMatch(2, 8, 1100), stateTestMatch(3, 3, 1100)})
+	layered := NewLayeredSource(older, newer)
+
+	ids, err := layered.ListMatchIDs("test", "NA1", 0, 5, nil, nil)
+	if err != nil || fmt.Sprint(ids) != "[NA1_3 NA1_2 NA1_1]" {
+		t.Errorf("Expected merged history [NA1_3 NA1_2 NA1_1], got %v (%v)", ids, err)
+	}
+	if ids, _ := layered.ListMatchIDs("test", "NA1", 1, 1, nil, nil); fmt.Sprint(ids) != "[NA1_2]" {
+		t.Errorf("Expected second page [NA1_2], got %v", ids)
+	}
+
+	// The first source holding a match wins
+	match, err := layered.GetMatch("NA1_2")
+	if err != nil || match.Info.Participants[0].Placement != 2 {
+		t.Errorf("Expected NA1_2 from the first source, got %+v (%v)", match, err)
+	}
+	if _, err := layered.GetMatch("NA1_3"); err != nil {
+		t.Errorf("Expected fallback to the second source: %v", err)
+	}
+	if _, err := NewLayeredSource().GetMatch("NA1_1"); err == nil {
+		t.Error("Expected error without sources")
+	}
+
+	// Riot IDs come from the first source that knows the player
+	named := stateTestMatch(4, 4, 1100)
+	named.Info.Participants[0].RiotIDGameName = "Tester"
+	layered = NewLayeredSource(older, NewFixtureSource([]*MatchDto{named}))
+	if account, err := layered.GetAccount("test"); err != nil || account.GameName != "Tester" {
+		t.Errorf("Expected Tester from the second source, got %+v (%v)", account, err)
+	}
+}
@@ -0,0 +1,139 @@
+package riot
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// profileState is the aggregate a profile is computed from, kept alongside the
+// cached profile so a refresh only has to fetch games played since the last update.
+// It stores each in-scope game's line rather than running totals so the window can
+// slide: when new games arrive, games beyond the requested count fall out exactly.
+type profileState struct {
+	region    string          // platform region the match history was found in
+	seen      map[string]bool // every match ID already inspected, including out-of-scope ones
+	games     []playerGame    // in-scope games, oldest first
+	updatedAt time.Time
+}
+
+// newProfileState creates an empty state for match history found in region
+func newProfileState(region string) *profileState {
+	return &profileState{
+		region: region,
+		seen:   make(map[string]bool),
+	}
+}
+
+// clone returns a copy that can be updated without affecting readers of the original
+func (st *profileState) clone() *profileState {
+	out := newProfileState(st.region)
+	for id := range st.seen {
+		out.seen[id] = true
+	}
+	out.games = make([]playerGame, len(st.games))
+	copy(out.games, st.games)
+	out.updatedAt = st.updatedAt
+	return out
+}
+
+// add folds a match into the state if it is in scope and contains the player.
+// Returns false if the match had already been folded in.
+func (st *profileState) add(puuid string, match *MatchDto, opts AnalysisOptions) bool {
+	if match == nil || st.seen[match.Metadata.MatchID] {
+		return false
+	}
+	st.seen[match.Metadata.MatchID] = true
+
+	if !opts.includes(match) {
+		return true
+	}
+	st.games = append(st.games, extractPlayerGames(puuid, []*MatchDto{match})...)
+	return true
+}
+
+// trim restores chronological order and keeps only the most recent count games
+func (st *profileState) trim(count int) {
+	sort.SliceStable(st.games, func(i, j int) bool {
+		return st.games[i].GameDatetime < st.games[j].GameDatetime
+	})
+	if count > 0 && len(st.games) > count {
+		st.games = st.games[len(st.games)-count:]
+	}
+}
+
+// newestGameTime returns the start time of the most recent game, or zero if empty
+func (st *profileState) newestGameTime() time.Time {
+	var newest int64
+	for _, game := range st.games {
+		if game.GameDatetime > newest {
+			newest = game.GameDatetime
+		}
+	}
+	if newest == 0 {
+		return time.Time{}
+	}
+	return time.UnixMilli(newest)
+}
+
+// buildProfileState lists the player's match history and fetches games until
+// the options' count is reached.
+func (pa *ProfileAnalyzer) buildProfileState(puuid string, opts AnalysisOptions) (*profileState, error) {
+	matchIDs, region, err := pa.listMatchIDs(puuid, opts)
+	if err != nil {
+		return nil, err
+	}
+	if len(matchIDs) < pa.MinGamesRequired {
+		return nil, fmt.Errorf("insufficient games for analysis: %d (minimum %d)", len(matchIDs), pa.MinGamesRequired)
+	}
+
+	state := newProfileState(region)
+	for _, matchID := range matchIDs {
+		if len(state.games) >= opts.Count {
+			break
+		}
+		match, err := pa.getMatch(matchID)
+		if err != nil {
+			continue // skip failed matches
+		}
+		state.add(puuid, match, opts)
+	}
+
+	state.trim(opts.Count)
+	state.updatedAt = time.Now()
+	return state, nil
+}
+
+// refreshProfileState fetches only the matches played since the newest game in
+// prev and folds them in. On API errors the previous state is returned unchanged.
+func (pa *ProfileAnalyzer) refreshProfileState(puuid string, prev *profileState, opts AnalysisOptions) *profileState {
+	since := prev.newestGameTime()
+	if since.IsZero() || opts.StartTime.After(since) {
+		since = opts.StartTime
+	}
+	window := opts
+	window.StartTime = since
+	startTime, endTime := window.epochRange()
+
+	matchIDs, err := pa.fetchMatchIDs(puuid, prev.region, opts.scanLimit(), startTime, endTime)
+	if err != nil {
+		fmt.Printf("Error refreshing match history for %s: %v\n", puuid, err)
+		return prev
+	}
+
+	state := prev.clone()
+	for _, matchID := range matchIDs {
+		if state.seen[matchID] {
+			continue
+		}
+		match, err := pa.getMatch(matchID)
+		if err != nil {
+			continue // skip failed matches
+		}
+		state.add(puuid, match, opts)
+	}
+
+	state.trim(opts.Count)
+	state.updatedAt = time.Now()
+	return state
+}
@@ -0,0 +1,112 @@
+package riot
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// stateTestMatch builds a match the player "test" finished in the given place at hour h
+func stateTestMatch(h int, placement int, queueID int) *MatchDto {
+	return &MatchDto{
+		Metadata: MetadataDto{MatchID: fmt.Sprintf("NA1_%d", h)},
+		Info: InfoDto{
+			GameDatetime: time.Date(2025, 8, 1, h, 0, 0, 0, time.UTC).UnixMilli(),
+			QueueID:      queueID,
+			Participants: []ParticipantDto{{PUUID: "test", Placement: placement}},
+		},
+	}
+}
+
+func TestProfileState_AddSkipsSeenAndOutOfScope(t *testing.T) {
+	state := newProfileState("NA1")
+	opts := AnalysisOptions{Count: 10, QueueID: 1100}
+
+	if !state.add("test", stateTestMatch(1, 4, 1100), opts) {
+		t.Error("Expected first add to fold in the match")
+	}
+	if state.add("test", stateTestMatch(1, 4, 1100), opts) {
+		t.Error("Expected duplicate match to be skipped")
+	}
+	state.add("test", stateTestMatch(2, 1, 1090), opts)
+
+	if len(state.games) != 1 {
+		t.Errorf("Expected 1 in-scope game, got %d", len(state.games))
+	}
+	if !state.seen["NA1_2"] {
+		t.Error("Expected out-of-scope match to be marked seen so it isn't refetched")
+	}
+}
+
+func TestProfileState_TrimKeepsNewestInOrder(t *testing.T) {
+	state := newProfileState("NA1")
+	opts := AnalysisOptions{Count: 3}
+
+	// Add newest-first, as the API returns them, then fold in a newer refresh
+	for _, h := range []int{4, 3, 2, 1} {
+		state.add("test", stateTestMatch(h, h, 1100), opts)
+	}
+	state.trim(opts.Count)
+
+	refreshed := state.clone()
+	refreshed.add("test", stateTestMatch(5, 5, 1100), opts)
+	refreshed.trim(opts.Count)
+
+	if len(state.games) != 3 || state.games[0].MatchID != "NA1_2" {
+		t.Errorf("Expected original state to keep games 2-4, got %+v", state.games)
+	}
+
+	var ids []string
+	for _, game := range refreshed.games {
+		ids = append(ids, game.MatchID)
+	}
+	if fmt.Sprint(ids) != "[NA1_3 NA1_4 NA1_5]" {
+		t.Errorf("Expected refreshed window [NA1_3 NA1_4 NA1_5], got %v", ids)
+	}
+	if !refreshed.newestGameTime().Equal(time.Date(2025, 8, 1, 5, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected newest game time %s", refreshed.newestGameTime())
+	}
+	if !newProfileState("NA1").newestGameTime().IsZero() {
+		t.Error("Expected zero newest game time for empty state")
+	}
+}
+
+func TestProfileFromGames_RecordsMatchIDs(t *testing.T) {
+	analyzer := NewProfileAnalyzer()
+	state := newProfileState("NA1")
+	opts := analyzer.normalizeOptions(AnalysisOptions{})
+	for h := 1; h <= 5; h++ {
+		state.add("test", stateTestMatch(h, h, 1100), opts)
+	}
+	state.trim(opts.Count)
+
+	profile := analyzer.profileFromGames("test", state.games, opts)
+	if profile.AnalyzedGames != 5 || len(profile.MatchIDs) != 5 {
+		t.Fatalf("Expected 5 games and match IDs, got %d and %d", profile.AnalyzedGames, len(profile.MatchIDs))
+	}
+	if profile.MatchIDs[4] != "NA1_5" {
+		t.Errorf("Expected newest match last, got %v", profile.MatchIDs)
+	}
+	if profile.PlayStyle.AveragePlacement != 3.0 {
+		t.Errorf("Expected average placement 3.00, got %.2f", profile.PlayStyle.AveragePlacement)
+	}
+}
+
+func TestCache_ProfileState(t *testing.T) {
+	cache := NewDefaultCache()
+	state := newProfileState("NA1")
+
+	cache.setProfileState("key", state)
+	if got, ok := cache.getProfileState("key"); !ok || got != state {
+		t.Error("Expected stored state to be returned")
+	}
+	if _, ok := cache.getProfileState("other"); ok {
+		t.Error("Expected miss for unknown key")
+	}
+
+	var nilCache *Cache
+	nilCache.setProfileState("key", state)
+	if _, ok := nilCache.getProfileState("key"); ok {
+		t.Error("Expected nil cache to miss")
+	}
+}
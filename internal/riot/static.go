@@ -0,0 +1,136 @@
+package riot
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+	"unicode"
+)
+
+// DataDragonVersion is the Data Dragon release the bot reads static data and icons from
+const DataDragonVersion = "15.17.1"
+
+// DataDragonURL is the Data Dragon CDN; tests point it at a local server
+var DataDragonURL = "https://ddragon.leagueoflegends.com/cdn"
+
+// StaticData maps TFT champion, trait and item IDs from match data to display names.
+// Lookups fall back to a name derived from the ID, so a nil *StaticData is usable.
+type StaticData struct {
+	Champions map[string]string // e.g. "TFT15_Ahri" -> "Ahri"
+	Traits    map[string]string // e.g. "TFT15_BattleAcademia" -> "Battle Academia"
+	Items     map[string]string // e.g. "TFT_Item_InfinityEdge" -> "Infinity Edge"
+}
+
+// dataDragonFile is the shape shared by Data Dragon's tft-champion, tft-trait and tft-item files
+type dataDragonFile struct {
+	Data map[string]struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"data"`
+}
+
+// LoadStaticData downloads the champion, trait and item names of a Data Dragon version
+func LoadStaticData(version string) (*StaticData, error) {
+	data := &StaticData{}
+	for file, names := range map[string]*map[string]string{
+		"tft-champion": &data.Champions,
+		"tft-trait":    &data.Traits,
+		"tft-item":     &data.Items,
+	} {
+		loaded, err := loadDataDragonNames(version, file)
+		if err != nil {
+			return nil, err
+		}
+		*names = loaded
+	}
+	return data, nil
+}
+
+// loadDataDragonNames reads one Data Dragon file into an ID -> name map
+func loadDataDragonNames(version, file string) (map[string]string, error) {
+	url := fmt.Sprintf("%s/%s/data/en_US/%s.json", DataDragonURL, version, file)
+	resp, err := httpClient.Load().Get(url)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		_ = resp.Body.Close()
+	}()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("static data request for %s failed with status %d", file, resp.StatusCode)
+	}
+
+	var parsed dataDragonFile
+	if err := json.NewDecoder(resp.Body).Decode(&parsed); err != nil {
+		return nil, fmt.Errorf("error decoding %s: %w", file, err)
+	}
+
+	// Champion entries are keyed by asset path, so index by their ID instead
+	names := make(map[string]string, len(parsed.Data))
+	for key, entry := range parsed.Data {
+		id := entry.ID
+		if id == "" {
+			id = key
+		}
+		names[id] = entry.Name
+	}
+	return names, nil
+}
+
+// ChampionName returns a champion's display name, e.g. "TFT15_Ahri" -> "Ahri"
+func (d *StaticData) ChampionName(id string) string {
+	if d != nil {
+		if name, ok := d.Champions[id]; ok {
+			return name
+		}
+	}
+	return splitWords(stripSetPrefix(id))
+}
+
+// TraitName returns a trait's display name, e.g. "TFT15_BattleAcademia" -> "Battle Academia"
+func (d *StaticData) TraitName(id string) string {
+	if d != nil {
+		if name, ok := d.Traits[id]; ok {
+			return name
+		}
+	}
+	return splitWords(stripSetPrefix(id))
+}
+
+// ItemName returns an item's display name, e.g. "TFT_Item_InfinityEdge" -> "Infinity Edge"
+func (d *StaticData) ItemName(id string) string {
+	if d != nil {
+		if name, ok := d.Items[id]; ok {
+			return name
+		}
+	}
+	if index := strings.Index(id, "Item_"); index != -1 {
+		id = id[index+len("Item_"):]
+	}
+	return splitWords(stripSetPrefix(id))
+}
+
+// stripSetPrefix removes a "TFT15_"-style prefix from an ID
+func stripSetPrefix(id string) string {
+	if strings.HasPrefix(id, "TFT") {
+		if index := strings.Index(id, "_"); index != -1 {
+			return id[index+1:]
+		}
+	}
+	return id
+}
+
+// splitWords spaces out a CamelCase name, e.g. "BattleAcademia" -> "Battle Academia"
+func splitWords(name string) string {
+	var words strings.Builder
+	runes := []rune(name)
+	for idx, r := range runes {
+		if idx > 0 && unicode.IsUpper(r) && unicode.IsLower(runes[idx-1]) {
+			words.WriteRune(' ')
+		}
+		words.WriteRune(r)
+	}
+	return words.String()
+}
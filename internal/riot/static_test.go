@@ -0,0 +1,61 @@
+package riot
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+)
+
+func TestLoadStaticData(t *testing.T) {
+	files := map[string]string{
+		"/15.17.1/data/en_US/tft-champion.json": `{"data":{"Maps/Shipping/Map22/Sets/TFTSet15/Shop/TFT15_Ahri":{"id":"TFT15_Ahri","name":"Ahri"}}}`,
+		"/15.17.1/data/en_US/tft-trait.json":    `{"data":{"TFT15_BattleAcademia":{"id":"TFT15_BattleAcademia","name":"Battle Academia"}}}`,
+		"/15.17.1/data/en_US/tft-item.json":     `{"data":{"TFT_Item_InfinityEdge":{"id":"TFT_Item_InfinityEdge","name":"Infinity Edge"}}}`,
+	}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, ok := files[r.URL.Path]
+		if !ok {
+			http.NotFound(w, r)
+			return
+		}
+		_, _ = w.Write([]byte(body))
+	}))
+	defer server.Close()
+
+	previous := DataDragonURL
+	DataDragonURL = server.URL
+	defer func() { DataDragonURL = previous }()
+
+	data, err := LoadStaticData("15.17.1")
+	if err != nil {
+		t.Fatalf("Failed to load static data: %v", err)
+	}
+	if name := data.ChampionName("TFT15_Ahri"); name != "Ahri" {
+		t.Errorf("Expected champion name by ID, got %q", name)
+	}
+	if name := data.TraitName("TFT15_BattleAcademia"); name != "Battle Academia" {
+		t.Errorf("Expected trait name, got %q", name)
+	}
+	if name := data.ItemName("TFT_Item_InfinityEdge"); name != "Infinity Edge" {
+		t.Errorf("Expected item name, got %q", name)
+	}
+
+	if _, err := LoadStaticData("0.0.0"); err == nil {
+		t.Error("Expected an error for a missing version")
+	}
+}
+
+func TestStaticDataFallbackNames(t *testing.T) {
+	var data *StaticData
+	tests := []struct{ got, want string }{
+		{data.ChampionName("TFT15_KaiSa"), "Kai Sa"},
+		{data.TraitName("TFT15_SoulFighter"), "Soul Fighter"},
+		{data.ItemName("TFT_Item_GuinsoosRageblade"), "Guinsoos Rageblade"},
+		{data.ItemName("TFT15_Item_Emblem"), "Emblem"},
+	}
+	for _, tt := range tests {
+		if tt.got != tt.want {
+			t.Errorf("Expected %q, got %q", tt.want, tt.got)
+		}
+	}
+}
@@ -1,37 +1,30 @@
 package riot
 
 import (
-	"os"
 	"testing"
 )
 
 func TestGetSummonerByPUUID_Success(t *testing.T) {
-	if os.Getenv("RIOT_API_KEY") == "" {
-		t.Skip("RIOT_API_KEY not set")
-	}
-
-	account, err := GetAccountByRiotId("mubs", "NA1")
-	if err != nil {
-		t.Fatalf("Failed to get account for test: %v", err)
-	}
+	newFakeAPI(t)
 
-	summoner, err := GetSummonerByPUUID(account.PUUID)
+	summoner, err := GetSummonerByPUUID(testPUUID)
 	if err != nil {
 		t.Fatalf("Failed to get summoner: %v", err)
 	}
 
-	if summoner.PUUID != account.PUUID {
-		t.Errorf("Expected PUUID %s, got %s", account.PUUID, summoner.PUUID)
+	if summoner.PUUID != testPUUID {
+		t.Errorf("Expected PUUID %s, got %s", testPUUID, summoner.PUUID)
 	}
 	if summoner.SummonerLevel <= 0 {
 		t.Error("Summoner level should be positive")
 	}
+	if summoner.ProfileIconID != 5331 {
+		t.Errorf("Expected profile icon from the sample active game, got %d", summoner.ProfileIconID)
+	}
 }
 
 func TestGetSummonerByPUUID_InvalidPUUID(t *testing.T) {
-	if os.Getenv("RIOT_API_KEY") == "" {
-		t.Skip("RIOT_API_KEY not set")
-	}
+	newFakeAPI(t)
 
 	_, err := GetSummonerByPUUID("invalid-puuid-12345")
 	if err == nil {
@@ -40,17 +33,15 @@ func TestGetSummonerByPUUID_InvalidPUUID(t *testing.T) {
 }
 
 func TestGetSummonerByRiotId_Success(t *testing.T) {
-	if os.Getenv("RIOT_API_KEY") == "" {
-		t.Skip("RIOT_API_KEY not set")
-	}
+	newFakeAPI(t)
 
-	summoner, account, err := GetSummonerByRiotId("mubs", "NA1")
+	summoner, account, err := GetSummonerByRiotId(testGameName, testTagLine)
 	if err != nil {
 		t.Fatalf("Failed to get summoner by Riot ID: %v", err)
 	}
 
-	if account.GameName != "mubs" {
-		t.Errorf("Expected game name mubs, got %s", account.GameName)
+	if account.GameName != testGameName {
+		t.Errorf("Expected game name %s, got %s", testGameName, account.GameName)
 	}
 	if summoner.PUUID != account.PUUID {
 		t.Errorf("Summoner PUUID should match account PUUID")
@@ -61,28 +52,20 @@ func TestGetSummonerByRiotId_Success(t *testing.T) {
 }
 
 func TestGetSummonerByRiotId_InvalidAccount(t *testing.T) {
-	if os.Getenv("RIOT_API_KEY") == "" {
-		t.Skip("RIOT_API_KEY not set")
-	}
+	newFakeAPI(t)
 
 	_, _, err := GetSummonerByRiotId("NonExistentPlayer123456", "NA1")
 	if err == nil {
 		t.Error("Expected error for non-existent account")
 	}
 }
-func BenchmarkGetSummonerByPUUID(b *testing.B) {
-	if os.Getenv("RIOT_API_KEY") == "" {
-		b.Skip("RIOT_API_KEY not set")
-	}
 
-	account, err := GetAccountByRiotId("mubs", "NA1")
-	if err != nil {
-		b.Fatalf("Failed to get account for benchmark: %v", err)
-	}
+func BenchmarkGetSummonerByPUUID(b *testing.B) {
+	newFakeAPI(b)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, err := GetSummonerByPUUID(account.PUUID)
+		_, err := GetSummonerByPUUID(testPUUID)
 		if err != nil {
 			b.Fatalf("Benchmark failed: %v", err)
 		}
@@ -90,13 +73,11 @@ func BenchmarkGetSummonerByPUUID(b *testing.B) {
 }
 
 func BenchmarkGetSummonerByRiotId(b *testing.B) {
-	if os.Getenv("RIOT_API_KEY") == "" {
-		b.Skip("RIOT_API_KEY not set")
-	}
+	newFakeAPI(b)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, _, err := GetSummonerByRiotId("mubs", "NA1")
+		_, _, err := GetSummonerByRiotId(testGameName, testTagLine)
 		if err != nil {
 			b.Fatalf("Benchmark failed: %v", err)
 		}
@@ -72,28 +72,37 @@ func GetTFTMatchIDsByPUUIDSimple(puuid string) ([]string, error) {
 	return GetTFTMatchIDsByPUUID(puuid, 0, 20, nil, nil)
 }
 
-// GetActiveTFTGameByPUUID returns current game information for the given PUUID.
-// It first probes match history to infer the player's platform, then queries spectator on that platform.
-// Falls back to the previous multi-region scan if platform detection fails.
-func GetActiveTFTGameByPUUID(puuid string) (*CurrentGameInfo, error) {
-	// Probe across regional routing representatives to infer platform via match ID prefix.
-	// NA1 -> AMERICAS routing, EUW1 -> EUROPE routing, KR -> ASIA routing (covers SEA).
-	probes := []string{"NA1", "EUW1", "KR"}
+// DetectPlatform finds the platform a player plays on (e.g. "NA1") from the prefix of their
+// latest match ID, probing one platform per regional cluster. Returns a 404 error when no
+// cluster has matches for the player.
+func DetectPlatform(puuid string) (string, error) {
+	// NA1 -> AMERICAS routing, EUW1 -> EUROPE routing, KR -> ASIA routing, SG2 -> SEA routing.
+	// A cluster without matches for the player moves on to the next one.
+	probes := []string{"NA1", "EUW1", "KR", "SG2"}
 	for _, probe := range probes {
 		ids, err := GetTFTMatchIDsByPUUIDWithRegion(puuid, probe, 0, 1, nil, nil)
 		if err == nil {
-			platform := probe
-			if len(ids) > 0 {
-				platform = extractRegionFromMatchID(ids[0]) // e.g., "NA1_..." -> "NA1"
+			if len(ids) == 0 {
+				continue
 			}
-			return GetActiveTFTGameByPUUIDWithRegion(puuid, platform)
+			return extractRegionFromMatchID(ids[0]), nil // e.g., "NA1_..." -> "NA1"
 		}
 		// On auth errors, no point in continuing the probe loop.
 		msg := err.Error()
 		if strings.Contains(msg, "status 401") || strings.Contains(msg, "status 403") {
-			break
+			return "", err
 		}
 	}
+	return "", errNotFound("no matches found for %s", puuid)
+}
+
+// GetActiveTFTGameByPUUID returns current game information for the given PUUID.
+// It first probes match history to infer the player's platform, then queries spectator on that platform.
+// Falls back to the previous multi-region scan if platform detection fails.
+func GetActiveTFTGameByPUUID(puuid string) (*CurrentGameInfo, error) {
+	if platform, err := DetectPlatform(puuid); err == nil {
+		return GetActiveTFTGameByPUUIDWithRegion(puuid, platform)
+	}
 
 	// Fallback: try common platforms in order of popularity
 	regions := []string{"NA1", "EUW1", "KR", "BR1", "LAS", "LAN", "EUNE", "OC1", "JP1", "TR1", "RU", "PH2", "SG2", "TH2", "TW2", "VN2"}
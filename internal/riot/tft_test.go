@@ -1,28 +1,21 @@
 package riot
 
 import (
-	"os"
 	"strings"
 	"testing"
+
+	"github.com/hunterjsb/tft/internal/riot/riottest"
 )
 
 func TestGetTFTMatchByID_Success(t *testing.T) {
-	if os.Getenv("RIOT_API_KEY") == "" {
-		t.Skip("RIOT_API_KEY not set")
-	}
-
-	account, err := GetAccountByRiotId("mubs", "NA1")
-	if err != nil {
-		t.Fatalf("Failed to get account for test: %v", err)
-	}
+	newFakeAPI(t)
 
-	matchIDs, err := GetTFTMatchIDsByPUUIDSimple(account.PUUID)
+	matchIDs, err := GetTFTMatchIDsByPUUIDSimple(testPUUID)
 	if err != nil {
 		t.Fatalf("Failed to get match IDs: %v", err)
 	}
-
 	if len(matchIDs) == 0 {
-		t.Skip("No TFT matches found for test account")
+		t.Fatal("Expected sample matches for test account")
 	}
 
 	match, err := GetTFTMatchByID(matchIDs[0])
@@ -42,9 +35,7 @@ func TestGetTFTMatchByID_Success(t *testing.T) {
 }
 
 func TestGetTFTMatchByID_InvalidMatchID(t *testing.T) {
-	if os.Getenv("RIOT_API_KEY") == "" {
-		t.Skip("RIOT_API_KEY not set")
-	}
+	newFakeAPI(t)
 
 	_, err := GetTFTMatchByID("INVALID_MATCH_ID")
 	if err == nil {
@@ -53,77 +44,73 @@ func TestGetTFTMatchByID_InvalidMatchID(t *testing.T) {
 }
 
 func TestGetTFTMatchIDsByPUUIDSimple_Success(t *testing.T) {
-	if os.Getenv("RIOT_API_KEY") == "" {
-		t.Skip("RIOT_API_KEY not set")
-	}
-
-	account, err := GetAccountByRiotId("mubs", "NA1")
-	if err != nil {
-		t.Fatalf("Failed to get account: %v", err)
-	}
+	newFakeAPI(t)
 
-	matchIDs, err := GetTFTMatchIDsByPUUIDSimple(account.PUUID)
+	matchIDs, err := GetTFTMatchIDsByPUUIDSimple(testPUUID)
 	if err != nil {
 		t.Fatalf("Failed to get match IDs: %v", err)
 	}
 
-	if len(matchIDs) > 20 {
-		t.Errorf("Should return at most 20 matches, got %d", len(matchIDs))
+	if len(matchIDs) != 8 {
+		t.Fatalf("Expected all 8 sample matches, got %d", len(matchIDs))
+	}
+	if matchIDs[0] != "NA1_5359015242" || matchIDs[7] != "NA1_5359014998" {
+		t.Errorf("Expected newest match first, got %v", matchIDs)
 	}
 }
 
 func TestGetTFTMatchIDsByPUUID_CustomParameters(t *testing.T) {
-	if os.Getenv("RIOT_API_KEY") == "" {
-		t.Skip("RIOT_API_KEY not set")
-	}
+	newFakeAPI(t)
 
-	account, err := GetAccountByRiotId("mubs", "NA1")
+	matchIDs, err := GetTFTMatchIDsByPUUID(testPUUID, 2, 3, nil, nil)
 	if err != nil {
-		t.Fatalf("Failed to get account: %v", err)
+		t.Fatalf("Failed to get match IDs with custom count: %v", err)
+	}
+	if len(matchIDs) != 3 || matchIDs[0] != "NA1_5359015155" {
+		t.Errorf("Expected 3 matches starting at the third newest, got %v", matchIDs)
 	}
 
-	matchIDs, err := GetTFTMatchIDsByPUUID(account.PUUID, 0, 5, nil, nil)
+	// Sample games are roughly 40 minutes apart; keep only the newest two
+	startTime := int64(1756595996)
+	matchIDs, err = GetTFTMatchIDsByPUUID(testPUUID, 0, 20, &startTime, nil)
 	if err != nil {
-		t.Fatalf("Failed to get match IDs with custom count: %v", err)
+		t.Fatalf("Failed to get match IDs with start time: %v", err)
 	}
-
-	if len(matchIDs) > 5 {
-		t.Errorf("Should return at most 5 matches, got %d", len(matchIDs))
+	if len(matchIDs) != 2 {
+		t.Errorf("Expected 2 matches since start time, got %v", matchIDs)
 	}
 }
 
 func TestGetTFTMatchIDsByPUUID_InvalidPUUID(t *testing.T) {
-	if os.Getenv("RIOT_API_KEY") == "" {
-		t.Skip("RIOT_API_KEY not set")
-	}
+	newFakeAPI(t)
 
-	_, err := GetTFTMatchIDsByPUUIDSimple("invalid-puuid")
-	if err == nil {
-		t.Error("Expected error for invalid PUUID")
+	matchIDs, err := GetTFTMatchIDsByPUUIDSimple("invalid-puuid")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
 	}
-}
-
-func BenchmarkGetTFTMatchByID(b *testing.B) {
-	if os.Getenv("RIOT_API_KEY") == "" {
-		b.Skip("RIOT_API_KEY not set")
+	if len(matchIDs) != 0 {
+		t.Errorf("Expected no matches for unknown PUUID, got %v", matchIDs)
 	}
+}
 
-	account, err := GetAccountByRiotId("mubs", "NA1")
-	if err != nil {
-		b.Fatalf("Failed to get account: %v", err)
-	}
+func TestGetTFTMatchIDsByPUUIDWithRegion_OtherCluster(t *testing.T) {
+	newFakeAPI(t)
 
-	matchIDs, err := GetTFTMatchIDsByPUUIDSimple(account.PUUID)
+	matchIDs, err := GetTFTMatchIDsByPUUIDWithRegion(testPUUID, "EUW1", 0, 20, nil, nil)
 	if err != nil {
-		b.Fatalf("Failed to get match IDs: %v", err)
+		t.Fatalf("Unexpected error: %v", err)
 	}
-	if len(matchIDs) == 0 {
-		b.Skip("No matches available for benchmark")
+	if len(matchIDs) != 0 {
+		t.Errorf("Expected NA1 matches to be absent from the Europe cluster, got %v", matchIDs)
 	}
+}
+
+func BenchmarkGetTFTMatchByID(b *testing.B) {
+	newFakeAPI(b)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, err := GetTFTMatchByID(matchIDs[0])
+		_, err := GetTFTMatchByID("NA1_5359015242")
 		if err != nil {
 			b.Fatalf("Benchmark failed: %v", err)
 		}
@@ -131,18 +118,11 @@ func BenchmarkGetTFTMatchByID(b *testing.B) {
 }
 
 func BenchmarkGetTFTMatchIDsByPUUIDSimple(b *testing.B) {
-	if os.Getenv("RIOT_API_KEY") == "" {
-		b.Skip("RIOT_API_KEY not set")
-	}
-
-	account, err := GetAccountByRiotId("mubs", "NA1")
-	if err != nil {
-		b.Fatalf("Failed to get account: %v", err)
-	}
+	newFakeAPI(b)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, err := GetTFTMatchIDsByPUUIDSimple(account.PUUID)
+		_, err := GetTFTMatchIDsByPUUIDSimple(testPUUID)
 		if err != nil {
 			b.Fatalf("Benchmark failed: %v", err)
 		}
@@ -150,18 +130,11 @@ func BenchmarkGetTFTMatchIDsByPUUIDSimple(b *testing.B) {
 }
 
 func BenchmarkGetTFTMatchIDsByPUUID(b *testing.B) {
-	if os.Getenv("RIOT_API_KEY") == "" {
-		b.Skip("RIOT_API_KEY not set")
-	}
-
-	account, err := GetAccountByRiotId("mubs", "NA1")
-	if err != nil {
-		b.Fatalf("Failed to get account: %v", err)
-	}
+	newFakeAPI(b)
 
 	b.ResetTimer()
 	for i := 0; i < b.N; i++ {
-		_, err := GetTFTMatchIDsByPUUID(account.PUUID, 0, 10, nil, nil)
+		_, err := GetTFTMatchIDsByPUUID(testPUUID, 0, 10, nil, nil)
 		if err != nil {
 			b.Fatalf("Benchmark failed: %v", err)
 		}
@@ -169,21 +142,10 @@ func BenchmarkGetTFTMatchIDsByPUUID(b *testing.B) {
 }
 
 func TestGetActiveTFTGameByPUUID(t *testing.T) {
-	if os.Getenv("RIOT_API_KEY") == "" {
-		t.Skip("RIOT_API_KEY not set")
-	}
+	newFakeAPI(t)
 
-	account, err := GetAccountByRiotId("mubs", "NA1")
+	info, err := GetActiveTFTGameByPUUIDWithRegion(testPUUID, "NA1")
 	if err != nil {
-		t.Fatalf("Failed to get account for test: %v", err)
-	}
-
-	info, err := GetActiveTFTGameByPUUIDWithRegion(account.PUUID, "NA1")
-	if err != nil {
-		// 404 indicates the player is not currently in an active game; treat as non-fatal/skip
-		if strings.Contains(err.Error(), "status 404") {
-			t.Skip("Player is not currently in an active TFT game (404)")
-		}
 		t.Fatalf("Failed to get active TFT game: %v", err)
 	}
 
@@ -196,7 +158,7 @@ func TestGetActiveTFTGameByPUUID(t *testing.T) {
 
 	found := false
 	for _, p := range info.Participants {
-		if p.PUUID == account.PUUID {
+		if p.PUUID == testPUUID {
 			found = true
 			break
 		}
@@ -207,12 +169,85 @@ func TestGetActiveTFTGameByPUUID(t *testing.T) {
 }
 
 func TestGetActiveTFTGameByPUUID_InvalidPUUID(t *testing.T) {
-	if os.Getenv("RIOT_API_KEY") == "" {
-		t.Skip("RIOT_API_KEY not set")
-	}
+	newFakeAPI(t)
 
 	_, err := GetActiveTFTGameByPUUIDWithRegion("invalid-puuid", "NA1")
-	if err == nil {
-		t.Error("Expected error for invalid PUUID")
+	if err == nil || !strings.Contains(err.Error(), "status 404") {
+		t.Errorf("Expected 404 for invalid PUUID, got %v", err)
+	}
+}
+
+func TestGetActiveTFTGameByPUUID_DetectsPlatform(t *testing.T) {
+	server := newFakeAPI(t)
+
+	info, err := GetActiveTFTGameByPUUID(testPUUID)
+	if err != nil {
+		t.Fatalf("Failed to find active game without a region: %v", err)
+	}
+	if info.PlatformID != "NA1" {
+		t.Errorf("Expected NA1 game, got %s", info.PlatformID)
+	}
+
+	// A rate-limited probe moves on to the next cluster, then falls back to scanning platforms
+	server.Inject(riottest.Fault{Path: "/tft/match/", Status: 429})
+	if _, err := GetActiveTFTGameByPUUID(testPUUID); err != nil {
+		t.Errorf("Expected platform scan fallback to find the game, got %v", err)
+	}
+}
+
+func TestDetectPlatform(t *testing.T) {
+	server := newFakeAPI(t)
+	if err := server.AddMatch([]byte(`{"metadata":{"match_id":"EUW1_100"},"info":{"participants":[{"puuid":"eu-player"}]}}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	for puuid, expected := range map[string]string{testPUUID: "NA1", "eu-player": "EUW1"} {
+		platform, err := DetectPlatform(puuid)
+		if err != nil || platform != expected {
+			t.Errorf("Expected %s, got %q (%v)", expected, platform, err)
+		}
+	}
+
+	if _, err := DetectPlatform("nobody"); err == nil || !strings.Contains(err.Error(), "status 404") {
+		t.Errorf("Expected a 404 for a player without matches, got %v", err)
+	}
+}
+
+func TestGetTFTMatchByID_RoutesEveryPlatform(t *testing.T) {
+	server := newFakeAPI(t)
+
+	// SEA platforms, OCE included, have their own cluster; LA1/LA2 are LAN/LAS match prefixes
+	for _, matchID := range []string{"OC1_1", "SG2_2", "VN2_3", "LA1_4", "LA2_5", "ME1_6", "JP1_7"} {
+		data := []byte(`{"metadata":{"match_id":"` + matchID + `"},"info":{"participants":[{"puuid":"p-` + matchID + `"}]}}`)
+		if err := server.AddMatch(data); err != nil {
+			t.Fatal(err)
+		}
+
+		match, err := GetTFTMatchByID(matchID)
+		if err != nil {
+			t.Errorf("Failed to get %s: %v", matchID, err)
+			continue
+		}
+		if match.Metadata.MatchID != matchID {
+			t.Errorf("Expected match %s, got %s", matchID, match.Metadata.MatchID)
+		}
+	}
+}
+
+func TestGetActiveTFTGameByPUUID_DetectsSEAPlatform(t *testing.T) {
+	server := newFakeAPI(t)
+	if err := server.AddMatch([]byte(`{"metadata":{"match_id":"SG2_100"},"info":{"participants":[{"puuid":"sea-player"}]}}`)); err != nil {
+		t.Fatal(err)
+	}
+	if err := server.AddActiveGame([]byte(`{"gameId":200,"platformId":"SG2","participants":[{"puuid":"sea-player"}]}`)); err != nil {
+		t.Fatal(err)
+	}
+
+	info, err := GetActiveTFTGameByPUUID("sea-player")
+	if err != nil {
+		t.Fatalf("Failed to find SEA game without a region: %v", err)
+	}
+	if info.PlatformID != "SG2" || info.GameID != 200 {
+		t.Errorf("Expected SG2 game 200, got %s %d", info.PlatformID, info.GameID)
 	}
 }
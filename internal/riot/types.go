@@ -26,6 +26,7 @@ const (
 	RIOT_TH2_URL  = "https://th2.api.riotgames.com"
 	RIOT_TW2_URL  = "https://tw2.api.riotgames.com"
 	RIOT_VN2_URL  = "https://vn2.api.riotgames.com"
+	RIOT_ME1_URL  = "https://me1.api.riotgames.com"
 )
 
 // RegionMapping maps region codes to their platform URLs (for spectator API)
@@ -38,7 +39,9 @@ var RegionMapping = map[string]string{
 	"JP1":  RIOT_JP1_URL,
 	"BR1":  RIOT_BR1_URL,
 	"LAN":  RIOT_LAN_URL,
+	"LA1":  RIOT_LAN_URL, // platform ID used in match IDs
 	"LAS":  RIOT_LAS_URL,
+	"LA2":  RIOT_LAS_URL,
 	"OC1":  RIOT_OC1_URL,
 	"TR1":  RIOT_TR1_URL,
 	"RU":   RIOT_RU_URL,
@@ -47,24 +50,31 @@ var RegionMapping = map[string]string{
 	"TH2":  RIOT_TH2_URL,
 	"TW2":  RIOT_TW2_URL,
 	"VN2":  RIOT_VN2_URL,
+	"ME1":  RIOT_ME1_URL,
 }
 
-// RegionalRouting maps region codes to their regional routing URLs (for match history API)
+// RegionalRouting maps region codes to their regional routing URLs (for match history API).
+// Match IDs start with the platform ID, e.g. "LA1_..." for LAN, so those are accepted too.
 var RegionalRouting = map[string]string{
 	// AMERICAS regions
 	"NA1": RIOT_AMERICAS_URL,
 	"BR1": RIOT_AMERICAS_URL,
 	"LAN": RIOT_AMERICAS_URL,
+	"LA1": RIOT_AMERICAS_URL,
 	"LAS": RIOT_AMERICAS_URL,
+	"LA2": RIOT_AMERICAS_URL,
 
 	// ASIA regions
 	"KR":  RIOT_ASIA_URL,
 	"JP1": RIOT_ASIA_URL,
-	"PH2": RIOT_ASIA_URL,
-	"SG2": RIOT_ASIA_URL,
-	"TH2": RIOT_ASIA_URL,
-	"TW2": RIOT_ASIA_URL,
-	"VN2": RIOT_ASIA_URL,
+
+	// SEA regions, including OCE
+	"OC1": RIOT_SEA_URL,
+	"PH2": RIOT_SEA_URL,
+	"SG2": RIOT_SEA_URL,
+	"TH2": RIOT_SEA_URL,
+	"TW2": RIOT_SEA_URL,
+	"VN2": RIOT_SEA_URL,
 
 	// EUROPE regions
 	"EUW1": RIOT_EUROPE_URL,
@@ -72,9 +82,34 @@ var RegionalRouting = map[string]string{
 	"EUN1": RIOT_EUROPE_URL,
 	"TR1":  RIOT_EUROPE_URL,
 	"RU":   RIOT_EUROPE_URL,
+	"ME1":  RIOT_EUROPE_URL,
+}
+
+// Platform describes a server players can pick as their region
+type Platform struct {
+	Code string // region code accepted by RegionMapping, e.g. "EUW1"
+	Name string // display name, e.g. "Europe West"
+}
 
-	// OCE - also uses AMERICAS routing
-	"OC1": RIOT_AMERICAS_URL,
+// Platforms is the catalog of selectable platforms, in display order
+var Platforms = []Platform{
+	{Code: "NA1", Name: "North America"},
+	{Code: "EUW1", Name: "Europe West"},
+	{Code: "EUN1", Name: "Europe Nordic & East"},
+	{Code: "KR", Name: "Korea"},
+	{Code: "JP1", Name: "Japan"},
+	{Code: "BR1", Name: "Brazil"},
+	{Code: "LAN", Name: "Latin America North"},
+	{Code: "LAS", Name: "Latin America South"},
+	{Code: "OC1", Name: "Oceania"},
+	{Code: "TR1", Name: "Turkey"},
+	{Code: "RU", Name: "Russia"},
+	{Code: "PH2", Name: "Philippines"},
+	{Code: "SG2", Name: "Singapore"},
+	{Code: "TH2", Name: "Thailand"},
+	{Code: "TW2", Name: "Taiwan"},
+	{Code: "VN2", Name: "Vietnam"},
+	{Code: "ME1", Name: "Middle East"},
 }
 
 // GetRegionalURL returns the platform URL for a given region (for spectator API)
@@ -142,10 +177,12 @@ type InfoDto struct {
 }
 
 type ParticipantDto struct {
+	Augments             []string     `json:"augments"`
 	Companion            CompanionDto `json:"companion"`
 	GoldLeft             int          `json:"gold_left"`
 	LastRound            int          `json:"last_round"`
 	Level                int          `json:"level"`
+	PartnerGroupID       int          `json:"partner_group_id"` // Double Up only, shared by teammates
 	Placement            int          `json:"placement"`
 	PlayersEliminated    int          `json:"players_eliminated"`
 	PUUID                string       `json:"puuid"`
@@ -230,3 +267,20 @@ type GameCustomizationObject struct {
 	Category string `json:"category"`
 	Content  string `json:"content"`
 }
+
+// League TFT v1 Types
+
+type LeagueEntry struct {
+	PUUID        string `json:"puuid"`
+	LeagueID     string `json:"leagueId"`
+	QueueType    string `json:"queueType"` // e.g. "RANKED_TFT", "RANKED_TFT_DOUBLE_UP"
+	Tier         string `json:"tier"`      // e.g. "GOLD", "MASTER"
+	Rank         string `json:"rank"`      // division, "I" to "IV"
+	LeaguePoints int    `json:"leaguePoints"`
+	Wins         int    `json:"wins"`
+	Losses       int    `json:"losses"`
+	HotStreak    bool   `json:"hotStreak"`
+	Veteran      bool   `json:"veteran"`
+	FreshBlood   bool   `json:"freshBlood"`
+	Inactive     bool   `json:"inactive"`
+}
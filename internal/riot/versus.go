@@ -0,0 +1,194 @@
+package riot
+
+import (
+	"fmt"
+	"sort"
+	"time"
+)
+
+// maxSharedTraits caps how many shared traits a head-to-head reports
+const maxSharedTraits = 5
+
+// HeadToHead compares two players across the matches they played in the same lobby.
+// Double Up games where they were teammates are excluded since they share a placement.
+type HeadToHead struct {
+	PlayerA          string           `json:"playerA"`
+	PlayerB          string           `json:"playerB"`
+	Games            int              `json:"games"`
+	AWins            int              `json:"aWins"`            // games A finished above B
+	BWins            int              `json:"bWins"`            // games B finished above A
+	AvgPlacementA    float64          `json:"avgPlacementA"`    // 1-8
+	AvgPlacementB    float64          `json:"avgPlacementB"`    // 1-8
+	AvgPlacementDiff float64          `json:"avgPlacementDiff"` // A minus B; negative means A places better
+	TraitOverlap     float64          `json:"traitOverlap"`     // 0-1, average share of active traits both boards ran
+	SharedTraits     []TraitFrequency `json:"sharedTraits"`     // traits both ran in the same game, by frequency
+	MatchIDs         []string         `json:"matchIds"`         // shared matches, oldest first
+	LastUpdated      time.Time        `json:"lastUpdated"`
+}
+
+// AnalyzeHeadToHead finds matches two players shared, from the intersection of
+// their recent histories and any archived matches containing both.
+func (pa *ProfileAnalyzer) AnalyzeHeadToHead(puuidA, puuidB string) (*HeadToHead, error) {
+	opts := pa.normalizeOptions(AnalysisOptions{})
+	idsA, _, err := pa.listMatchIDs(puuidA, opts)
+	if err != nil {
+		return nil, err
+	}
+	idsB, _, err := pa.listMatchIDs(puuidB, opts)
+	if err != nil {
+		return nil, err
+	}
+
+	var matches []*MatchDto
+	seen := make(map[string]bool)
+	for _, id := range intersectIDs(idsA, idsB) {
+		seen[id] = true
+		match, err := pa.getMatch(id)
+		if err != nil {
+			continue // skip failed matches
+		}
+		matches = append(matches, match)
+	}
+
+	// Older shared games may only be in the archive
+	if pa.Archive != nil {
+		archivedA, errA := pa.Archive.MatchIDsFor(puuidA)
+		archivedB, errB := pa.Archive.MatchIDsFor(puuidB)
+		if errA == nil && errB == nil {
+			for _, id := range intersectIDs(archivedA, archivedB) {
+				if seen[id] {
+					continue
+				}
+				if match, err := pa.Archive.Get(id); err == nil {
+					matches = append(matches, match)
+				}
+			}
+		}
+	}
+
+	h2h := buildHeadToHead(puuidA, puuidB, matches)
+	if h2h.Games == 0 {
+		return nil, fmt.Errorf("no shared matches found")
+	}
+	return h2h, nil
+}
+
+// intersectIDs returns the IDs in both lists, in the order of the first
+func intersectIDs(a, b []string) []string {
+	inB := make(map[string]bool, len(b))
+	for _, id := range b {
+		inB[id] = true
+	}
+	var shared []string
+	for _, id := range a {
+		if inB[id] {
+			shared = append(shared, id)
+		}
+	}
+	return shared
+}
+
+// hasParticipant reports whether puuid played in a match
+func hasParticipant(match *MatchDto, puuid string) bool {
+	for _, participant := range match.Info.Participants {
+		if participant.PUUID == puuid {
+			return true
+		}
+	}
+	return false
+}
+
+// buildHeadToHead aggregates two players' results across matches they both played
+func buildHeadToHead(puuidA, puuidB string, matches []*MatchDto) *HeadToHead {
+	h2h := &HeadToHead{
+		PlayerA:     puuidA,
+		PlayerB:     puuidB,
+		LastUpdated: time.Now(),
+	}
+
+	gamesB := make(map[string]ParticipantDto)
+	for _, game := range extractPlayerGames(puuidB, matches) {
+		gamesB[game.MatchID] = game.Participant
+	}
+
+	totalA := 0
+	totalB := 0
+	overlap := 0.0
+	traitCounts := make(map[string]int)
+
+	for _, game := range extractPlayerGames(puuidA, matches) {
+		b, ok := gamesB[game.MatchID]
+		if !ok {
+			continue
+		}
+		if game.Partner.PUUID == puuidB {
+			continue // teammates, not opponents
+		}
+		a := game.Participant
+
+		h2h.Games++
+		h2h.MatchIDs = append(h2h.MatchIDs, game.MatchID)
+		totalA += a.Placement
+		totalB += b.Placement
+		if a.Placement < b.Placement {
+			h2h.AWins++
+		} else if b.Placement < a.Placement {
+			h2h.BWins++
+		}
+
+		traitsA := activeTraitSet(a)
+		traitsB := activeTraitSet(b)
+		union := len(traitsA)
+		shared := 0
+		for name := range traitsB {
+			if traitsA[name] {
+				shared++
+				traitCounts[name]++
+			} else {
+				union++
+			}
+		}
+		if union > 0 {
+			overlap += float64(shared) / float64(union)
+		}
+	}
+
+	if h2h.Games == 0 {
+		return h2h
+	}
+
+	games := float64(h2h.Games)
+	h2h.AvgPlacementA = float64(totalA) / games
+	h2h.AvgPlacementB = float64(totalB) / games
+	h2h.AvgPlacementDiff = h2h.AvgPlacementA - h2h.AvgPlacementB
+	h2h.TraitOverlap = overlap / games
+
+	for name, count := range traitCounts {
+		h2h.SharedTraits = append(h2h.SharedTraits, TraitFrequency{
+			Name:      name,
+			Frequency: float64(count) / games,
+		})
+	}
+	sort.Slice(h2h.SharedTraits, func(i, j int) bool {
+		if h2h.SharedTraits[i].Frequency != h2h.SharedTraits[j].Frequency {
+			return h2h.SharedTraits[i].Frequency > h2h.SharedTraits[j].Frequency
+		}
+		return h2h.SharedTraits[i].Name < h2h.SharedTraits[j].Name
+	})
+	if len(h2h.SharedTraits) > maxSharedTraits {
+		h2h.SharedTraits = h2h.SharedTraits[:maxSharedTraits]
+	}
+
+	return h2h
+}
+
+// activeTraitSet returns the names of a board's active traits
+func activeTraitSet(p ParticipantDto) map[string]bool {
+	traits := make(map[string]bool)
+	for _, trait := range p.Traits {
+		if trait.TierCurrent > 0 {
+			traits[trait.Name] = true
+		}
+	}
+	return traits
+}
@@ -0,0 +1,63 @@
+package riot
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+// versusTestMatch builds a match at hour h with "a" and "b" in the given placements
+func versusTestMatch(h int, placementA, placementB int, traitsA, traitsB []string) *MatchDto {
+	board := func(puuid string, placement int, traits []string) ParticipantDto {
+		p := ParticipantDto{PUUID: puuid, Placement: placement}
+		for _, name := range traits {
+			p.Traits = append(p.Traits, TraitDto{Name: name, TierCurrent: 1})
+		}
+		return p
+	}
+	return &MatchDto{
+		Metadata: MetadataDto{MatchID: fmt.Sprintf("NA1_%d", h)},
+		Info: InfoDto{
+			GameDatetime: time.Date(2025, 8, 1, h, 0, 0, 0, time.UTC).UnixMilli(),
+			QueueID:      1100,
+			Participants: []ParticipantDto{
+				board("a", placementA, traitsA),
+				board("b", placementB, traitsB),
+			},
+		},
+	}
+}
+
+func TestBuildHeadToHead(t *testing.T) {
+	matches := []*MatchDto{
+		versusTestMatch(2, 5, 2, []string{"Duelist", "Sniper"}, []string{"Duelist", "Bastion"}),
+		versusTestMatch(1, 1, 4, []string{"Duelist"}, []string{"Duelist"}),
+		versusTestMatch(3, 3, 6, nil, nil),
+		stateTestMatch(4, 1, 1100), // only "test" played, not shared
+	}
+	// Teammates in Double Up are not opponents
+	matches = append(matches, duoTestMatch(5, "b", 1, 2))
+
+	h2h := buildHeadToHead("a", "b", matches)
+	if h2h.Games != 3 {
+		t.Fatalf("Expected 3 shared games, got %d", h2h.Games)
+	}
+	if h2h.AWins != 2 || h2h.BWins != 1 {
+		t.Errorf("Expected a 2-1 record, got %d-%d", h2h.AWins, h2h.BWins)
+	}
+	if h2h.AvgPlacementA != 3.0 || h2h.AvgPlacementB != 4.0 || h2h.AvgPlacementDiff != -1.0 {
+		t.Errorf("Unexpected placements %.2f vs %.2f (diff %.2f)", h2h.AvgPlacementA, h2h.AvgPlacementB, h2h.AvgPlacementDiff)
+	}
+	if fmt.Sprint(h2h.MatchIDs) != "[NA1_1 NA1_2 NA1_3]" {
+		t.Errorf("Expected shared matches oldest first, got %v", h2h.MatchIDs)
+	}
+
+	// Game 1 overlaps fully, game 2 shares 1 of 3 traits, game 3 has no traits
+	expectedOverlap := (1.0 + 1.0/3.0) / 3.0
+	if h2h.TraitOverlap != expectedOverlap {
+		t.Errorf("Expected trait overlap %.3f, got %.3f", expectedOverlap, h2h.TraitOverlap)
+	}
+	if len(h2h.SharedTraits) != 1 || h2h.SharedTraits[0].Name != "Duelist" {
+		t.Errorf("Expected Duelist as the only shared trait, got %+v", h2h.SharedTraits)
+	}
+}
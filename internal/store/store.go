@@ -0,0 +1,172 @@
+// Package store persists small keyed collections, such as account links and
+// subscriptions, as JSON files.
+package store
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"sync"
+)
+
+// Store is a string-keyed map of V that is written to a JSON file after every change.
+// A Store opened with an empty path keeps its data in memory only.
+type Store[V any] struct {
+	mu   sync.RWMutex
+	path string
+	data map[string]V
+}
+
+// Open loads the store at path, creating its directory if needed. A missing file
+// is treated as an empty store.
+func Open[V any](path string) (*Store[V], error) {
+	s := &Store[V]{path: path, data: make(map[string]V)}
+	if path == "" {
+		return s, nil
+	}
+
+	if err := os.MkdirAll(filepath.Dir(path), 0o755); err != nil {
+		return nil, fmt.Errorf("error creating store directory: %w", err)
+	}
+
+	data, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if err := json.Unmarshal(data, &s.data); err != nil {
+		return nil, fmt.Errorf("error decoding store %s: %w", path, err)
+	}
+	if s.data == nil {
+		s.data = make(map[string]V)
+	}
+	return s, nil
+}
+
+// Get returns the value stored under key
+func (s *Store[V]) Get(key string) (V, bool) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	value, ok := s.data[key]
+	return value, ok
+}
+
+// Set stores value under key and saves the store
+func (s *Store[V]) Set(key string, value V) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, existed := s.data[key]
+	s.data[key] = value
+	if err := s.save(); err != nil {
+		// Keep memory consistent with disk
+		if existed {
+			s.data[key] = previous
+		} else {
+			delete(s.data, key)
+		}
+		return err
+	}
+	return nil
+}
+
+// Update replaces the value under key with fn's result and saves the store.
+// fn receives the current value and whether it exists.
+func (s *Store[V]) Update(key string, fn func(value V, ok bool) V) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, existed := s.data[key]
+	s.data[key] = fn(previous, existed)
+	if err := s.save(); err != nil {
+		if existed {
+			s.data[key] = previous
+		} else {
+			delete(s.data, key)
+		}
+		return err
+	}
+	return nil
+}
+
+// Delete removes key and saves the store, reporting whether it was present
+func (s *Store[V]) Delete(key string) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	previous, ok := s.data[key]
+	if !ok {
+		return false, nil
+	}
+	delete(s.data, key)
+	if err := s.save(); err != nil {
+		s.data[key] = previous
+		return false, err
+	}
+	return true, nil
+}
+
+// Keys returns every key in sorted order
+func (s *Store[V]) Keys() []string {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	keys := make([]string, 0, len(s.data))
+	for key := range s.data {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+// All returns a copy of the stored entries
+func (s *Store[V]) All() map[string]V {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+
+	entries := make(map[string]V, len(s.data))
+	for key, value := range s.data {
+		entries[key] = value
+	}
+	return entries
+}
+
+// Len returns the number of stored entries
+func (s *Store[V]) Len() int {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	return len(s.data)
+}
+
+// save writes the store to disk; the caller must hold the write lock
+func (s *Store[V]) save() error {
+	if s.path == "" {
+		return nil
+	}
+
+	data, err := json.MarshalIndent(s.data, "", "  ")
+	if err != nil {
+		return err
+	}
+
+	// Write to a temp file first so a crash never leaves a partial store
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(data); err != nil {
+		_ = tmp.Close()
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		_ = os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
@@ -0,0 +1,80 @@
+package store
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+)
+
+type testEntry struct {
+	Name  string `json:"name"`
+	Count int    `json:"count"`
+}
+
+func TestStore_PersistsAcrossOpen(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "nested", "entries.json")
+
+	s, err := Open[testEntry](path)
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if s.Len() != 0 {
+		t.Fatalf("Expected empty store, got %d entries", s.Len())
+	}
+	if err := s.Set("b", testEntry{Name: "bee", Count: 2}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := s.Set("a", testEntry{Name: "ay", Count: 1}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := s.Update("a", func(e testEntry, ok bool) testEntry {
+		e.Count++
+		return e
+	}); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+
+	reopened, err := Open[testEntry](path)
+	if err != nil {
+		t.Fatalf("Unexpected error reopening: %v", err)
+	}
+	if entry, ok := reopened.Get("a"); !ok || entry.Count != 2 {
+		t.Errorf("Expected updated entry to persist, got %+v", entry)
+	}
+	if keys := reopened.Keys(); len(keys) != 2 || keys[0] != "a" || keys[1] != "b" {
+		t.Errorf("Expected sorted keys [a b], got %v", keys)
+	}
+
+	if removed, err := reopened.Delete("b"); err != nil || !removed {
+		t.Fatalf("Expected delete to succeed, got %v %v", removed, err)
+	}
+	if removed, _ := reopened.Delete("b"); removed {
+		t.Error("Expected second delete to report nothing removed")
+	}
+	if final, _ := Open[testEntry](path); final.Len() != 1 {
+		t.Errorf("Expected delete to persist, got %d entries", final.Len())
+	}
+}
+
+func TestStore_InMemoryAndCorruptFile(t *testing.T) {
+	s, err := Open[int]("")
+	if err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	if err := s.Set("x", 1); err != nil {
+		t.Fatalf("Unexpected error: %v", err)
+	}
+	all := s.All()
+	all["x"] = 5
+	if v, _ := s.Get("x"); v != 1 {
+		t.Errorf("Expected All to return a copy, got %d", v)
+	}
+
+	path := filepath.Join(t.TempDir(), "corrupt.json")
+	if err := os.WriteFile(path, []byte("{not json"), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := Open[int](path); err == nil {
+		t.Error("Expected error opening a corrupt store")
+	}
+}